@@ -0,0 +1,74 @@
+package entities
+
+import "daily-tracker/pkg/uuid"
+
+// AggregateRoot корень агрегата, накапливающий доменные события
+// Позволяет публиковать события любой сущности одним обработчиком
+type AggregateRoot interface {
+	// AggregateID строковый идентификатор агрегата для потока событий
+	AggregateID() string
+	DomainEvents() []DomainEvent
+	// DomainEventIDs идентификаторы накопленных событий в том же порядке, что и DomainEvents
+	// ID присваивается при генерации события и не меняется до очистки,
+	// поэтому повторная публикация после сбоя отправляет события с теми же ID
+	DomainEventIDs() []string
+	ClearDomainEvents()
+}
+
+// Проверка на этапе компиляции: сущности являются корнями агрегатов
+var (
+	_ AggregateRoot = (*TaskEntry)(nil)
+	_ AggregateRoot = (*SleepEntry)(nil)
+)
+
+// EventObserver получает каждое доменное событие сразу после его генерации
+type EventObserver func(DomainEvent)
+
+// aggregateBase общая реализация списка доменных событий
+// Встраивается в сущности; нулевое значение готово к использованию
+type aggregateBase struct {
+	domainEvents []DomainEvent
+	eventIDs     []string // ID событий, параллельно domainEvents
+	observer     EventObserver
+}
+
+// RegisterObserver подписывает наблюдателя на события агрегата (nil отписывает)
+// Наблюдатель вызывается синхронно в момент генерации события, дополнительно
+// к буферизации в DomainEvents - для простых приложений без шины событий
+func (a *aggregateBase) RegisterObserver(observer EventObserver) {
+	a.observer = observer
+}
+
+// DomainEvents возвращает список доменных событий
+func (a *aggregateBase) DomainEvents() []DomainEvent {
+	return a.domainEvents
+}
+
+// DomainEventIDs возвращает идентификаторы событий в порядке DomainEvents
+func (a *aggregateBase) DomainEventIDs() []string {
+	return a.eventIDs
+}
+
+// ClearDomainEvents очищает список событий (обычно после публикации)
+func (a *aggregateBase) ClearDomainEvents() {
+	a.domainEvents = make([]DomainEvent, 0)
+	a.eventIDs = nil
+}
+
+// addDomainEvent добавляет событие в список и уведомляет наблюдателя
+func (a *aggregateBase) addDomainEvent(event DomainEvent) {
+	a.domainEvents = append(a.domainEvents, event)
+	a.eventIDs = append(a.eventIDs, uuid.NewV4())
+	if a.observer != nil {
+		a.observer(event)
+	}
+}
+
+// clone копирует список событий и их ID для Clone сущности
+// Наблюдатель привязан к исходному экземпляру (например, к UI), копия его не наследует
+func (a *aggregateBase) clone() aggregateBase {
+	return aggregateBase{
+		domainEvents: append(make([]DomainEvent, 0, len(a.domainEvents)), a.domainEvents...),
+		eventIDs:     append([]string(nil), a.eventIDs...),
+	}
+}
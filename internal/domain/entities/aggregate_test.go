@@ -0,0 +1,155 @@
+package entities
+
+import (
+	"daily-tracker/internal/domain/valueobjects"
+	"testing"
+	"time"
+)
+
+func TestAggregateRoot_DrainEvents(t *testing.T) {
+	taskEntry, err := NewTaskEntry("task-1", time.Now(), 1, "Написать отчет", valueobjects.TaskCategoryWork, 5)
+	if err != nil {
+		t.Fatalf("Failed to create task entry: %v", err)
+	}
+	taskEntry.StartTask()
+
+	date := time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)
+	bedtime := time.Date(2025, 8, 10, 23, 0, 0, 0, time.UTC)
+	wakeTime := time.Date(2025, 8, 11, 7, 0, 0, 0, time.UTC)
+	sleepEntry, err := NewSleepEntry("sleep-1", date, bedtime, wakeTime, 7)
+	if err != nil {
+		t.Fatalf("Failed to create sleep entry: %v", err)
+	}
+
+	aggregates := []AggregateRoot{taskEntry, sleepEntry}
+
+	var drained []DomainEvent
+	for _, aggregate := range aggregates {
+		drained = append(drained, aggregate.DomainEvents()...)
+		aggregate.ClearDomainEvents()
+	}
+
+	// TaskEntryCreated + TaskStarted + SleepEntryCreated
+	if len(drained) != 3 {
+		t.Errorf("Expected 3 drained events, got %d", len(drained))
+	}
+
+	for _, aggregate := range aggregates {
+		if len(aggregate.DomainEvents()) != 0 {
+			t.Errorf("Expected no events after clear for %T, got %d", aggregate, len(aggregate.DomainEvents()))
+		}
+	}
+}
+
+func TestAggregateRoot_RegisterObserver(t *testing.T) {
+	taskEntry, err := NewTaskEntry("task-1", time.Now(), 1, "Написать отчет", valueobjects.TaskCategoryWork, 5)
+	if err != nil {
+		t.Fatalf("Failed to create task entry: %v", err)
+	}
+
+	var observed []DomainEvent
+	taskEntry.RegisterObserver(func(event DomainEvent) {
+		observed = append(observed, event)
+	})
+
+	taskEntry.StartTask()
+	taskEntry.PauseTask()
+
+	// TaskEntryCreated был сгенерирован до подписки и наблюдателю не передается
+	buffered := taskEntry.DomainEvents()[1:]
+	if len(observed) != len(buffered) {
+		t.Fatalf("Expected %d observed events, got %d", len(buffered), len(observed))
+	}
+	for i := range observed {
+		if observed[i] != buffered[i] {
+			t.Errorf("Expected observed event %d to be %T, got %T", i, buffered[i], observed[i])
+		}
+	}
+
+	// Буфер событий работает как прежде
+	if len(taskEntry.DomainEvents()) != 3 {
+		t.Errorf("Expected 3 buffered events, got %d", len(taskEntry.DomainEvents()))
+	}
+}
+
+func TestAggregateRoot_RegisterObserver_SleepEntry(t *testing.T) {
+	date := time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)
+	bedtime := time.Date(2025, 8, 10, 23, 0, 0, 0, time.UTC)
+	wakeTime := time.Date(2025, 8, 11, 7, 0, 0, 0, time.UTC)
+	sleepEntry, err := NewSleepEntry("sleep-1", date, bedtime, wakeTime, 7)
+	if err != nil {
+		t.Fatalf("Failed to create sleep entry: %v", err)
+	}
+	sleepEntry.ClearDomainEvents()
+
+	var observed []string
+	sleepEntry.RegisterObserver(func(event DomainEvent) {
+		observed = append(observed, event.EventType())
+	})
+
+	sleepEntry.SetSleepLatency(20 * time.Minute)
+	sleepEntry.SetScreenUseBeforeBed(3 * time.Hour)
+
+	if len(observed) != len(sleepEntry.DomainEvents()) || len(observed) == 0 {
+		t.Fatalf("Expected observer to see all %d events, got %v", len(sleepEntry.DomainEvents()), observed)
+	}
+	for i, event := range sleepEntry.DomainEvents() {
+		if observed[i] != event.EventType() {
+			t.Errorf("Expected event %d to be %s, got %s", i, event.EventType(), observed[i])
+		}
+	}
+}
+
+func TestAggregateRoot_RegisterObserver_NilAndClone(t *testing.T) {
+	taskEntry, err := NewTaskEntry("task-1", time.Now(), 1, "Написать отчет", valueobjects.TaskCategoryWork, 5)
+	if err != nil {
+		t.Fatalf("Failed to create task entry: %v", err)
+	}
+
+	calls := 0
+	taskEntry.RegisterObserver(func(DomainEvent) { calls++ })
+
+	clone := taskEntry.Clone()
+	clone.StartTask()
+	if calls != 0 {
+		t.Errorf("Expected clone not to notify the original observer, got %d calls", calls)
+	}
+
+	taskEntry.RegisterObserver(nil)
+	taskEntry.StartTask()
+	if calls != 0 {
+		t.Errorf("Expected no calls after unregistering, got %d", calls)
+	}
+}
+
+func TestAggregateRoot_DomainEventIDs(t *testing.T) {
+	taskEntry, err := NewTaskEntry("task-1", time.Now(), 1, "Написать отчет", valueobjects.TaskCategoryWork, 5)
+	if err != nil {
+		t.Fatalf("Failed to create task entry: %v", err)
+	}
+	taskEntry.StartTask()
+
+	ids := taskEntry.DomainEventIDs()
+	if len(ids) != len(taskEntry.DomainEvents()) {
+		t.Fatalf("Expected one ID per event, got %d IDs for %d events", len(ids), len(taskEntry.DomainEvents()))
+	}
+
+	if ids[0] == ids[1] {
+		t.Error("Expected distinct IDs for distinct events")
+	}
+
+	again := taskEntry.DomainEventIDs()
+	if again[0] != ids[0] || again[1] != ids[1] {
+		t.Error("Expected IDs to stay stable between calls")
+	}
+
+	clone := taskEntry.Clone()
+	if clone.DomainEventIDs()[1] != ids[1] {
+		t.Error("Expected clone to keep event IDs")
+	}
+
+	taskEntry.ClearDomainEvents()
+	if len(taskEntry.DomainEventIDs()) != 0 {
+		t.Errorf("Expected no IDs after clear, got %d", len(taskEntry.DomainEventIDs()))
+	}
+}
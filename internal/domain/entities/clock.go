@@ -0,0 +1,95 @@
+package entities
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock источник текущего времени для сущностей и их событий
+// Позволяет сделать зависящее от времени поведение детерминированным в тестах
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock часы по умолчанию, использующие системное время
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// clock часы пакета, используемые всеми сущностями
+// clockMu защищает подмену часов от гонки с чтением в now()
+var (
+	clockMu sync.RWMutex
+	clock   Clock = systemClock{}
+)
+
+// SetClock подменяет часы пакета и возвращает функцию восстановления прежних
+// nil возвращает системные часы. Предназначено для настройки при старте и тестов,
+// а не для переключения во время работы
+func SetClock(c Clock) (restore func()) {
+	if c == nil {
+		c = systemClock{}
+	}
+
+	clockMu.Lock()
+	previous := clock
+	clock = c
+	clockMu.Unlock()
+
+	return func() {
+		clockMu.Lock()
+		defer clockMu.Unlock()
+		clock = previous
+	}
+}
+
+// now возвращает текущее время по часам пакета
+func now() time.Time {
+	clockMu.RLock()
+	c := clock
+	clockMu.RUnlock()
+	return c.Now()
+}
+
+// FutureDateTolerance насколько дата новой записи может опережать текущее время
+// Запас покрывает разницу часовых поясов и записи, заведенные с вечера на завтра
+const FutureDateTolerance = 24 * time.Hour
+
+// isTooFarInFuture проверяет, опережает ли дата текущее время больше допустимого
+func isTooFarInFuture(date time.Time) bool {
+	return date.After(now().Add(FutureDateTolerance))
+}
+
+// FixedClock часы, которые стоят на месте, пока их не передвинут
+// Используется в тестах для детерминированного времени
+type FixedClock struct {
+	mu      sync.Mutex
+	current time.Time
+}
+
+// NewFixedClock создает часы, показывающие заданное время
+func NewFixedClock(t time.Time) *FixedClock {
+	return &FixedClock{current: t}
+}
+
+func (c *FixedClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.current
+}
+
+// Set переставляет часы на заданное время
+func (c *FixedClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.current = t
+}
+
+// Advance передвигает часы вперед на d
+func (c *FixedClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.current = c.current.Add(d)
+}
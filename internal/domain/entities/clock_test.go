@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSetClock_ConcurrentWithNow(t *testing.T) {
+	fixed := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+	defer SetClock(nil)()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetClock(NewFixedClock(fixed))
+		}()
+		go func() {
+			defer wg.Done()
+			now()
+		}()
+	}
+	wg.Wait()
+
+	if !now().Equal(fixed) {
+		t.Errorf("Expected fixed time %v, got %v", fixed, now())
+	}
+}
+
+func TestSetClock_Restore(t *testing.T) {
+	fixed := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+	restore := SetClock(NewFixedClock(fixed))
+
+	if !now().Equal(fixed) {
+		t.Errorf("Expected fixed time %v, got %v", fixed, now())
+	}
+
+	restore()
+	if now().Equal(fixed) {
+		t.Error("Expected restore to bring back the previous clock")
+	}
+}
@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"daily-tracker/internal/domain/valueobjects"
+	"daily-tracker/pkg/errors"
+	"daily-tracker/pkg/uuid"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// IDGenerator стратегия выдачи идентификаторов новым сущностям
+type IDGenerator interface {
+	NewTaskEntryID() TaskEntryID
+	NewSleepEntryID() SleepEntryID
+}
+
+// Проверка на этапе компиляции, что генераторы реализуют интерфейс
+var (
+	_ IDGenerator = (*UUIDGenerator)(nil)
+	_ IDGenerator = (*SequentialIDGenerator)(nil)
+)
+
+// UUIDGenerator выдает случайные UUID версии 4 - генератор по умолчанию
+type UUIDGenerator struct{}
+
+// NewUUIDGenerator создает генератор UUID
+func NewUUIDGenerator() *UUIDGenerator {
+	return &UUIDGenerator{}
+}
+
+func (g *UUIDGenerator) NewTaskEntryID() TaskEntryID {
+	return TaskEntryID(uuid.NewV4())
+}
+
+func (g *UUIDGenerator) NewSleepEntryID() SleepEntryID {
+	return SleepEntryID(uuid.NewV4())
+}
+
+// SequentialIDGenerator выдает предсказуемые ID вида "task-1", "sleep-1"
+// Предназначен для тестов; счетчики задач и сна независимы, генератор потокобезопасен
+type SequentialIDGenerator struct {
+	mu       sync.Mutex
+	taskSeq  int
+	sleepSeq int
+}
+
+// NewSequentialIDGenerator создает последовательный генератор, начинающий с 1
+func NewSequentialIDGenerator() *SequentialIDGenerator {
+	return &SequentialIDGenerator{}
+}
+
+func (g *SequentialIDGenerator) NewTaskEntryID() TaskEntryID {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.taskSeq++
+	return TaskEntryID("task-" + strconv.Itoa(g.taskSeq))
+}
+
+func (g *SequentialIDGenerator) NewSleepEntryID() SleepEntryID {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.sleepSeq++
+	return SleepEntryID("sleep-" + strconv.Itoa(g.sleepSeq))
+}
+
+// NewTaskEntryWithGenerator создает запись задачи с ID от генератора
+// Остальные параметры и опции - как у NewTaskEntry
+func NewTaskEntryWithGenerator(
+	generator IDGenerator,
+	date time.Time,
+	dayNumber int,
+	keyTask string,
+	category valueobjects.TaskCategory,
+	stressBefore valueobjects.StressLevel,
+	opts ...TaskOption,
+) (*TaskEntry, error) {
+	if generator == nil {
+		return nil, errors.NewDomainErrorWithCode("id generator cannot be nil", errors.CodeInvalidValue)
+	}
+
+	return NewTaskEntry(generator.NewTaskEntryID(), date, dayNumber, keyTask, category, stressBefore, opts...)
+}
+
+// NewSleepEntryWithGenerator создает запись сна с ID от генератора
+// Остальные параметры и опции - как у NewSleepEntry
+func NewSleepEntryWithGenerator(
+	generator IDGenerator,
+	date time.Time,
+	bedtime, wakeTime time.Time,
+	sleepQuality valueobjects.SleepQuality,
+	opts ...SleepOption,
+) (*SleepEntry, error) {
+	if generator == nil {
+		return nil, errors.NewDomainErrorWithCode("id generator cannot be nil", errors.CodeInvalidValue)
+	}
+
+	return NewSleepEntry(generator.NewSleepEntryID(), date, bedtime, wakeTime, sleepQuality, opts...)
+}
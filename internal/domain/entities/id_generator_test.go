@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"daily-tracker/internal/domain/valueobjects"
+	"daily-tracker/pkg/errors"
+	"testing"
+	"time"
+)
+
+func TestUUIDGenerator_Unique(t *testing.T) {
+	generator := NewUUIDGenerator()
+
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		taskID := string(generator.NewTaskEntryID())
+		sleepID := string(generator.NewSleepEntryID())
+
+		if seen[taskID] || seen[sleepID] {
+			t.Fatalf("Expected unique IDs, got duplicate after %d iterations", i)
+		}
+		seen[taskID] = true
+		seen[sleepID] = true
+	}
+}
+
+func TestSequentialIDGenerator_Predictable(t *testing.T) {
+	generator := NewSequentialIDGenerator()
+
+	expectedTasks := []TaskEntryID{"task-1", "task-2", "task-3"}
+	for _, expected := range expectedTasks {
+		if id := generator.NewTaskEntryID(); id != expected {
+			t.Errorf("Expected %s, got %s", expected, id)
+		}
+	}
+
+	// Счетчик записей сна не зависит от счетчика задач
+	if id := generator.NewSleepEntryID(); id != "sleep-1" {
+		t.Errorf("Expected sleep-1, got %s", id)
+	}
+}
+
+func TestNewEntriesWithGenerator(t *testing.T) {
+	generator := NewSequentialIDGenerator()
+
+	first, err := NewTaskEntryWithGenerator(generator, time.Now(), 1, "Написать отчет", valueobjects.TaskCategoryWork, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second, _ := NewTaskEntryWithGenerator(generator, time.Now(), 2, "Проверить отчет", valueobjects.TaskCategoryWork, 4,
+		WithNotes("после обеда"))
+
+	if first.ID() != "task-1" || second.ID() != "task-2" {
+		t.Errorf("Expected task-1 and task-2, got %s and %s", first.ID(), second.ID())
+	}
+	if second.Notes() != "после обеда" {
+		t.Errorf("Expected options to be applied, got notes %q", second.Notes())
+	}
+
+	bedtime := time.Date(2025, 8, 10, 23, 0, 0, 0, time.UTC)
+	sleepEntry, err := NewSleepEntryWithGenerator(generator, bedtime, bedtime, bedtime.Add(8*time.Hour), 7)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if sleepEntry.ID() != "sleep-1" {
+		t.Errorf("Expected sleep-1, got %s", sleepEntry.ID())
+	}
+
+	if _, err := NewTaskEntryWithGenerator(nil, time.Now(), 1, "Написать отчет", valueobjects.TaskCategoryWork, 5); !errors.IsDomainError(err) {
+		t.Errorf("Expected DomainError for nil generator, got %v", err)
+	}
+}
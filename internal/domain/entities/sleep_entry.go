@@ -8,41 +8,101 @@ import (
 
 // SleepEntry представляет запись о сне
 type SleepEntry struct {
-	id                 SleepEntryID                   // Уникальный идентификатор
-	date               time.Time                      // Дата записи
-	bedtime            time.Time                      // Время отхода ко сну
-	wakeTime           time.Time                      // Время пробуждения
-	sleepLatency       time.Duration                  // Время засыпания в минутах
-	nightAwakenings    int                            // Количество пробуждений за ночь
-	totalSleepHours    float64                        // Общее время сна в часах
-	sleepQuality       valueobjects.SleepQuality      // Качество сна (0-10)
-	daytimeSleepiness  valueobjects.DaytimeSleepiness // Дневная сонливость (0-10)
-	caffeineAfterNoon  bool                           // Употребление кофеина после полудня
-	screenUseBeforeBed time.Duration                  // Время использования экранов перед сном
-	eveningFreeTime    time.Duration                  // Время отдыха вечером
-	notes              string                         // Заметки
+	id                   SleepEntryID                   // Уникальный идентификатор
+	date                 time.Time                      // Дата записи
+	bedtime              time.Time                      // Время отхода ко сну
+	wakeTime             time.Time                      // Время пробуждения
+	sleepLatency         time.Duration                  // Время засыпания в минутах
+	nightAwakenings      int                            // Количество пробуждений за ночь
+	timeAwakeDuringNight time.Duration                  // Время бодрствования во время ночных пробуждений
+	totalSleepHours      float64                        // Общее время сна в часах
+	sleepQuality         valueobjects.SleepQuality      // Качество сна (0-10)
+	daytimeSleepiness    valueobjects.DaytimeSleepiness // Дневная сонливость (0-10)
+	caffeineAfterNoon    bool                           // Употребление кофеина после полудня
+	screenUseBeforeBed   time.Duration                  // Время использования экранов перед сном
+	eveningFreeTime      time.Duration                  // Время отдыха вечером
+	notes                string                         // Заметки
+	totalSleepClamped    bool                           // Общее время сна обнулено из-за некорректных данных
+	naps                 []Nap                          // Дневной сон
+	segments             []SleepSegment                 // Дополнительные сегменты ночного сна (основной - bedtime/wakeTime)
 
 	// DDD: Domain Events
-	domainEvents []DomainEvent
+	aggregateBase
 }
 
 // SleepEntryID - строго типизированный ID
 type SleepEntryID string
 
+// SleepSegment отдельный блок ночного сна (например, при двухфазном сне)
+type SleepSegment struct {
+	Bedtime  time.Time `json:"bedtime"`
+	WakeTime time.Time `json:"wake_time"`
+}
+
+// Duration возвращает время в постели за сегмент
+func (s SleepSegment) Duration() time.Duration {
+	// Даты учтены в Bedtime и WakeTime (пробуждение раньше отхода ко сну
+	// отклоняет validateSleepTimes), поэтому достаточно разницы.
+	// Считаем по настенным часам в поясе отхода ко сну, чтобы переход
+	// на летнее/зимнее время не добавлял и не отнимал час
+	return wallClockDuration(s.Bedtime, s.WakeTime)
+}
+
+// overlaps проверяет пересечение сегментов; смежные сегменты не пересекаются
+func (s SleepSegment) overlaps(other SleepSegment) bool {
+	return s.Bedtime.Before(other.WakeTime) && other.Bedtime.Before(s.WakeTime)
+}
+
+// Nap эпизод дневного сна
+type Nap struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Duration возвращает длительность дневного сна
+func (n Nap) Duration() time.Duration {
+	return n.End.Sub(n.Start)
+}
+
+// validateNap проверяет, что дневной сон не заканчивается раньше начала
+func validateNap(start, end time.Time) error {
+	if end.Before(start) {
+		return errors.NewDomainErrorWithCode("nap end cannot be before start", errors.CodeInvalidRange)
+	}
+	return nil
+}
+
+// insufficientSleepHours порог опасно короткого сна в часах
+const insufficientSleepHours = 5.0
+
+// maxHealthyScreenUse время экранов перед сном, после которого сон считается под угрозой
+const maxHealthyScreenUse = 2 * time.Hour
+
 // Конструктор для создания новой записи сна
 func NewSleepEntry(
 	id SleepEntryID,
 	date time.Time,
 	bedtime, wakeTime time.Time,
 	sleepQuality valueobjects.SleepQuality,
+	opts ...SleepOption,
 ) (*SleepEntry, error) {
-	// Валидация на уровне домена
-	if wakeTime.Before(bedtime) {
-		// Учитываем случай, когда просыпаемся на следующий день
-		nextDay := bedtime.AddDate(0, 0, 1)
-		if wakeTime.Before(time.Date(nextDay.Year(), nextDay.Month(), nextDay.Day(), 0, 0, 0, 0, wakeTime.Location())) {
-			return nil, errors.NewDomainError("wake time cannot be before bedtime on the same day")
-		}
+	// Валидация на уровне домена: собираем все нарушения сразу
+	validation := errors.NewValidationErrors()
+
+	if isTooFarInFuture(date) {
+		validation.Add("date", "cannot be in the future")
+	}
+
+	if validateSleepTimes(bedtime, wakeTime) != nil {
+		validation.Add("wakeTime", "cannot be before bedtime on the same day")
+	}
+
+	if sleepQuality < 0 || sleepQuality > 10 {
+		validation.Add("sleepQuality", "must be between 0 and 10")
+	}
+
+	if err := validation.Err(); err != nil {
+		return nil, err
 	}
 
 	sleepEntry := &SleepEntry{
@@ -51,7 +111,12 @@ func NewSleepEntry(
 		bedtime:      bedtime,
 		wakeTime:     wakeTime,
 		sleepQuality: sleepQuality,
-		domainEvents: make([]DomainEvent, 0),
+	}
+
+	for _, opt := range opts {
+		if err := opt(sleepEntry); err != nil {
+			return nil, err
+		}
 	}
 
 	// Автоматически вычисляем общее время сна
@@ -63,17 +128,143 @@ func NewSleepEntry(
 		date:         date,
 		totalHours:   sleepEntry.totalSleepHours,
 		quality:      sleepQuality,
-		occurredOn:   time.Now(),
+		occurredOn:   now(),
 	})
 
+	sleepEntry.checkSleepEfficiency(valueobjects.SleepEfficiencyMax)
+	sleepEntry.checkInsufficientSleep(insufficientSleepHours)
+
+	// Факторы плохого сна, заданные опциями, сигнализируют так же, как и сеттеры
+	if sleepEntry.screenUseBeforeBed > maxHealthyScreenUse {
+		sleepEntry.addDomainEvent(&PoorSleepQualityDetectedEvent{
+			sleepEntryID: id,
+			reason:       PoorSleepReasonScreenTime,
+			occurredOn:   now(),
+		})
+	}
+	if sleepEntry.nightAwakenings >= 3 {
+		sleepEntry.addDomainEvent(&PoorSleepQualityDetectedEvent{
+			sleepEntryID: id,
+			reason:       PoorSleepReasonAwakenings,
+			awakenings:   sleepEntry.nightAwakenings,
+			occurredOn:   now(),
+		})
+	}
+
 	return sleepEntry, nil
 }
 
+// SleepEntryState полный набор сохраненных полей записи сна
+// Используется репозиториями для восстановления сущности из хранилища
+type SleepEntryState struct {
+	ID                   SleepEntryID                   `json:"id"`
+	Date                 time.Time                      `json:"date"`
+	Bedtime              time.Time                      `json:"bedtime"`
+	WakeTime             time.Time                      `json:"wake_time"`
+	SleepLatency         time.Duration                  `json:"sleep_latency"`
+	NightAwakenings      int                            `json:"night_awakenings"`
+	TimeAwakeDuringNight time.Duration                  `json:"time_awake_during_night,omitempty"`
+	TotalSleepHours      float64                        `json:"total_sleep_hours"`
+	SleepQuality         valueobjects.SleepQuality      `json:"sleep_quality"`
+	DaytimeSleepiness    valueobjects.DaytimeSleepiness `json:"daytime_sleepiness"`
+	CaffeineAfterNoon    bool                           `json:"caffeine_after_noon"`
+	ScreenUseBeforeBed   time.Duration                  `json:"screen_use_before_bed"`
+	EveningFreeTime      time.Duration                  `json:"evening_free_time"`
+	Notes                string                         `json:"notes"`
+	Naps                 []Nap                          `json:"naps,omitempty"`
+	Segments             []SleepSegment                 `json:"segments,omitempty"`
+}
+
+// ReconstructSleepEntry восстанавливает запись сна из сохраненного состояния
+// Не генерирует доменных событий и не пересчитывает общее время сна:
+// сохраненное значение могло быть вычислено с другой задержкой засыпания
+func ReconstructSleepEntry(state SleepEntryState) (*SleepEntry, error) {
+	if err := validateSleepTimes(state.Bedtime, state.WakeTime); err != nil {
+		return nil, err
+	}
+
+	if state.NightAwakenings < 0 {
+		return nil, errors.NewDomainErrorWithCode("night awakenings cannot be negative", errors.CodeInvalidRange)
+	}
+
+	if err := validateTimeAwakeDuringNight(state.TimeAwakeDuringNight); err != nil {
+		return nil, err
+	}
+
+	for _, nap := range state.Naps {
+		if err := validateNap(nap.Start, nap.End); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, segment := range state.Segments {
+		if err := validateSleepTimes(segment.Bedtime, segment.WakeTime); err != nil {
+			return nil, err
+		}
+	}
+
+	return &SleepEntry{
+		id:                   state.ID,
+		date:                 state.Date,
+		bedtime:              state.Bedtime,
+		wakeTime:             state.WakeTime,
+		sleepLatency:         state.SleepLatency,
+		nightAwakenings:      state.NightAwakenings,
+		timeAwakeDuringNight: state.TimeAwakeDuringNight,
+		totalSleepHours:      state.TotalSleepHours,
+		sleepQuality:         state.SleepQuality,
+		daytimeSleepiness:    state.DaytimeSleepiness,
+		caffeineAfterNoon:    state.CaffeineAfterNoon,
+		screenUseBeforeBed:   state.ScreenUseBeforeBed,
+		eveningFreeTime:      state.EveningFreeTime,
+		notes:                state.Notes,
+		naps:                 append([]Nap(nil), state.Naps...),
+		segments:             append([]SleepSegment(nil), state.Segments...),
+	}, nil
+}
+
+// State возвращает снимок полей записи для сохранения в хранилище
+func (se *SleepEntry) State() SleepEntryState {
+	return SleepEntryState{
+		ID:                   se.id,
+		Date:                 se.date,
+		Bedtime:              se.bedtime,
+		WakeTime:             se.wakeTime,
+		SleepLatency:         se.sleepLatency,
+		NightAwakenings:      se.nightAwakenings,
+		TimeAwakeDuringNight: se.timeAwakeDuringNight,
+		TotalSleepHours:      se.totalSleepHours,
+		SleepQuality:         se.sleepQuality,
+		DaytimeSleepiness:    se.daytimeSleepiness,
+		CaffeineAfterNoon:    se.caffeineAfterNoon,
+		ScreenUseBeforeBed:   se.screenUseBeforeBed,
+		EveningFreeTime:      se.eveningFreeTime,
+		Notes:                se.notes,
+		Naps:                 se.Naps(),
+		Segments:             append([]SleepSegment(nil), se.segments...),
+	}
+}
+
+// Clone возвращает независимую копию записи, включая дневной сон, сегменты
+// и список доменных событий (сами события неизменяемы и не копируются)
+func (se *SleepEntry) Clone() *SleepEntry {
+	clone := *se
+	clone.naps = append([]Nap(nil), se.naps...)
+	clone.segments = append([]SleepSegment(nil), se.segments...)
+	clone.aggregateBase = se.aggregateBase.clone()
+	return &clone
+}
+
 // Геттеры
 func (se *SleepEntry) ID() SleepEntryID {
 	return se.id
 }
 
+// AggregateID возвращает ID записи строкой (реализация AggregateRoot)
+func (se *SleepEntry) AggregateID() string {
+	return string(se.id)
+}
+
 func (se *SleepEntry) Date() time.Time {
 	return se.date
 }
@@ -86,10 +277,28 @@ func (se *SleepEntry) WakeTime() time.Time {
 	return se.wakeTime
 }
 
+func (se *SleepEntry) SleepLatency() time.Duration {
+	return se.sleepLatency
+}
+
+func (se *SleepEntry) NightAwakenings() int {
+	return se.nightAwakenings
+}
+
+func (se *SleepEntry) TimeAwakeDuringNight() time.Duration {
+	return se.timeAwakeDuringNight
+}
+
 func (se *SleepEntry) TotalSleepHours() float64 {
 	return se.totalSleepHours
 }
 
+// IsTotalSleepClamped сообщает, что расчет дал отрицательное время сна и оно обнулено
+// (например, время засыпания больше времени в постели)
+func (se *SleepEntry) IsTotalSleepClamped() bool {
+	return se.totalSleepClamped
+}
+
 func (se *SleepEntry) SleepQuality() valueobjects.SleepQuality {
 	return se.sleepQuality
 }
@@ -114,34 +323,206 @@ func (se *SleepEntry) Notes() string {
 	return se.notes
 }
 
+// Segments возвращает все сегменты ночного сна, начиная с основного
+func (se *SleepEntry) Segments() []SleepSegment {
+	segments := make([]SleepSegment, 0, len(se.segments)+1)
+	segments = append(segments, SleepSegment{Bedtime: se.bedtime, WakeTime: se.wakeTime})
+	return append(segments, se.segments...)
+}
+
+// Naps возвращает копию списка дневного сна
+func (se *SleepEntry) Naps() []Nap {
+	if len(se.naps) == 0 {
+		return nil
+	}
+	return append([]Nap(nil), se.naps...)
+}
+
 // Доменные методы с бизнес-логикой
 
 // SetSleepLatency устанавливает время засыпания
 func (se *SleepEntry) SetSleepLatency(latency time.Duration) error {
-	if latency < 0 {
-		return errors.NewDomainError("sleep latency cannot be negative")
-	}
-
-	if latency > 2*time.Hour {
-		return errors.NewDomainError("sleep latency seems too long (over 2 hours)")
+	if err := validateSleepLatency(latency); err != nil {
+		return err
 	}
 
 	oldLatency := se.sleepLatency
+	oldEfficiency := se.efficiencyBaseline()
+	oldTotalHours := se.totalSleepHours
 	se.sleepLatency = latency
 
+	// Время засыпания вычитается из общего времени сна - пересчитываем
+	se.calculateTotalSleepHours()
+
 	// Генерируем событие об изменении времени засыпания
 	if oldLatency != latency {
 		se.addDomainEvent(&SleepLatencyChangedEvent{
 			sleepEntryID: se.id,
 			oldLatency:   oldLatency,
 			newLatency:   latency,
-			occurredOn:   time.Now(),
+			occurredOn:   now(),
+		})
+	}
+
+	se.checkSleepEfficiency(oldEfficiency)
+	se.checkInsufficientSleep(oldTotalHours)
+
+	return nil
+}
+
+// SetTimeAwakeDuringNight устанавливает суммарное время бодрствования
+// во время ночных пробуждений и пересчитывает общее время сна
+func (se *SleepEntry) SetTimeAwakeDuringNight(d time.Duration) error {
+	if err := validateTimeAwakeDuringNight(d); err != nil {
+		return err
+	}
+
+	oldEfficiency := se.efficiencyBaseline()
+	oldTotalHours := se.totalSleepHours
+	se.timeAwakeDuringNight = d
+
+	// Время бодрствования не считается сном - пересчитываем
+	se.calculateTotalSleepHours()
+
+	se.checkSleepEfficiency(oldEfficiency)
+	se.checkInsufficientSleep(oldTotalHours)
+
+	return nil
+}
+
+// SetScreenUseBeforeBed устанавливает время использования экранов перед сном
+func (se *SleepEntry) SetScreenUseBeforeBed(d time.Duration) error {
+	if err := validateEveningDuration(d, "screen use before bed"); err != nil {
+		return err
+	}
+
+	se.screenUseBeforeBed = d
+
+	// Больше 2 часов экранов перед сном - фактор плохого сна
+	if d > maxHealthyScreenUse {
+		se.addDomainEvent(&PoorSleepQualityDetectedEvent{
+			sleepEntryID: se.id,
+			reason:       PoorSleepReasonScreenTime,
+			occurredOn:   now(),
 		})
 	}
 
 	return nil
 }
 
+// SetCaffeineAfterNoon отмечает употребление кофеина после полудня
+func (se *SleepEntry) SetCaffeineAfterNoon(caffeine bool) {
+	se.caffeineAfterNoon = caffeine
+}
+
+// SetEveningFreeTime устанавливает время отдыха вечером
+func (se *SleepEntry) SetEveningFreeTime(d time.Duration) error {
+	if err := validateEveningDuration(d, "evening free time"); err != nil {
+		return err
+	}
+
+	se.eveningFreeTime = d
+	return nil
+}
+
+// AddSegment добавляет сегмент ночного сна и пересчитывает общее время сна
+// Сегменты не должны пересекаться, смежные (конец одного - начало другого) допустимы
+func (se *SleepEntry) AddSegment(start, end time.Time) error {
+	if err := validateSleepTimes(start, end); err != nil {
+		return err
+	}
+
+	segment := SleepSegment{Bedtime: start, WakeTime: end}
+	for _, existing := range se.Segments() {
+		if segment.overlaps(existing) {
+			return errors.NewDomainErrorWithCode("sleep segment overlaps an existing segment", errors.CodeOverlap)
+		}
+	}
+
+	oldEfficiency := se.efficiencyBaseline()
+	se.segments = append(se.segments, segment)
+	se.calculateTotalSleepHours()
+	se.checkSleepEfficiency(oldEfficiency)
+
+	return nil
+}
+
+// UpdateBedtime исправляет время отхода ко сну и пересчитывает общее время сна
+func (se *SleepEntry) UpdateBedtime(bedtime time.Time) error {
+	return se.adjustSleepTimes(bedtime, se.wakeTime)
+}
+
+// UpdateWakeTime исправляет время пробуждения и пересчитывает общее время сна
+func (se *SleepEntry) UpdateWakeTime(wakeTime time.Time) error {
+	return se.adjustSleepTimes(se.bedtime, wakeTime)
+}
+
+// adjustSleepTimes применяет новые границы основного сна с той же проверкой, что и конструктор
+// Основной сон не должен пересекаться с дополнительными сегментами; без изменений событие не генерируется
+func (se *SleepEntry) adjustSleepTimes(bedtime, wakeTime time.Time) error {
+	if err := validateSleepTimes(bedtime, wakeTime); err != nil {
+		return err
+	}
+
+	mainSleep := SleepSegment{Bedtime: bedtime, WakeTime: wakeTime}
+	for _, segment := range se.segments {
+		if mainSleep.overlaps(segment) {
+			return errors.NewDomainErrorWithCode("sleep times overlap an existing segment", errors.CodeOverlap)
+		}
+	}
+
+	if bedtime.Equal(se.bedtime) && wakeTime.Equal(se.wakeTime) {
+		return nil
+	}
+
+	event := &SleepTimesAdjustedEvent{
+		sleepEntryID:  se.id,
+		oldBedtime:    se.bedtime,
+		newBedtime:    bedtime,
+		oldWakeTime:   se.wakeTime,
+		newWakeTime:   wakeTime,
+		oldTotalHours: se.totalSleepHours,
+		occurredOn:    now(),
+	}
+
+	oldEfficiency := se.efficiencyBaseline()
+	se.bedtime = bedtime
+	se.wakeTime = wakeTime
+	se.calculateTotalSleepHours()
+
+	event.newTotalHours = se.totalSleepHours
+	se.addDomainEvent(event)
+	se.checkSleepEfficiency(oldEfficiency)
+	se.checkInsufficientSleep(event.oldTotalHours)
+
+	return nil
+}
+
+// AddNap добавляет эпизод дневного сна
+// Ночное время сна (totalSleepHours) при этом не меняется
+func (se *SleepEntry) AddNap(start, end time.Time) error {
+	if err := validateNap(start, end); err != nil {
+		return err
+	}
+
+	se.naps = append(se.naps, Nap{Start: start, End: end})
+	return nil
+}
+
+// TotalNapHours возвращает суммарное время дневного сна в часах
+func (se *SleepEntry) TotalNapHours() float64 {
+	var total time.Duration
+	for _, nap := range se.naps {
+		total += nap.Duration()
+	}
+	return total.Hours()
+}
+
+// TotalSleepIncludingNaps возвращает ночной сон вместе с дневным в часах
+func (se *SleepEntry) TotalSleepIncludingNaps() float64 {
+	return se.totalSleepHours + se.TotalNapHours()
+}
+
 // RecordNightAwakening записывает пробуждение ночью
 func (se *SleepEntry) RecordNightAwakening() {
 	se.nightAwakenings++
@@ -150,16 +531,16 @@ func (se *SleepEntry) RecordNightAwakening() {
 	se.addDomainEvent(&NightAwakeningRecordedEvent{
 		sleepEntryID:    se.id,
 		awakeningNumber: se.nightAwakenings,
-		occurredOn:      time.Now(),
+		occurredOn:      now(),
 	})
 
 	// Если пробуждений стало много, генерируем событие плохого сна
 	if se.nightAwakenings >= 3 {
 		se.addDomainEvent(&PoorSleepQualityDetectedEvent{
 			sleepEntryID: se.id,
-			reason:       "multiple night awakenings",
+			reason:       PoorSleepReasonAwakenings,
 			awakenings:   se.nightAwakenings,
-			occurredOn:   time.Now(),
+			occurredOn:   now(),
 		})
 	}
 }
@@ -175,7 +556,7 @@ func (se *SleepEntry) SetDaytimeSleepiness(sleepiness valueobjects.DaytimeSleepi
 			sleepEntryID:  se.id,
 			oldSleepiness: oldSleepiness,
 			newSleepiness: sleepiness,
-			occurredOn:    time.Now(),
+			occurredOn:    now(),
 		})
 	}
 }
@@ -190,56 +571,246 @@ func (se *SleepEntry) UpdateSleepQuality(quality valueobjects.SleepQuality) {
 		sleepEntryID: se.id,
 		oldQuality:   oldQuality,
 		newQuality:   quality,
-		occurredOn:   time.Now(),
+		occurredOn:   now(),
 	})
 
 	// Если качество сна стало очень плохим, генерируем специальное событие
 	if quality.Int() <= 3 {
 		se.addDomainEvent(&PoorSleepQualityDetectedEvent{
 			sleepEntryID: se.id,
-			reason:       "low quality rating",
+			reason:       PoorSleepReasonLowQuality,
 			quality:      &quality,
-			occurredOn:   time.Now(),
+			occurredOn:   now(),
 		})
 	}
 }
 
-// IsSleepHealthy проверяет, является ли сон здоровым
+// SleepHealthCriteria пороги здорового сна
+// Позволяет заменить общие рекомендации индивидуальными целями
+type SleepHealthCriteria struct {
+	MinHours      float64                   // Минимум часов сна
+	MaxHours      float64                   // Максимум часов сна
+	MinQuality    valueobjects.SleepQuality // Минимальное качество сна
+	MaxAwakenings int                       // Допустимое число ночных пробуждений
+}
+
+// DefaultSleepHealthCriteria общие рекомендации: 7-9 часов, качество от 6, не больше одного пробуждения
+func DefaultSleepHealthCriteria() SleepHealthCriteria {
+	return SleepHealthCriteria{
+		MinHours:      7.0,
+		MaxHours:      9.0,
+		MinQuality:    6,
+		MaxAwakenings: 1,
+	}
+}
+
+// Validate проверяет, что пороги непротиворечивы и лежат в допустимых диапазонах
+func (c SleepHealthCriteria) Validate() error {
+	validation := errors.NewValidationErrors()
+
+	if c.MinHours < 0 || c.MaxHours > 24 {
+		validation.Add("hours", "must be between 0 and 24")
+	}
+
+	if c.MinHours > c.MaxHours {
+		validation.Add("hours", "minimum cannot exceed maximum")
+	}
+
+	if c.MinQuality < 0 || c.MinQuality > 10 {
+		validation.Add("minQuality", "must be between 0 and 10")
+	}
+
+	if c.MaxAwakenings < 0 {
+		validation.Add("maxAwakenings", "cannot be negative")
+	}
+
+	return validation.Err()
+}
+
+// IsSleepHealthy проверяет, является ли сон здоровым по общим рекомендациям
 func (se *SleepEntry) IsSleepHealthy() bool {
-	// Бизнес-правила для здорового сна
-	return se.totalSleepHours >= 7.0 &&
-		se.totalSleepHours <= 9.0 &&
-		se.sleepQuality.Int() >= 6 &&
-		se.nightAwakenings <= 1
+	healthy, _ := se.IsSleepHealthyBy(DefaultSleepHealthCriteria())
+	return healthy
+}
+
+// IsSleepHealthyBy проверяет сон по заданным порогам
+// Возвращает ошибку валидации, если пороги некорректны
+func (se *SleepEntry) IsSleepHealthyBy(criteria SleepHealthCriteria) (bool, error) {
+	if err := criteria.Validate(); err != nil {
+		return false, err
+	}
+
+	return se.totalSleepHours >= criteria.MinHours &&
+		se.totalSleepHours <= criteria.MaxHours &&
+		se.sleepQuality >= criteria.MinQuality &&
+		se.nightAwakenings <= criteria.MaxAwakenings, nil
+}
+
+// Ключи факторов качества сна, возвращаемых QualityFactors()
+const (
+	QualityFactorSufficientDuration = "sufficient_duration"
+	QualityFactorFewAwakenings      = "few_awakenings"
+	QualityFactorLowLatency         = "low_latency"
+	QualityFactorNoLateCaffeine     = "no_late_caffeine"
+	QualityFactorLimitedScreenTime  = "limited_screen_time"
+)
+
+// maxHealthySleepLatency время засыпания, которое еще считается нормальным
+const maxHealthySleepLatency = 30 * time.Minute
+
+// QualityFactors раскладывает качество сна на отдельные факторы: true - фактор в норме
+// Пороги длительности и пробуждений берутся из DefaultSleepHealthCriteria
+func (se *SleepEntry) QualityFactors() map[string]bool {
+	criteria := DefaultSleepHealthCriteria()
+
+	return map[string]bool{
+		QualityFactorSufficientDuration: se.totalSleepHours >= criteria.MinHours,
+		QualityFactorFewAwakenings:      se.nightAwakenings <= criteria.MaxAwakenings,
+		QualityFactorLowLatency:         se.sleepLatency <= maxHealthySleepLatency,
+		QualityFactorNoLateCaffeine:     !se.caffeineAfterNoon,
+		QualityFactorLimitedScreenTime:  se.screenUseBeforeBed <= maxHealthyScreenUse,
+	}
+}
+
+// validateSleepTimes проверяет, что время пробуждения не раньше отхода ко сну
+func validateSleepTimes(bedtime, wakeTime time.Time) error {
+	if wakeTime.Before(bedtime) {
+		// Учитываем случай, когда просыпаемся на следующий день
+		nextDay := bedtime.AddDate(0, 0, 1)
+		if wakeTime.Before(time.Date(nextDay.Year(), nextDay.Month(), nextDay.Day(), 0, 0, 0, 0, wakeTime.Location())) {
+			return errors.NewDomainErrorWithCode("wake time cannot be before bedtime on the same day", errors.CodeInvalidRange)
+		}
+	}
+	return nil
+}
+
+// validateSleepLatency проверяет, что время засыпания в разумных пределах (0-2 часа)
+func validateSleepLatency(latency time.Duration) error {
+	if latency < 0 {
+		return errors.NewDomainErrorWithCode("sleep latency cannot be negative", errors.CodeInvalidRange)
+	}
+
+	if latency > 2*time.Hour {
+		return errors.NewDomainErrorWithCode("sleep latency seems too long (over 2 hours)", errors.CodeInvalidRange)
+	}
+
+	return nil
+}
+
+// validateTimeAwakeDuringNight проверяет, что время ночного бодрствования в пределах суток
+func validateTimeAwakeDuringNight(d time.Duration) error {
+	if d < 0 {
+		return errors.NewDomainErrorWithCode("time awake during night cannot be negative", errors.CodeInvalidRange)
+	}
+
+	if d > 24*time.Hour {
+		return errors.NewDomainErrorWithCode("time awake during night cannot exceed 24 hours", errors.CodeInvalidRange)
+	}
+
+	return nil
+}
+
+// validateEveningDuration проверяет, что вечерняя длительность в пределах суток
+func validateEveningDuration(d time.Duration, field string) error {
+	if d < 0 {
+		return errors.NewDomainErrorWithCode(field+" cannot be negative", errors.CodeInvalidRange)
+	}
+
+	if d > 24*time.Hour {
+		return errors.NewDomainErrorWithCode(field+" cannot exceed 24 hours", errors.CodeInvalidRange)
+	}
+
+	return nil
 }
 
 // calculateTotalSleepHours вычисляет общее время сна
 func (se *SleepEntry) calculateTotalSleepHours() {
-	duration := se.wakeTime.Sub(se.bedtime)
-
-	// Если отрицательное время, значит проснулись на следующий день
-	if duration < 0 {
-		duration = duration + 24*time.Hour
+	// Вычитаем время засыпания (один раз) и время ночного бодрствования
+	// из суммарного времени в постели
+	actualSleepDuration := se.timeInBed() - se.sleepLatency - se.timeAwakeDuringNight
+
+	// Отрицательный сон невозможен: обнуляем и помечаем запись
+	se.totalSleepClamped = actualSleepDuration < 0
+	if se.totalSleepClamped {
+		actualSleepDuration = 0
 	}
 
-	// Вычитаем время засыпания из общего времени
-	actualSleepDuration := duration - se.sleepLatency
 	se.totalSleepHours = actualSleepDuration.Hours()
 }
 
-// DomainEvents возвращает список доменных событий
-func (se *SleepEntry) DomainEvents() []DomainEvent {
-	return se.domainEvents
+// timeInBed вычисляет время, проведенное в постели, по всем сегментам
+func (se *SleepEntry) timeInBed() time.Duration {
+	var duration time.Duration
+	for _, segment := range se.Segments() {
+		duration += segment.Duration()
+	}
+	return duration
+}
+
+// SleepEfficiency вычисляет эффективность сна: время сна / время в постели
+// При нулевом времени в постели возвращает 0, результат ограничен диапазоном 0-100
+func (se *SleepEntry) SleepEfficiency() valueobjects.SleepEfficiency {
+	inBed := se.timeInBed()
+	if inBed <= 0 {
+		return valueobjects.SleepEfficiencyMin
+	}
+
+	percent := se.totalSleepHours / inBed.Hours() * 100
+	if percent < valueobjects.SleepEfficiencyMin {
+		percent = valueobjects.SleepEfficiencyMin
+	}
+	if percent > valueobjects.SleepEfficiencyMax {
+		percent = valueobjects.SleepEfficiencyMax
+	}
+
+	return valueobjects.SleepEfficiency(percent)
+}
+
+// efficiencyBaseline возвращает эффективность до изменения для checkSleepEfficiency
+// Без времени в постели эффективности нет, поэтому она не считается плохой
+func (se *SleepEntry) efficiencyBaseline() valueobjects.SleepEfficiency {
+	if se.timeInBed() <= 0 {
+		return valueobjects.SleepEfficiencyMax
+	}
+	return se.SleepEfficiency()
 }
 
-// ClearDomainEvents очищает список событий
-func (se *SleepEntry) ClearDomainEvents() {
-	se.domainEvents = make([]DomainEvent, 0)
+// checkSleepEfficiency генерирует событие плохого сна, когда эффективность
+// опускается ниже порога (только при переходе через порог)
+// При нулевом времени в постели эффективность не определена и проверка пропускается
+func (se *SleepEntry) checkSleepEfficiency(previous valueobjects.SleepEfficiency) {
+	if se.timeInBed() <= 0 {
+		return
+	}
+
+	current := se.SleepEfficiency()
+	if current.IsPoor() && !previous.IsPoor() {
+		se.addDomainEvent(&PoorSleepQualityDetectedEvent{
+			sleepEntryID: se.id,
+			reason:       PoorSleepReasonLowEfficiency,
+			occurredOn:   now(),
+		})
+	}
 }
 
-// Приватный метод для добавления доменных событий
-func (se *SleepEntry) addDomainEvent(event DomainEvent) {
-	se.domainEvents = append(se.domainEvents, event)
+// checkInsufficientSleep генерирует событие, когда сон впервые стал короче insufficientSleepHours
+func (se *SleepEntry) checkInsufficientSleep(previousHours float64) {
+	if se.totalSleepHours < insufficientSleepHours && previousHours >= insufficientSleepHours {
+		se.addDomainEvent(&InsufficientSleepDetectedEvent{
+			sleepEntryID: se.id,
+			totalHours:   se.totalSleepHours,
+			occurredOn:   now(),
+		})
+	}
+}
+
+// wallClockDuration возвращает разницу между показаниями часов в поясе from
+// Например, 23:00 → 07:00 всегда дает 8 часов, даже если ночью был переход DST
+func wallClockDuration(from, to time.Time) time.Duration {
+	to = to.In(from.Location())
+	fromWall := time.Date(from.Year(), from.Month(), from.Day(), from.Hour(), from.Minute(), from.Second(), from.Nanosecond(), time.UTC)
+	toWall := time.Date(to.Year(), to.Month(), to.Day(), to.Hour(), to.Minute(), to.Second(), to.Nanosecond(), time.UTC)
+	return toWall.Sub(fromWall)
 }
 
 // Вспомогательная функция для вычисления модуля числа
@@ -277,6 +848,14 @@ func (e *SleepEntryCreatedEvent) TotalHours() float64 {
 	return e.totalHours
 }
 
+func (e *SleepEntryCreatedEvent) Date() time.Time {
+	return e.date
+}
+
+func (e *SleepEntryCreatedEvent) Quality() valueobjects.SleepQuality {
+	return e.quality
+}
+
 // SleepLatencyChangedEvent - событие изменения времени засыпания
 type SleepLatencyChangedEvent struct {
 	sleepEntryID SleepEntryID
@@ -293,6 +872,18 @@ func (e *SleepLatencyChangedEvent) EventType() string {
 	return "SleepLatencyChanged"
 }
 
+func (e *SleepLatencyChangedEvent) SleepEntryID() SleepEntryID {
+	return e.sleepEntryID
+}
+
+func (e *SleepLatencyChangedEvent) OldLatency() time.Duration {
+	return e.oldLatency
+}
+
+func (e *SleepLatencyChangedEvent) NewLatency() time.Duration {
+	return e.newLatency
+}
+
 // NightAwakeningRecordedEvent - событие записи ночного пробуждения
 type NightAwakeningRecordedEvent struct {
 	sleepEntryID    SleepEntryID
@@ -308,6 +899,23 @@ func (e *NightAwakeningRecordedEvent) EventType() string {
 	return "NightAwakeningRecorded"
 }
 
+func (e *NightAwakeningRecordedEvent) SleepEntryID() SleepEntryID {
+	return e.sleepEntryID
+}
+
+// AwakeningNumber возвращает порядковый номер пробуждения за ночь
+func (e *NightAwakeningRecordedEvent) AwakeningNumber() int {
+	return e.awakeningNumber
+}
+
+// Причины PoorSleepQualityDetectedEvent, возвращаемые Reason()
+const (
+	PoorSleepReasonScreenTime    = "excessive screen time"
+	PoorSleepReasonAwakenings    = "multiple night awakenings"
+	PoorSleepReasonLowQuality    = "low quality rating"
+	PoorSleepReasonLowEfficiency = "low sleep efficiency"
+)
+
 // PoorSleepQualityDetectedEvent - событие обнаружения плохого качества сна
 type PoorSleepQualityDetectedEvent struct {
 	sleepEntryID SleepEntryID
@@ -325,10 +933,28 @@ func (e *PoorSleepQualityDetectedEvent) EventType() string {
 	return "PoorSleepQualityDetected"
 }
 
+func (e *PoorSleepQualityDetectedEvent) SleepEntryID() SleepEntryID {
+	return e.sleepEntryID
+}
+
 func (e *PoorSleepQualityDetectedEvent) Reason() string {
 	return e.reason
 }
 
+// Awakenings возвращает число пробуждений; 0, если событие вызвано не пробуждениями
+func (e *PoorSleepQualityDetectedEvent) Awakenings() int {
+	return e.awakenings
+}
+
+// Quality возвращает копию оценки качества сна; nil, если событие вызвано не низкой оценкой
+func (e *PoorSleepQualityDetectedEvent) Quality() *valueobjects.SleepQuality {
+	if e.quality == nil {
+		return nil
+	}
+	quality := *e.quality
+	return &quality
+}
+
 // DaytimeSleepinessChangedEvent - событие изменения дневной сонливости
 type DaytimeSleepinessChangedEvent struct {
 	sleepEntryID  SleepEntryID
@@ -345,6 +971,18 @@ func (e *DaytimeSleepinessChangedEvent) EventType() string {
 	return "DaytimeSleepinessChanged"
 }
 
+func (e *DaytimeSleepinessChangedEvent) SleepEntryID() SleepEntryID {
+	return e.sleepEntryID
+}
+
+func (e *DaytimeSleepinessChangedEvent) OldSleepiness() valueobjects.DaytimeSleepiness {
+	return e.oldSleepiness
+}
+
+func (e *DaytimeSleepinessChangedEvent) NewSleepiness() valueobjects.DaytimeSleepiness {
+	return e.newSleepiness
+}
+
 // SleepQualityUpdatedEvent - событие обновления качества сна
 type SleepQualityUpdatedEvent struct {
 	sleepEntryID SleepEntryID
@@ -360,3 +998,87 @@ func (e *SleepQualityUpdatedEvent) OccurredOn() time.Time {
 func (e *SleepQualityUpdatedEvent) EventType() string {
 	return "SleepQualityUpdated"
 }
+
+func (e *SleepQualityUpdatedEvent) SleepEntryID() SleepEntryID {
+	return e.sleepEntryID
+}
+
+func (e *SleepQualityUpdatedEvent) OldQuality() valueobjects.SleepQuality {
+	return e.oldQuality
+}
+
+func (e *SleepQualityUpdatedEvent) NewQuality() valueobjects.SleepQuality {
+	return e.newQuality
+}
+
+// SleepTimesAdjustedEvent - событие исправления времени отхода ко сну или пробуждения
+type SleepTimesAdjustedEvent struct {
+	sleepEntryID  SleepEntryID
+	oldBedtime    time.Time
+	newBedtime    time.Time
+	oldWakeTime   time.Time
+	newWakeTime   time.Time
+	oldTotalHours float64
+	newTotalHours float64
+	occurredOn    time.Time
+}
+
+func (e *SleepTimesAdjustedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *SleepTimesAdjustedEvent) EventType() string {
+	return "SleepTimesAdjusted"
+}
+
+func (e *SleepTimesAdjustedEvent) SleepEntryID() SleepEntryID {
+	return e.sleepEntryID
+}
+
+func (e *SleepTimesAdjustedEvent) OldBedtime() time.Time {
+	return e.oldBedtime
+}
+
+func (e *SleepTimesAdjustedEvent) NewBedtime() time.Time {
+	return e.newBedtime
+}
+
+func (e *SleepTimesAdjustedEvent) OldWakeTime() time.Time {
+	return e.oldWakeTime
+}
+
+func (e *SleepTimesAdjustedEvent) NewWakeTime() time.Time {
+	return e.newWakeTime
+}
+
+func (e *SleepTimesAdjustedEvent) OldTotalHours() float64 {
+	return e.oldTotalHours
+}
+
+func (e *SleepTimesAdjustedEvent) NewTotalHours() float64 {
+	return e.newTotalHours
+}
+
+// InsufficientSleepDetectedEvent - событие обнаружения опасно короткого сна (меньше 5 часов)
+type InsufficientSleepDetectedEvent struct {
+	sleepEntryID SleepEntryID
+	totalHours   float64
+	occurredOn   time.Time
+}
+
+func (e *InsufficientSleepDetectedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *InsufficientSleepDetectedEvent) EventType() string {
+	return "InsufficientSleepDetected"
+}
+
+func (e *InsufficientSleepDetectedEvent) SleepEntryID() SleepEntryID {
+	return e.sleepEntryID
+}
+
+// TotalHours возвращает вычисленное время сна в часах
+func (e *InsufficientSleepDetectedEvent) TotalHours() float64 {
+	return e.totalHours
+}
@@ -0,0 +1,1066 @@
+package entities
+
+import (
+	"daily-tracker/internal/domain/valueobjects"
+	"daily-tracker/pkg/errors"
+	stderrors "errors"
+	"reflect"
+	"testing"
+	"time"
+	_ "time/tzdata" // база часовых поясов для тестов DST без зависимости от системы
+)
+
+func TestReconstructSleepEntry_RestoresStateWithoutEvents(t *testing.T) {
+	state := SleepEntryState{
+		ID:                 SleepEntryID("stored-sleep"),
+		Date:               time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC),
+		Bedtime:            time.Date(2025, 8, 11, 0, 30, 0, 0, time.UTC),
+		WakeTime:           time.Date(2025, 8, 11, 8, 0, 0, 0, time.UTC),
+		SleepLatency:       30 * time.Minute,
+		NightAwakenings:    2,
+		TotalSleepHours:    7.5, // историческое значение, не совпадает с пересчетом
+		SleepQuality:       6,
+		DaytimeSleepiness:  5,
+		CaffeineAfterNoon:  true,
+		ScreenUseBeforeBed: 90 * time.Minute,
+		EveningFreeTime:    30 * time.Minute,
+		Notes:              "Запланировать свет утром 15 минут",
+	}
+
+	sleepEntry, err := ReconstructSleepEntry(state)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(sleepEntry.DomainEvents()) != 0 {
+		t.Errorf("Expected no domain events, got %d", len(sleepEntry.DomainEvents()))
+	}
+
+	// Сохраненное общее время сна не пересчитывается
+	if sleepEntry.TotalSleepHours() != 7.5 {
+		t.Errorf("Expected stored total 7.5h, got %v", sleepEntry.TotalSleepHours())
+	}
+
+	if sleepEntry.SleepLatency() != state.SleepLatency || sleepEntry.NightAwakenings() != 2 {
+		t.Errorf("Expected latency %v and 2 awakenings, got %v and %d",
+			state.SleepLatency, sleepEntry.SleepLatency(), sleepEntry.NightAwakenings())
+	}
+
+	if !sleepEntry.CaffeineAfterNoon() || sleepEntry.ScreenUseBeforeBed() != state.ScreenUseBeforeBed {
+		t.Error("Expected evening habits to be restored")
+	}
+
+	if sleepEntry.Notes() != state.Notes {
+		t.Errorf("Expected notes %q, got %q", state.Notes, sleepEntry.Notes())
+	}
+}
+
+func TestReconstructSleepEntry_InvalidTimes(t *testing.T) {
+	state := SleepEntryState{
+		ID:       SleepEntryID("stored-sleep"),
+		Bedtime:  time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC),
+		WakeTime: time.Date(2025, 8, 11, 7, 0, 0, 0, time.UTC),
+	}
+
+	sleepEntry, err := ReconstructSleepEntry(state)
+	if err == nil {
+		t.Error("Expected error for wake time before bedtime, got nil")
+	}
+
+	if sleepEntry != nil {
+		t.Error("Expected sleepEntry to be nil when error occurs")
+	}
+}
+
+func TestNewSleepEntry_ReportsAllViolations(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+
+	sleepEntry, err := NewSleepEntry("sleep-id", bedtime, bedtime, bedtime.Add(-time.Hour), 12)
+	if sleepEntry != nil {
+		t.Error("Expected sleepEntry to be nil when error occurs")
+	}
+
+	var validation *errors.ValidationErrors
+	if !stderrors.As(err, &validation) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+
+	byField := validation.ErrorsByField()
+	if len(byField["wakeTime"]) != 1 || len(byField["sleepQuality"]) != 1 {
+		t.Errorf("Expected wakeTime and sleepQuality violations, got %v", byField)
+	}
+}
+
+func TestNewSleepEntry_FutureDate(t *testing.T) {
+	current := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+	defer SetClock(NewFixedClock(current))()
+
+	tests := []struct {
+		name        string
+		date        time.Time
+		expectError bool
+	}{
+		{"today", current, false},
+		{"within tolerance", current.Add(12 * time.Hour), false},
+		{"far future", current.AddDate(1, 0, 0), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bedtime := tt.date.Add(-8 * time.Hour)
+			_, err := NewSleepEntry("sleep-1", tt.date, bedtime, tt.date, 7)
+
+			if !tt.expectError {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			var validation *errors.ValidationErrors
+			if !stderrors.As(err, &validation) || len(validation.ErrorsByField()["date"]) != 1 {
+				t.Errorf("Expected validation error on date, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNewSleepEntry_CrossMidnightDates(t *testing.T) {
+	tests := []struct {
+		name     string
+		bedtime  time.Time
+		wakeTime time.Time
+		expected float64
+	}{
+		{
+			name:     "wake dated next day",
+			bedtime:  time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC),
+			wakeTime: time.Date(2025, 8, 12, 7, 0, 0, 0, time.UTC),
+			expected: 8,
+		},
+		{
+			name:     "bedtime after midnight",
+			bedtime:  time.Date(2025, 8, 11, 0, 30, 0, 0, time.UTC),
+			wakeTime: time.Date(2025, 8, 11, 8, 0, 0, 0, time.UTC),
+			expected: 7.5,
+		},
+		{
+			name:     "long recovery sleep is not wrapped",
+			bedtime:  time.Date(2025, 8, 11, 20, 0, 0, 0, time.UTC),
+			wakeTime: time.Date(2025, 8, 12, 22, 0, 0, 0, time.UTC),
+			expected: 26,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sleepEntry, err := NewSleepEntry("sleep-id", tt.wakeTime, tt.bedtime, tt.wakeTime, 7)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if sleepEntry.TotalSleepHours() != tt.expected {
+				t.Errorf("Expected %vh, got %vh", tt.expected, sleepEntry.TotalSleepHours())
+			}
+		})
+	}
+}
+
+func TestNewSleepEntry_SameDayEarlyWakeRejected(t *testing.T) {
+	// Пробуждение той же датой, но раньше по часам не переносится на следующий день:
+	// время пробуждения должно быть датировано утром следующего дня
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	sameDayWake := time.Date(2025, 8, 11, 7, 0, 0, 0, time.UTC)
+
+	if _, err := NewSleepEntry("sleep-id", sameDayWake, bedtime, sameDayWake, 7); !errors.IsValidationError(err) {
+		t.Errorf("Expected validation error for same-day early wake, got %v", err)
+	}
+
+	sleepEntry, err := NewSleepEntry("sleep-id", sameDayWake, bedtime, sameDayWake.AddDate(0, 0, 1), 7)
+	if err != nil {
+		t.Fatalf("Expected no error for next-day wake, got: %v", err)
+	}
+
+	if sleepEntry.TotalSleepHours() != 8 {
+		t.Errorf("Expected 8h for next-day wake, got %vh", sleepEntry.TotalSleepHours())
+	}
+}
+
+func TestCalculateTotalSleepHours_ClampsNegative(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	sleepEntry := &SleepEntry{
+		bedtime:      bedtime,
+		wakeTime:     bedtime.Add(20 * time.Minute),
+		sleepLatency: 30 * time.Minute,
+	}
+
+	sleepEntry.calculateTotalSleepHours()
+
+	if sleepEntry.TotalSleepHours() != 0 {
+		t.Errorf("Expected total to be clamped to 0, got %vh", sleepEntry.TotalSleepHours())
+	}
+
+	if !sleepEntry.IsTotalSleepClamped() {
+		t.Error("Expected clamped entry to be flagged")
+	}
+}
+
+func TestSleepEntry_SetTimeAwakeDuringNight_SubtractsWithLatency(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	wakeTime := bedtime.Add(8 * time.Hour)
+
+	tests := []struct {
+		name     string
+		latency  time.Duration
+		awake    time.Duration
+		expected float64
+		clamped  bool
+	}{
+		{"latency only", 30 * time.Minute, 0, 7.5, false},
+		{"awake only", 0, 45 * time.Minute, 7.25, false},
+		{"latency and awake", 30 * time.Minute, 90 * time.Minute, 6, false},
+		{"awake exceeds time in bed", 2 * time.Hour, 7 * time.Hour, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sleepEntry, err := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if err := sleepEntry.SetSleepLatency(tt.latency); err != nil {
+				t.Fatalf("Expected no error setting latency, got: %v", err)
+			}
+			if err := sleepEntry.SetTimeAwakeDuringNight(tt.awake); err != nil {
+				t.Fatalf("Expected no error setting awake time, got: %v", err)
+			}
+
+			if sleepEntry.TotalSleepHours() != tt.expected {
+				t.Errorf("Expected total %vh, got %vh", tt.expected, sleepEntry.TotalSleepHours())
+			}
+			if sleepEntry.IsTotalSleepClamped() != tt.clamped {
+				t.Errorf("Expected clamped %v, got %v", tt.clamped, sleepEntry.IsTotalSleepClamped())
+			}
+		})
+	}
+}
+
+func TestSleepEntry_SetTimeAwakeDuringNight_Validation(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	wakeTime := bedtime.Add(8 * time.Hour)
+	sleepEntry, err := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for _, d := range []time.Duration{-time.Minute, 25 * time.Hour} {
+		if err := sleepEntry.SetTimeAwakeDuringNight(d); !errors.IsDomainError(err) {
+			t.Errorf("Expected domain error for %v, got: %v", d, err)
+		}
+	}
+
+	if sleepEntry.TimeAwakeDuringNight() != 0 {
+		t.Errorf("Expected rejected values to be ignored, got %v", sleepEntry.TimeAwakeDuringNight())
+	}
+}
+
+func TestSleepEntry_SetTimeAwakeDuringNight_InsufficientSleepEmitsEvent(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	wakeTime := bedtime.Add(7 * time.Hour)
+	sleepEntry, err := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	sleepEntry.ClearDomainEvents()
+
+	if err := sleepEntry.SetTimeAwakeDuringNight(150 * time.Minute); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	found := false
+	for _, event := range sleepEntry.DomainEvents() {
+		if _, ok := event.(*InsufficientSleepDetectedEvent); ok {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected InsufficientSleepDetectedEvent after long night wakefulness")
+	}
+}
+
+func TestNewSleepEntry_DSTTransitions(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		bedtime  time.Time
+		wakeTime time.Time
+	}{
+		{
+			// 9 марта 2025 в 2:00 часы переводятся на 3:00 - реально прошло 7 часов
+			name:     "spring forward",
+			bedtime:  time.Date(2025, 3, 8, 23, 0, 0, 0, newYork),
+			wakeTime: time.Date(2025, 3, 9, 7, 0, 0, 0, newYork),
+		},
+		{
+			// 2 ноября 2025 в 2:00 часы переводятся на 1:00 - реально прошло 9 часов
+			name:     "fall back",
+			bedtime:  time.Date(2025, 11, 1, 23, 0, 0, 0, newYork),
+			wakeTime: time.Date(2025, 11, 2, 7, 0, 0, 0, newYork),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sleepEntry, err := NewSleepEntry("sleep-id", tt.wakeTime, tt.bedtime, tt.wakeTime, 7)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if sleepEntry.TotalSleepHours() != 8 {
+				t.Errorf("Expected 8h by wall clock, got %vh", sleepEntry.TotalSleepHours())
+			}
+		})
+	}
+}
+
+func TestSleepEntry_SleepEfficiency(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		wakeTime time.Time
+		latency  time.Duration
+		expected float64
+	}{
+		{"typical", bedtime.Add(8 * time.Hour), 30 * time.Minute, 93.75},
+		{"perfect", bedtime.Add(8 * time.Hour), 0, 100},
+		{"zero time in bed", bedtime, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sleepEntry, err := NewSleepEntry("sleep-id", tt.wakeTime, bedtime, tt.wakeTime, 7)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			sleepEntry.SetSleepLatency(tt.latency)
+
+			if sleepEntry.SleepEfficiency().Float64() != tt.expected {
+				t.Errorf("Expected efficiency %v, got %v", tt.expected, sleepEntry.SleepEfficiency())
+			}
+		})
+	}
+}
+
+func TestSleepEntry_SleepEfficiency_PoorEmitsEvent(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	wakeTime := bedtime.Add(8 * time.Hour)
+	sleepEntry, _ := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+	sleepEntry.ClearDomainEvents()
+
+	// 6.5 часа сна из 8 в постели - 81.25%
+	if err := sleepEntry.SetSleepLatency(90 * time.Minute); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if sleepEntry.TotalSleepHours() != 6.5 {
+		t.Errorf("Expected latency to reduce total to 6.5h, got %vh", sleepEntry.TotalSleepHours())
+	}
+
+	events := sleepEntry.DomainEvents()
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	poor, ok := events[1].(*PoorSleepQualityDetectedEvent)
+	if !ok || poor.Reason() != "low sleep efficiency" {
+		t.Errorf("Expected PoorSleepQualityDetected for low efficiency, got %s", events[1].EventType())
+	}
+
+	// Повторное ухудшение уже плохой эффективности не дублирует событие
+	sleepEntry.ClearDomainEvents()
+	sleepEntry.SetSleepLatency(100 * time.Minute)
+	if len(sleepEntry.DomainEvents()) != 1 {
+		t.Errorf("Expected only the latency event, got %d events", len(sleepEntry.DomainEvents()))
+	}
+}
+
+func TestSleepEntry_SleepEfficiency_ZeroTimeInBed(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	sleepEntry, err := NewSleepEntry("sleep-id", bedtime, bedtime, bedtime, 7)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Без времени в постели эффективность не считается плохой
+	for _, event := range sleepEntry.DomainEvents() {
+		if poor, ok := event.(*PoorSleepQualityDetectedEvent); ok && poor.Reason() == PoorSleepReasonLowEfficiency {
+			t.Errorf("Expected no low efficiency event for zero time in bed")
+		}
+	}
+
+	// Первое появление времени в постели с плохой эффективностью - переход через порог
+	sleepEntry.ClearDomainEvents()
+	if err := sleepEntry.UpdateWakeTime(bedtime.Add(8 * time.Hour)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if err := sleepEntry.SetSleepLatency(90 * time.Minute); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	found := false
+	for _, event := range sleepEntry.DomainEvents() {
+		if poor, ok := event.(*PoorSleepQualityDetectedEvent); ok && poor.Reason() == PoorSleepReasonLowEfficiency {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected low efficiency event once time in bed appears")
+	}
+}
+
+func TestSleepEntry_EveningHabitSetters_Validation(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	wakeTime := bedtime.Add(8 * time.Hour)
+	sleepEntry, _ := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+
+	tests := []struct {
+		name        string
+		set         func(time.Duration) error
+		value       time.Duration
+		expectError bool
+	}{
+		{"screen use valid", sleepEntry.SetScreenUseBeforeBed, 90 * time.Minute, false},
+		{"screen use negative", sleepEntry.SetScreenUseBeforeBed, -time.Minute, true},
+		{"screen use over 24h", sleepEntry.SetScreenUseBeforeBed, 25 * time.Hour, true},
+		{"free time valid", sleepEntry.SetEveningFreeTime, 2 * time.Hour, false},
+		{"free time negative", sleepEntry.SetEveningFreeTime, -time.Minute, true},
+		{"free time over 24h", sleepEntry.SetEveningFreeTime, 24*time.Hour + time.Second, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.set(tt.value)
+
+			if tt.expectError && err == nil {
+				t.Error("Expected error, got nil")
+			}
+
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+
+	// Отклоненные значения не меняют сохраненные
+	if sleepEntry.ScreenUseBeforeBed() != 90*time.Minute || sleepEntry.EveningFreeTime() != 2*time.Hour {
+		t.Errorf("Expected 1h30m screen use and 2h free time, got %v and %v",
+			sleepEntry.ScreenUseBeforeBed(), sleepEntry.EveningFreeTime())
+	}
+
+	sleepEntry.SetCaffeineAfterNoon(true)
+	if !sleepEntry.CaffeineAfterNoon() {
+		t.Error("Expected caffeine after noon to be set")
+	}
+}
+
+func TestSleepEntry_SetScreenUseBeforeBed_ExcessiveEmitsEvent(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	wakeTime := bedtime.Add(8 * time.Hour)
+	sleepEntry, _ := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+	sleepEntry.ClearDomainEvents()
+
+	// Ровно 2 часа - еще норма
+	sleepEntry.SetScreenUseBeforeBed(2 * time.Hour)
+	if len(sleepEntry.DomainEvents()) != 0 {
+		t.Fatalf("Expected no events for 2h screen use, got %d", len(sleepEntry.DomainEvents()))
+	}
+
+	sleepEntry.SetScreenUseBeforeBed(150 * time.Minute)
+
+	events := sleepEntry.DomainEvents()
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+
+	poor, ok := events[0].(*PoorSleepQualityDetectedEvent)
+	if !ok || poor.Reason() != "excessive screen time" {
+		t.Errorf("Expected PoorSleepQualityDetected for screen time, got %s", events[0].EventType())
+	}
+}
+
+func TestPoorSleepQualityDetectedEvent_Payload(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	wakeTime := bedtime.Add(8 * time.Hour)
+
+	t.Run("awakenings", func(t *testing.T) {
+		sleepEntry, _ := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+		for i := 0; i < 3; i++ {
+			sleepEntry.RecordNightAwakening()
+		}
+
+		poor := lastPoorSleepEvent(t, sleepEntry)
+		if poor.Reason() != PoorSleepReasonAwakenings || poor.Awakenings() != 3 {
+			t.Errorf("Expected awakenings reason with 3 awakenings, got %q with %d", poor.Reason(), poor.Awakenings())
+		}
+
+		if poor.Quality() != nil {
+			t.Errorf("Expected no quality for awakening-triggered event, got %d", *poor.Quality())
+		}
+
+		if poor.SleepEntryID() != "sleep-id" {
+			t.Errorf("Expected sleep entry sleep-id, got %s", poor.SleepEntryID())
+		}
+	})
+
+	t.Run("low quality", func(t *testing.T) {
+		sleepEntry, _ := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+		quality, _ := valueobjects.NewSleepQuality(2)
+		sleepEntry.UpdateSleepQuality(quality)
+
+		poor := lastPoorSleepEvent(t, sleepEntry)
+		if poor.Reason() != PoorSleepReasonLowQuality || poor.Awakenings() != 0 {
+			t.Errorf("Expected low quality reason without awakenings, got %q with %d", poor.Reason(), poor.Awakenings())
+		}
+
+		if poor.Quality() == nil || *poor.Quality() != quality {
+			t.Fatalf("Expected quality %d, got %v", quality, poor.Quality())
+		}
+
+		// Геттер возвращает копию
+		*poor.Quality() = 9
+		if *poor.Quality() != quality {
+			t.Error("Expected Quality() to return a copy")
+		}
+	})
+}
+
+func TestSleepEntryEvents_Getters(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	wakeTime := bedtime.Add(8 * time.Hour)
+	sleepEntry, _ := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+
+	created := sleepEntry.DomainEvents()[0].(*SleepEntryCreatedEvent)
+	if !created.Date().Equal(wakeTime) || created.Quality() != 7 {
+		t.Errorf("Expected created event for %v with quality 7, got %v and %d", wakeTime, created.Date(), created.Quality())
+	}
+
+	sleepEntry.SetSleepLatency(20 * time.Minute)
+	sleepEntry.RecordNightAwakening()
+	sleepEntry.SetDaytimeSleepiness(6)
+	sleepEntry.UpdateSleepQuality(5)
+
+	for _, event := range sleepEntry.DomainEvents()[1:] {
+		switch e := event.(type) {
+		case *SleepLatencyChangedEvent:
+			if e.SleepEntryID() != "sleep-id" || e.OldLatency() != 0 || e.NewLatency() != 20*time.Minute {
+				t.Errorf("Expected latency 0 -> 20m for sleep-id, got %v -> %v for %s", e.OldLatency(), e.NewLatency(), e.SleepEntryID())
+			}
+		case *NightAwakeningRecordedEvent:
+			if e.SleepEntryID() != "sleep-id" || e.AwakeningNumber() != 1 {
+				t.Errorf("Expected awakening 1 for sleep-id, got %d for %s", e.AwakeningNumber(), e.SleepEntryID())
+			}
+		case *DaytimeSleepinessChangedEvent:
+			if e.SleepEntryID() != "sleep-id" || e.OldSleepiness() != 0 || e.NewSleepiness() != 6 {
+				t.Errorf("Expected sleepiness 0 -> 6 for sleep-id, got %d -> %d for %s", e.OldSleepiness(), e.NewSleepiness(), e.SleepEntryID())
+			}
+		case *SleepQualityUpdatedEvent:
+			if e.SleepEntryID() != "sleep-id" || e.OldQuality() != 7 || e.NewQuality() != 5 {
+				t.Errorf("Expected quality 7 -> 5 for sleep-id, got %d -> %d for %s", e.OldQuality(), e.NewQuality(), e.SleepEntryID())
+			}
+		default:
+			t.Errorf("Unexpected event %s", event.EventType())
+		}
+	}
+
+	if len(sleepEntry.DomainEvents()) != 5 {
+		t.Errorf("Expected 5 events, got %d", len(sleepEntry.DomainEvents()))
+	}
+}
+
+func TestSleepEntry_UpdateSleepTimes_Recalculates(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	wakeTime := time.Date(2025, 8, 12, 7, 0, 0, 0, time.UTC)
+	sleepEntry, _ := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+	sleepEntry.SetSleepLatency(30 * time.Minute)
+	sleepEntry.ClearDomainEvents()
+
+	// Опечатка при вводе: на самом деле легли в 22:30 и встали в 6:00
+	if err := sleepEntry.UpdateBedtime(bedtime.Add(-30 * time.Minute)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if sleepEntry.TotalSleepHours() != 8 {
+		t.Errorf("Expected 8 hours after bedtime fix, got %.2f", sleepEntry.TotalSleepHours())
+	}
+
+	if err := sleepEntry.UpdateWakeTime(wakeTime.Add(-time.Hour)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if sleepEntry.TotalSleepHours() != 7 {
+		t.Errorf("Expected 7 hours after wake time fix, got %.2f", sleepEntry.TotalSleepHours())
+	}
+
+	events := sleepEntry.DomainEvents()
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	adjusted, ok := events[1].(*SleepTimesAdjustedEvent)
+	if !ok {
+		t.Fatalf("Expected SleepTimesAdjusted, got %s", events[1].EventType())
+	}
+	if !adjusted.OldWakeTime().Equal(wakeTime) || !adjusted.NewWakeTime().Equal(wakeTime.Add(-time.Hour)) {
+		t.Errorf("Expected wake time %v -> %v, got %v -> %v",
+			wakeTime, wakeTime.Add(-time.Hour), adjusted.OldWakeTime(), adjusted.NewWakeTime())
+	}
+	if adjusted.OldTotalHours() != 8 || adjusted.NewTotalHours() != 7 {
+		t.Errorf("Expected total hours 8 -> 7, got %.2f -> %.2f", adjusted.OldTotalHours(), adjusted.NewTotalHours())
+	}
+
+	// Повторная установка того же времени ничего не меняет
+	sleepEntry.ClearDomainEvents()
+	sleepEntry.UpdateWakeTime(sleepEntry.WakeTime())
+	if len(sleepEntry.DomainEvents()) != 0 {
+		t.Errorf("Expected no events for unchanged time, got %d", len(sleepEntry.DomainEvents()))
+	}
+}
+
+func TestSleepEntry_UpdateSleepTimes_Validation(t *testing.T) {
+	bedtime := time.Date(2025, 8, 12, 1, 0, 0, 0, time.UTC)
+	wakeTime := time.Date(2025, 8, 12, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		update func(se *SleepEntry) error
+		check  func(err error) bool
+	}{
+		{
+			name:   "wake before bedtime same day",
+			update: func(se *SleepEntry) error { return se.UpdateWakeTime(bedtime.Add(-time.Hour)) },
+			check:  isInvalidRange,
+		},
+		{
+			name:   "bedtime after wake same day",
+			update: func(se *SleepEntry) error { return se.UpdateBedtime(wakeTime.Add(time.Hour)) },
+			check:  isInvalidRange,
+		},
+		{
+			name:   "overlaps additional segment",
+			update: func(se *SleepEntry) error { return se.UpdateWakeTime(wakeTime.Add(5 * time.Hour)) },
+			check:  errors.IsDomainError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sleepEntry, _ := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+			sleepEntry.AddSegment(wakeTime.Add(4*time.Hour), wakeTime.Add(6*time.Hour))
+			hours := sleepEntry.TotalSleepHours()
+			sleepEntry.ClearDomainEvents()
+
+			if err := tt.update(sleepEntry); !tt.check(err) {
+				t.Fatalf("Expected validation failure, got: %v", err)
+			}
+
+			if !sleepEntry.Bedtime().Equal(bedtime) || !sleepEntry.WakeTime().Equal(wakeTime) {
+				t.Error("Expected sleep times to stay unchanged")
+			}
+
+			if sleepEntry.TotalSleepHours() != hours || len(sleepEntry.DomainEvents()) != 0 {
+				t.Errorf("Expected %.2f hours and no events, got %.2f and %d",
+					hours, sleepEntry.TotalSleepHours(), len(sleepEntry.DomainEvents()))
+			}
+		})
+	}
+}
+
+// isInvalidRange проверяет, что ошибка - DomainError с кодом CodeInvalidRange,
+// как у остальных проверок времени сна
+func isInvalidRange(err error) bool {
+	var domainErr *errors.DomainError
+	return stderrors.As(err, &domainErr) && domainErr.Code() == errors.CodeInvalidRange.String()
+}
+
+func TestNewSleepEntry_InsufficientSleep(t *testing.T) {
+	bedtime := time.Date(2025, 8, 12, 2, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		hours    time.Duration
+		expected bool
+	}{
+		{"4-hour night fires", 4 * time.Hour, true},
+		{"exactly 5 hours is enough", 5 * time.Hour, false},
+		{"7-hour night does not fire", 7 * time.Hour, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wakeTime := bedtime.Add(tt.hours)
+			sleepEntry, err := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			insufficient := findInsufficientSleepEvent(sleepEntry)
+			if (insufficient != nil) != tt.expected {
+				t.Fatalf("Expected InsufficientSleepDetected = %v, got %v", tt.expected, insufficient != nil)
+			}
+
+			if insufficient != nil && (insufficient.TotalHours() != 4 || insufficient.SleepEntryID() != "sleep-id") {
+				t.Errorf("Expected 4 hours for sleep-id, got %.2f for %s", insufficient.TotalHours(), insufficient.SleepEntryID())
+			}
+		})
+	}
+}
+
+func TestSleepEntry_SetSleepLatency_InsufficientSleep(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	wakeTime := bedtime.Add(6 * time.Hour)
+	sleepEntry, err := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	sleepEntry.ClearDomainEvents()
+
+	// 6ч в постели - 90 минут засыпания = 4.5ч, меньше insufficientSleepHours
+	if err := sleepEntry.SetSleepLatency(90 * time.Minute); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	count := 0
+	for _, event := range sleepEntry.DomainEvents() {
+		if _, ok := event.(*InsufficientSleepDetectedEvent); ok {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 InsufficientSleepDetectedEvent, got %d", count)
+	}
+
+	// Повторное изменение ниже порога не генерирует событие снова
+	sleepEntry.ClearDomainEvents()
+	sleepEntry.SetSleepLatency(100 * time.Minute)
+	for _, event := range sleepEntry.DomainEvents() {
+		if _, ok := event.(*InsufficientSleepDetectedEvent); ok {
+			t.Error("Expected no repeated InsufficientSleepDetectedEvent")
+		}
+	}
+}
+
+func TestSleepEntry_UpdateWakeTime_InsufficientSleep(t *testing.T) {
+	bedtime := time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC)
+	wakeTime := bedtime.Add(7 * time.Hour)
+	sleepEntry, _ := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+
+	sleepEntry.UpdateWakeTime(bedtime.Add(3 * time.Hour))
+
+	insufficient := findInsufficientSleepEvent(sleepEntry)
+	if insufficient == nil || insufficient.TotalHours() != 3 {
+		t.Fatalf("Expected InsufficientSleepDetected with 3 hours, got %v", insufficient)
+	}
+
+	// Сон уже недостаточный - повторного события нет
+	sleepEntry.ClearDomainEvents()
+	sleepEntry.UpdateWakeTime(bedtime.Add(4 * time.Hour))
+	if findInsufficientSleepEvent(sleepEntry) != nil {
+		t.Error("Expected no repeated event while sleep stays insufficient")
+	}
+}
+
+func TestSleepEntry_Clone_IsIndependent(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	wakeTime := bedtime.Add(8 * time.Hour)
+	original, _ := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+	napStart := time.Date(2025, 8, 12, 13, 0, 0, 0, time.UTC)
+	original.AddNap(napStart, napStart.Add(30*time.Minute))
+
+	clone := original.Clone()
+	if clone.TotalSleepHours() != original.TotalSleepHours() || len(clone.DomainEvents()) != len(original.DomainEvents()) {
+		t.Fatalf("Expected clone to match original, got %+v", clone.State())
+	}
+
+	clone.naps[0].End = napStart.Add(2 * time.Hour)
+	clone.AddSegment(wakeTime.Add(4*time.Hour), wakeTime.Add(5*time.Hour))
+	clone.UpdateSleepQuality(3)
+	clone.ClearDomainEvents()
+
+	if original.Naps()[0].Duration() != 30*time.Minute {
+		t.Errorf("Expected original nap to stay 30m, got %v", original.Naps()[0].Duration())
+	}
+
+	if original.TotalSleepHours() != 8 || len(original.Segments()) != 1 || original.SleepQuality() != 7 {
+		t.Errorf("Expected original to keep 8h, one segment and quality 7, got %.2f, %d, %d",
+			original.TotalSleepHours(), len(original.Segments()), original.SleepQuality())
+	}
+
+	if len(original.DomainEvents()) == 0 {
+		t.Error("Expected original to keep its events")
+	}
+}
+
+func TestSleepEntry_AddNap(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	wakeTime := bedtime.Add(7 * time.Hour)
+	sleepEntry, _ := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+
+	napStart := time.Date(2025, 8, 12, 13, 0, 0, 0, time.UTC)
+	if err := sleepEntry.AddNap(napStart, napStart.Add(30*time.Minute)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	napStart = time.Date(2025, 8, 12, 17, 0, 0, 0, time.UTC)
+	if err := sleepEntry.AddNap(napStart, napStart.Add(time.Hour)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(sleepEntry.Naps()) != 2 {
+		t.Errorf("Expected 2 naps, got %d", len(sleepEntry.Naps()))
+	}
+
+	if sleepEntry.TotalNapHours() != 1.5 {
+		t.Errorf("Expected 1.5 nap hours, got %v", sleepEntry.TotalNapHours())
+	}
+
+	// Ночной сон не меняется, дневной добавляется только в общий итог
+	if sleepEntry.TotalSleepHours() != 7 {
+		t.Errorf("Expected nighttime total to stay 7h, got %vh", sleepEntry.TotalSleepHours())
+	}
+
+	if sleepEntry.TotalSleepIncludingNaps() != 8.5 {
+		t.Errorf("Expected 8.5h including naps, got %vh", sleepEntry.TotalSleepIncludingNaps())
+	}
+}
+
+func TestSleepEntry_AddNap_EndBeforeStart(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	wakeTime := bedtime.Add(7 * time.Hour)
+	sleepEntry, _ := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+
+	napStart := time.Date(2025, 8, 12, 13, 0, 0, 0, time.UTC)
+	if err := sleepEntry.AddNap(napStart, napStart.Add(-time.Minute)); err == nil {
+		t.Error("Expected error for nap ending before start, got nil")
+	}
+
+	if len(sleepEntry.Naps()) != 0 {
+		t.Errorf("Expected rejected nap not to be stored, got %d naps", len(sleepEntry.Naps()))
+	}
+}
+
+func TestSleepEntry_AddSegment_Biphasic(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 22, 0, 0, 0, time.UTC)
+	wakeTime := time.Date(2025, 8, 12, 2, 0, 0, 0, time.UTC)
+	sleepEntry, _ := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+	sleepEntry.SetSleepLatency(30 * time.Minute)
+
+	// Второй сегмент начинается сразу после первого - смежные сегменты допустимы
+	if err := sleepEntry.AddSegment(wakeTime, wakeTime.Add(time.Hour)); err != nil {
+		t.Fatalf("Expected adjacent segment to be accepted, got: %v", err)
+	}
+
+	segmentStart := time.Date(2025, 8, 12, 4, 0, 0, 0, time.UTC)
+	if err := sleepEntry.AddSegment(segmentStart, segmentStart.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(sleepEntry.Segments()) != 3 {
+		t.Errorf("Expected 3 segments, got %d", len(sleepEntry.Segments()))
+	}
+
+	// 4ч + 1ч + 2ч в постели минус 30 минут засыпания
+	if sleepEntry.TotalSleepHours() != 6.5 {
+		t.Errorf("Expected 6.5h across segments, got %vh", sleepEntry.TotalSleepHours())
+	}
+}
+
+func TestSleepEntry_AddSegment_Overlapping(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 22, 0, 0, 0, time.UTC)
+	wakeTime := time.Date(2025, 8, 12, 2, 0, 0, 0, time.UTC)
+	sleepEntry, _ := NewSleepEntry("sleep-id", wakeTime, bedtime, wakeTime, 7)
+
+	tests := []struct {
+		name  string
+		start time.Time
+		end   time.Time
+	}{
+		{"overlaps end", wakeTime.Add(-time.Hour), wakeTime.Add(time.Hour)},
+		{"inside", bedtime.Add(time.Hour), bedtime.Add(2 * time.Hour)},
+		{"covers", bedtime.Add(-time.Hour), wakeTime.Add(time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := sleepEntry.AddSegment(tt.start, tt.end)
+
+			if !errors.IsDomainError(err) {
+				t.Errorf("Expected DomainError for overlapping segment, got: %v", err)
+			}
+		})
+	}
+
+	if len(sleepEntry.Segments()) != 1 || sleepEntry.TotalSleepHours() != 4 {
+		t.Errorf("Expected rejected segments to leave entry unchanged, got %d segments and %vh",
+			len(sleepEntry.Segments()), sleepEntry.TotalSleepHours())
+	}
+}
+
+func TestSleepEntry_IsSleepHealthyBy(t *testing.T) {
+	// 6.5 часов, качество 7, два пробуждения - нездоровый сон по общим рекомендациям
+	sleepEntry, err := ReconstructSleepEntry(SleepEntryState{
+		ID:              SleepEntryID("sleep-1"),
+		Bedtime:         time.Date(2025, 8, 11, 0, 30, 0, 0, time.UTC),
+		WakeTime:        time.Date(2025, 8, 11, 7, 0, 0, 0, time.UTC),
+		NightAwakenings: 2,
+		TotalSleepHours: 6.5,
+		SleepQuality:    7,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create sleep entry: %v", err)
+	}
+
+	if sleepEntry.IsSleepHealthy() {
+		t.Error("Expected sleep to be unhealthy by default criteria")
+	}
+
+	healthy, err := sleepEntry.IsSleepHealthyBy(DefaultSleepHealthCriteria())
+	if err != nil || healthy {
+		t.Errorf("Expected default criteria to match IsSleepHealthy, got %v (err: %v)", healthy, err)
+	}
+
+	custom := SleepHealthCriteria{MinHours: 6, MaxHours: 8, MinQuality: 7, MaxAwakenings: 2}
+	healthy, err = sleepEntry.IsSleepHealthyBy(custom)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !healthy {
+		t.Error("Expected sleep to be healthy by custom criteria")
+	}
+}
+
+func TestSleepEntry_QualityFactors(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    SleepEntryState
+		expected map[string]bool
+	}{
+		{
+			name: "healthy night",
+			state: SleepEntryState{
+				TotalSleepHours:    7.5,
+				SleepLatency:       15 * time.Minute,
+				ScreenUseBeforeBed: 30 * time.Minute,
+			},
+			expected: map[string]bool{
+				QualityFactorSufficientDuration: true,
+				QualityFactorFewAwakenings:      true,
+				QualityFactorLowLatency:         true,
+				QualityFactorNoLateCaffeine:     true,
+				QualityFactorLimitedScreenTime:  true,
+			},
+		},
+		{
+			name: "poor night",
+			state: SleepEntryState{
+				TotalSleepHours:    5,
+				SleepLatency:       time.Hour,
+				NightAwakenings:    3,
+				CaffeineAfterNoon:  true,
+				ScreenUseBeforeBed: 3 * time.Hour,
+			},
+			expected: map[string]bool{
+				QualityFactorSufficientDuration: false,
+				QualityFactorFewAwakenings:      false,
+				QualityFactorLowLatency:         false,
+				QualityFactorNoLateCaffeine:     false,
+				QualityFactorLimitedScreenTime:  false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.state.ID = "sleep-1"
+			tt.state.Bedtime = time.Date(2025, 8, 10, 23, 0, 0, 0, time.UTC)
+			tt.state.WakeTime = time.Date(2025, 8, 11, 7, 0, 0, 0, time.UTC)
+			sleepEntry, err := ReconstructSleepEntry(tt.state)
+			if err != nil {
+				t.Fatalf("Failed to create sleep entry: %v", err)
+			}
+
+			factors := sleepEntry.QualityFactors()
+			if !reflect.DeepEqual(factors, tt.expected) {
+				t.Errorf("Expected factors %v, got %v", tt.expected, factors)
+			}
+		})
+	}
+}
+
+func TestSleepHealthCriteria_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		criteria      SleepHealthCriteria
+		expectedField string
+	}{
+		{"min hours above max", SleepHealthCriteria{MinHours: 9, MaxHours: 7, MinQuality: 6}, "hours"},
+		{"negative hours", SleepHealthCriteria{MinHours: -1, MaxHours: 8, MinQuality: 6}, "hours"},
+		{"more than a day", SleepHealthCriteria{MinHours: 7, MaxHours: 25, MinQuality: 6}, "hours"},
+		{"quality out of range", SleepHealthCriteria{MinHours: 7, MaxHours: 9, MinQuality: 11}, "minQuality"},
+		{"negative awakenings", SleepHealthCriteria{MinHours: 7, MaxHours: 9, MaxAwakenings: -1}, "maxAwakenings"},
+	}
+
+	sleepEntry := &SleepEntry{totalSleepHours: 8, sleepQuality: 7}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			healthy, err := sleepEntry.IsSleepHealthyBy(tt.criteria)
+
+			var validation *errors.ValidationErrors
+			if !stderrors.As(err, &validation) {
+				t.Fatalf("Expected ValidationErrors, got %v", err)
+			}
+
+			if _, ok := validation.ErrorsByField()[tt.expectedField]; !ok {
+				t.Errorf("Expected error on field %s, got %v", tt.expectedField, validation.ErrorsByField())
+			}
+
+			if healthy {
+				t.Error("Expected invalid criteria to report unhealthy")
+			}
+		})
+	}
+
+	if err := DefaultSleepHealthCriteria().Validate(); err != nil {
+		t.Errorf("Expected default criteria to be valid, got %v", err)
+	}
+}
+
+// lastPoorSleepEvent возвращает последнее событие PoorSleepQualityDetected записи
+func lastPoorSleepEvent(t *testing.T, sleepEntry *SleepEntry) *PoorSleepQualityDetectedEvent {
+	t.Helper()
+
+	events := sleepEntry.DomainEvents()
+	for i := len(events) - 1; i >= 0; i-- {
+		if poor, ok := events[i].(*PoorSleepQualityDetectedEvent); ok {
+			return poor
+		}
+	}
+
+	t.Fatal("Expected a PoorSleepQualityDetected event")
+	return nil
+}
+
+// findInsufficientSleepEvent возвращает событие InsufficientSleepDetected записи или nil
+func findInsufficientSleepEvent(sleepEntry *SleepEntry) *InsufficientSleepDetectedEvent {
+	for _, event := range sleepEntry.DomainEvents() {
+		if insufficient, ok := event.(*InsufficientSleepDetectedEvent); ok {
+			return insufficient
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"daily-tracker/pkg/errors"
+	"time"
+)
+
+// SleepOption задает необязательное поле при создании записи сна
+// Опции применяются до расчета общего времени сна, поэтому задержка засыпания
+// сразу учитывается в totalSleepHours и в событии создания
+type SleepOption func(*SleepEntry) error
+
+// WithSleepLatency задает время засыпания (от 0 до 2 часов)
+func WithSleepLatency(latency time.Duration) SleepOption {
+	return func(se *SleepEntry) error {
+		if err := validateSleepLatency(latency); err != nil {
+			return err
+		}
+		se.sleepLatency = latency
+		return nil
+	}
+}
+
+// WithNightAwakenings задает количество ночных пробуждений
+func WithNightAwakenings(count int) SleepOption {
+	return func(se *SleepEntry) error {
+		if count < 0 {
+			return errors.NewDomainErrorWithCode("night awakenings cannot be negative", errors.CodeInvalidRange)
+		}
+		se.nightAwakenings = count
+		return nil
+	}
+}
+
+// WithCaffeineAfterNoon отмечает употребление кофеина после полудня
+func WithCaffeineAfterNoon(caffeine bool) SleepOption {
+	return func(se *SleepEntry) error {
+		se.caffeineAfterNoon = caffeine
+		return nil
+	}
+}
+
+// WithScreenUseBeforeBed задает время экранов перед сном
+func WithScreenUseBeforeBed(d time.Duration) SleepOption {
+	return func(se *SleepEntry) error {
+		if err := validateEveningDuration(d, "screen use before bed"); err != nil {
+			return err
+		}
+		se.screenUseBeforeBed = d
+		return nil
+	}
+}
+
+// WithSleepNotes задает заметки к записи сна
+// Имя отличается от WithNotes, который уже занят опцией записи задачи
+func WithSleepNotes(notes string) SleepOption {
+	return func(se *SleepEntry) error {
+		se.notes = notes
+		return nil
+	}
+}
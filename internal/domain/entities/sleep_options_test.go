@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewSleepEntry_WithOptions(t *testing.T) {
+	date := time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)
+	bedtime := time.Date(2025, 8, 10, 23, 0, 0, 0, time.UTC)
+	wakeTime := time.Date(2025, 8, 11, 7, 0, 0, 0, time.UTC)
+
+	sleepEntry, err := NewSleepEntry("sleep-1", date, bedtime, wakeTime, 7,
+		WithSleepLatency(30*time.Minute),
+		WithNightAwakenings(1),
+		WithCaffeineAfterNoon(true),
+		WithScreenUseBeforeBed(45*time.Minute),
+		WithSleepNotes("Поздний кофе"),
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Задержка засыпания учтена в исходном общем времени сна: 8ч - 30мин
+	if sleepEntry.TotalSleepHours() != 7.5 {
+		t.Errorf("Expected total sleep 7.5h, got %v", sleepEntry.TotalSleepHours())
+	}
+
+	created, ok := sleepEntry.DomainEvents()[0].(*SleepEntryCreatedEvent)
+	if !ok {
+		t.Fatalf("Expected first event SleepEntryCreatedEvent, got %T", sleepEntry.DomainEvents()[0])
+	}
+	if created.TotalHours() != 7.5 {
+		t.Errorf("Expected created event total 7.5h, got %v", created.TotalHours())
+	}
+
+	if sleepEntry.SleepLatency() != 30*time.Minute || sleepEntry.NightAwakenings() != 1 {
+		t.Errorf("Expected latency 30m and 1 awakening, got %v and %d",
+			sleepEntry.SleepLatency(), sleepEntry.NightAwakenings())
+	}
+	if !sleepEntry.CaffeineAfterNoon() || sleepEntry.ScreenUseBeforeBed() != 45*time.Minute {
+		t.Error("Expected evening habits to be set from options")
+	}
+	if sleepEntry.Notes() != "Поздний кофе" {
+		t.Errorf("Expected notes 'Поздний кофе', got %q", sleepEntry.Notes())
+	}
+}
+
+func TestNewSleepEntry_OptionsSignalPoorSleep(t *testing.T) {
+	date := time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)
+	bedtime := time.Date(2025, 8, 10, 23, 0, 0, 0, time.UTC)
+	wakeTime := time.Date(2025, 8, 11, 7, 0, 0, 0, time.UTC)
+
+	sleepEntry, err := NewSleepEntry("sleep-1", date, bedtime, wakeTime, 7, WithNightAwakenings(4))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	poor := lastPoorSleepEvent(t, sleepEntry)
+	if poor.Reason() != PoorSleepReasonAwakenings || poor.Awakenings() != 4 {
+		t.Errorf("Expected awakenings reason with 4 awakenings, got %q with %d", poor.Reason(), poor.Awakenings())
+	}
+}
+
+func TestNewSleepEntry_InvalidOption(t *testing.T) {
+	date := time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)
+	bedtime := time.Date(2025, 8, 10, 23, 0, 0, 0, time.UTC)
+	wakeTime := time.Date(2025, 8, 11, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		opt  SleepOption
+	}{
+		{"negative latency", WithSleepLatency(-time.Minute)},
+		{"latency over 2 hours", WithSleepLatency(3 * time.Hour)},
+		{"negative awakenings", WithNightAwakenings(-1)},
+		{"negative screen use", WithScreenUseBeforeBed(-time.Minute)},
+		{"screen use over 24 hours", WithScreenUseBeforeBed(25 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sleepEntry, err := NewSleepEntry("sleep-1", date, bedtime, wakeTime, 7, tt.opt)
+			if err == nil {
+				t.Error("Expected error for invalid option, got nil")
+			}
+			if sleepEntry != nil {
+				t.Error("Expected sleepEntry to be nil when error occurs")
+			}
+		})
+	}
+}
@@ -3,7 +3,11 @@ package entities
 import (
 	"daily-tracker/internal/domain/valueobjects"
 	"daily-tracker/pkg/errors"
+	"fmt"
+	"math"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // TaskEntry представляет запись о выполнении задачи
@@ -20,16 +24,38 @@ type TaskEntry struct {
 	activeDuration  time.Duration             // Активное время выполнения
 	continuedAfter  bool                      // Продолжалась ли после 10 мин
 	stressAfter     valueobjects.StressLevel  // Уровень стресса после
+	hasStressAfter  bool                      // Был ли записан уровень стресса после
 	distractions    time.Duration             // Время отвлечений
 	blocksCompleted int                       // Количество завершенных блоков
 	pomodoroCount   int                       // Количество помидорок
 	lightExposure   time.Duration             // Время на свету
 	energy          valueobjects.EnergyLevel  // Уровень энергии (0-10)
 	mood            valueobjects.MoodLevel    // Уровень настроения (0-10)
-	notes           string                    // Заметки
+	notes           string                    // Заметки (все заметки истории через перевод строки)
+	noteHistory     []NoteEntry               // История заметок в порядке добавления
+	completedAt     *time.Time                // Время завершения (nil, пока не завершена)
+	paused          bool                      // Поставлена ли задача на паузу
+	sessionStart    *time.Time                // Начало текущего отрезка работы (nil на паузе)
+	priority        valueobjects.TaskPriority // Приоритет (пустой, если не задан)
+	tags            []string                  // Произвольные теги в нижнем регистре
+	subtasks        []Subtask                 // Чек-лист подзадач
+	version         int                       // Версия для оптимистичной блокировки
+	loadedVersion   int                       // Версия на момент загрузки или последнего сохранения
 
 	// DDD: Domain Events для отслеживания изменений
-	domainEvents []DomainEvent
+	aggregateBase
+}
+
+// Subtask пункт чек-листа, на который разбита основная задача
+type Subtask struct {
+	Title string `json:"title"`
+	Done  bool   `json:"done"`
+}
+
+// NoteEntry заметка к задаче с моментом ее добавления
+type NoteEntry struct {
+	Text    string    `json:"text"`
+	AddedAt time.Time `json:"added_at"`
 }
 
 // TaskEntryID - строго типизированный ID (Go идиома)
@@ -42,8 +68,13 @@ type DomainEvent interface {
 	EventType() string
 }
 
+// MaxKeyTaskLength максимальная длина ключевой задачи в символах (рунах, а не байтах)
+// Можно изменить при старте приложения
+var MaxKeyTaskLength = 500
+
 // Конструктор для создания новой записи задачи
 // В Go нет ключевого слова constructor, используем функции-фабрики
+// Необязательные поля задаются опциями (WithNotes, WithEnergy и т.д.)
 func NewTaskEntry(
 	id TaskEntryID,
 	date time.Time,
@@ -51,17 +82,35 @@ func NewTaskEntry(
 	keyTask string,
 	category valueobjects.TaskCategory,
 	stressBefore valueobjects.StressLevel,
+	opts ...TaskOption,
 ) (*TaskEntry, error) {
-	// Валидация входных данных на уровне домена
+	// Валидация входных данных на уровне домена: собираем все нарушения сразу
+	validation := errors.NewValidationErrors()
+
+	keyTask = strings.TrimSpace(keyTask)
 	if keyTask == "" {
-		return nil, errors.NewDomainError("key task cannot be empty")
+		validation.Add("keyTask", "cannot be empty")
+	} else if utf8.RuneCountInString(keyTask) > MaxKeyTaskLength {
+		validation.Add("keyTask", fmt.Sprintf("cannot be longer than %d characters", MaxKeyTaskLength))
 	}
 
 	if dayNumber < 1 {
-		return nil, errors.NewDomainError("day number must be positive")
+		validation.Add("dayNumber", "must be positive")
 	}
 
-	return &TaskEntry{
+	if isTooFarInFuture(date) {
+		validation.Add("date", "cannot be in the future")
+	}
+
+	if stressBefore < valueobjects.StressLevelMin || stressBefore > valueobjects.StressLevelMax {
+		validation.Add("stressBefore", "must be between 0 and 10")
+	}
+
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+
+	taskEntry := &TaskEntry{
 		id:           id,
 		date:         date,
 		dayNumber:    dayNumber,
@@ -69,15 +118,168 @@ func NewTaskEntry(
 		category:     category,
 		stressBefore: stressBefore,
 		started:      false,
-		domainEvents: make([]DomainEvent, 0),
+	}
+
+	// Событие создания несет исходные данные, чтобы агрегат можно было восстановить из потока
+	taskEntry.addDomainEvent(&TaskEntryCreatedEvent{
+		taskEntryID:  id,
+		date:         date,
+		dayNumber:    dayNumber,
+		keyTask:      keyTask,
+		category:     category,
+		stressBefore: stressBefore,
+		occurredOn:   now(),
+	})
+
+	for _, opt := range opts {
+		if err := opt(taskEntry); err != nil {
+			return nil, err
+		}
+	}
+
+	return taskEntry, nil
+}
+
+// TaskEntryState полный набор сохраненных полей записи задачи
+// Используется репозиториями для восстановления сущности из хранилища
+type TaskEntryState struct {
+	ID              TaskEntryID               `json:"id"`
+	Date            time.Time                 `json:"date"`
+	DayNumber       int                       `json:"day_number"`
+	KeyTask         string                    `json:"key_task"`
+	Category        valueobjects.TaskCategory `json:"category"`
+	StressBefore    valueobjects.StressLevel  `json:"stress_before"`
+	Started         bool                      `json:"started"`
+	StartTime       *time.Time                `json:"start_time,omitempty"`
+	ActiveDuration  time.Duration             `json:"active_duration"`
+	ContinuedAfter  bool                      `json:"continued_after"`
+	StressAfter     valueobjects.StressLevel  `json:"stress_after"`
+	HasStressAfter  bool                      `json:"has_stress_after"`
+	Distractions    time.Duration             `json:"distractions"`
+	BlocksCompleted int                       `json:"blocks_completed"`
+	PomodoroCount   int                       `json:"pomodoro_count"`
+	LightExposure   time.Duration             `json:"light_exposure"`
+	Energy          valueobjects.EnergyLevel  `json:"energy"`
+	Mood            valueobjects.MoodLevel    `json:"mood"`
+	Notes           string                    `json:"notes"`
+	NoteHistory     []NoteEntry               `json:"note_history,omitempty"`
+	CompletedAt     *time.Time                `json:"completed_at,omitempty"`
+	Paused          bool                      `json:"paused"`
+	SessionStart    *time.Time                `json:"session_start,omitempty"`
+	Priority        valueobjects.TaskPriority `json:"priority,omitempty"`
+	Tags            []string                  `json:"tags,omitempty"`
+	Subtasks        []Subtask                 `json:"subtasks,omitempty"`
+	Version         int                       `json:"version"`
+}
+
+// ReconstructTaskEntry восстанавливает запись задачи из сохраненного состояния
+// В отличие от NewTaskEntry не генерирует доменных событий,
+// чтобы загруженный агрегат не публиковал устаревшие события повторно
+func ReconstructTaskEntry(state TaskEntryState) (*TaskEntry, error) {
+	// Нарушения собираются так же, как в NewTaskEntry
+	validation := errors.NewValidationErrors()
+
+	if state.KeyTask == "" {
+		validation.Add("keyTask", "cannot be empty")
+	}
+
+	if state.DayNumber < 1 {
+		validation.Add("dayNumber", "must be positive")
+	}
+
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+
+	return &TaskEntry{
+		id:              state.ID,
+		date:            state.Date,
+		dayNumber:       state.DayNumber,
+		keyTask:         state.KeyTask,
+		category:        state.Category,
+		stressBefore:    state.StressBefore,
+		started:         state.Started,
+		startTime:       copyTime(state.StartTime),
+		activeDuration:  state.ActiveDuration,
+		continuedAfter:  state.ContinuedAfter,
+		stressAfter:     state.StressAfter,
+		hasStressAfter:  state.HasStressAfter,
+		distractions:    state.Distractions,
+		blocksCompleted: state.BlocksCompleted,
+		pomodoroCount:   state.PomodoroCount,
+		lightExposure:   state.LightExposure,
+		energy:          state.Energy,
+		mood:            state.Mood,
+		notes:           state.Notes,
+		noteHistory:     copyNotes(state.NoteHistory),
+		completedAt:     copyTime(state.CompletedAt),
+		paused:          state.Paused,
+		sessionStart:    copyTime(state.SessionStart),
+		priority:        state.Priority,
+		tags:            copyTags(state.Tags),
+		subtasks:        copySubtasks(state.Subtasks),
+		version:         state.Version,
+		loadedVersion:   state.Version,
 	}, nil
 }
 
+// State возвращает снимок полей записи для сохранения в хранилище
+func (te *TaskEntry) State() TaskEntryState {
+	return TaskEntryState{
+		ID:              te.id,
+		Date:            te.date,
+		DayNumber:       te.dayNumber,
+		KeyTask:         te.keyTask,
+		Category:        te.category,
+		StressBefore:    te.stressBefore,
+		Started:         te.started,
+		StartTime:       copyTime(te.startTime),
+		ActiveDuration:  te.activeDuration,
+		ContinuedAfter:  te.continuedAfter,
+		StressAfter:     te.stressAfter,
+		HasStressAfter:  te.hasStressAfter,
+		Distractions:    te.distractions,
+		BlocksCompleted: te.blocksCompleted,
+		PomodoroCount:   te.pomodoroCount,
+		LightExposure:   te.lightExposure,
+		Energy:          te.energy,
+		Mood:            te.mood,
+		Notes:           te.notes,
+		NoteHistory:     copyNotes(te.noteHistory),
+		CompletedAt:     copyTime(te.completedAt),
+		Paused:          te.paused,
+		SessionStart:    copyTime(te.sessionStart),
+		Priority:        te.priority,
+		Tags:            copyTags(te.tags),
+		Subtasks:        copySubtasks(te.subtasks),
+		Version:         te.version,
+	}
+}
+
+// Clone возвращает независимую копию записи, включая указатели на время,
+// теги, подзадачи, историю заметок и список доменных событий (сами события неизменяемы и не копируются)
+func (te *TaskEntry) Clone() *TaskEntry {
+	clone := *te
+	clone.startTime = copyTime(te.startTime)
+	clone.completedAt = copyTime(te.completedAt)
+	clone.sessionStart = copyTime(te.sessionStart)
+	clone.tags = copyTags(te.tags)
+	clone.subtasks = copySubtasks(te.subtasks)
+	clone.noteHistory = copyNotes(te.noteHistory)
+	clone.aggregateBase = te.aggregateBase.clone()
+	return &clone
+}
+
 // Геттеры (в Go принято не использовать префикс Get)
 func (te *TaskEntry) ID() TaskEntryID {
 	return te.id
 }
 
+// AggregateID возвращает ID записи строкой (реализация AggregateRoot)
+func (te *TaskEntry) AggregateID() string {
+	return string(te.id)
+}
+
 func (te *TaskEntry) Date() time.Time {
 	return te.date
 }
@@ -118,6 +320,12 @@ func (te *TaskEntry) StressAfter() valueobjects.StressLevel {
 	return te.stressAfter
 }
 
+// HasStressAfter сообщает, был ли записан уровень стресса после выполнения
+// (нулевой StressAfter сам по себе допустимое значение шкалы)
+func (te *TaskEntry) HasStressAfter() bool {
+	return te.hasStressAfter
+}
+
 func (te *TaskEntry) Distractions() time.Duration {
 	return te.distractions
 }
@@ -141,52 +349,409 @@ func (te *TaskEntry) Mood() valueobjects.MoodLevel {
 	return te.mood
 }
 
+func (te *TaskEntry) Notes() string {
+	return te.notes
+}
+
+// NoteHistory возвращает копию истории заметок в порядке добавления
+func (te *TaskEntry) NoteHistory() []NoteEntry {
+	return copyNotes(te.noteHistory)
+}
+
+func (te *TaskEntry) CompletedAt() *time.Time {
+	return te.completedAt
+}
+
+func (te *TaskEntry) Paused() bool {
+	return te.paused
+}
+
+func (te *TaskEntry) Priority() valueobjects.TaskPriority {
+	return te.priority
+}
+
+// HasPriority проверяет, задан ли приоритет задачи
+func (te *TaskEntry) HasPriority() bool {
+	return te.priority != ""
+}
+
+// Tags возвращает копию тегов, чтобы вызывающий код не мог изменить сущность
+func (te *TaskEntry) Tags() []string {
+	return copyTags(te.tags)
+}
+
+// Subtasks возвращает копию чек-листа подзадач
+func (te *TaskEntry) Subtasks() []Subtask {
+	return copySubtasks(te.subtasks)
+}
+
+// HasTag проверяет наличие тега (без учета регистра и пробелов)
+func (te *TaskEntry) HasTag(tag string) bool {
+	return te.tagIndex(normalizeTag(tag)) >= 0
+}
+
+// Version возвращает номер версии записи
+// Растет при каждом изменении состояния; репозиторий по нему отклоняет устаревшие записи
+func (te *TaskEntry) Version() int {
+	return te.version
+}
+
+// LoadedVersion возвращает версию, с которой запись была загружена или последний раз сохранена
+// Репозиторий принимает запись, только если сохраненная версия совпадает с ней
+// (0 у новой записи, которой еще нет в хранилище)
+func (te *TaskEntry) LoadedVersion() int {
+	return te.loadedVersion
+}
+
+// MarkPersisted фиксирует текущую версию как сохраненную
+// Вызывается репозиторием после успешной записи, чтобы следующие правки
+// сверялись уже с новой версией
+func (te *TaskEntry) MarkPersisted() {
+	te.loadedVersion = te.version
+}
+
+// IsCompleted проверяет, завершена ли задача
+func (te *TaskEntry) IsCompleted() bool {
+	return te.completedAt != nil
+}
+
 // Доменные методы - бизнес-логика инкапсулирована в Entity
 
 // StartTask начинает выполнение задачи
 func (te *TaskEntry) StartTask() error {
 	if te.started {
-		return errors.NewDomainError("task already started")
+		return errors.NewDomainErrorWithCode("task already started", errors.CodeAlreadyStarted)
 	}
 
-	now := time.Now()
+	startedAt := now()
 	te.started = true
-	te.startTime = &now
+	te.startTime = &startedAt
+	te.sessionStart = copyTime(&startedAt)
+	te.touch()
 
 	// Генерируем доменное событие
 	te.addDomainEvent(&TaskStartedEvent{
 		taskEntryID: te.id,
-		occurredOn:  now,
+		occurredOn:  startedAt,
+	})
+
+	return nil
+}
+
+// PauseTask ставит задачу на паузу, добавляя время текущего отрезка к activeDuration
+func (te *TaskEntry) PauseTask() error {
+	if !te.started {
+		return errors.NewDomainErrorWithCode("cannot pause task: task not started", errors.CodeNotStarted)
+	}
+
+	if te.completedAt != nil {
+		return errors.NewDomainErrorWithCode("cannot pause task: task already completed", errors.CodeAlreadyCompleted)
+	}
+
+	if te.paused {
+		return errors.NewDomainErrorWithCode("task already paused", errors.CodeAlreadyPaused)
+	}
+
+	pausedAt := now()
+	if te.sessionStart != nil && pausedAt.After(*te.sessionStart) {
+		te.activeDuration += pausedAt.Sub(*te.sessionStart)
+	}
+	te.paused = true
+	te.sessionStart = nil
+	te.touch()
+
+	te.addDomainEvent(&TaskPausedEvent{
+		taskEntryID:    te.id,
+		activeDuration: te.activeDuration,
+		occurredOn:     pausedAt,
+	})
+
+	return nil
+}
+
+// ResumeTask снимает задачу с паузы и начинает новый отрезок работы
+func (te *TaskEntry) ResumeTask() error {
+	if te.completedAt != nil {
+		return errors.NewDomainErrorWithCode("cannot resume task: task already completed", errors.CodeAlreadyCompleted)
+	}
+
+	if !te.paused {
+		return errors.NewDomainErrorWithCode("cannot resume task: task not paused", errors.CodeNotPaused)
+	}
+
+	resumedAt := now()
+	te.paused = false
+	te.sessionStart = &resumedAt
+	te.touch()
+
+	te.addDomainEvent(&TaskResumedEvent{
+		taskEntryID: te.id,
+		occurredOn:  resumedAt,
+	})
+
+	return nil
+}
+
+// pomodorosPerSet количество помидорок, после которого рекомендуется длинный перерыв
+const pomodorosPerSet = 4
+
+// RecordPomodoro записывает завершенную помидорку
+// Каждые pomodorosPerSet помидорок генерируется событие о завершении подхода
+func (te *TaskEntry) RecordPomodoro() error {
+	if !te.started {
+		return errors.NewDomainErrorWithCode("cannot record pomodoro: task not started", errors.CodeNotStarted)
+	}
+
+	te.pomodoroCount++
+	te.touch()
+	occurredOn := now()
+
+	te.addDomainEvent(&PomodoroCompletedEvent{
+		taskEntryID:   te.id,
+		pomodoroCount: te.pomodoroCount,
+		occurredOn:    occurredOn,
+	})
+
+	if te.pomodoroCount%pomodorosPerSet == 0 {
+		te.addDomainEvent(&PomodoroSetCompletedEvent{
+			taskEntryID:   te.id,
+			pomodoroCount: te.pomodoroCount,
+			occurredOn:    occurredOn,
+		})
+	}
+
+	return nil
+}
+
+// CompleteBlock засчитывает завершенный блок работы
+func (te *TaskEntry) CompleteBlock() error {
+	if !te.started {
+		return errors.NewDomainErrorWithCode("cannot complete block: task not started", errors.CodeNotStarted)
+	}
+
+	te.blocksCompleted++
+	te.touch()
+
+	te.addDomainEvent(&BlockCompletedEvent{
+		taskEntryID:     te.id,
+		blocksCompleted: te.blocksCompleted,
+		occurredOn:      now(),
+	})
+
+	return nil
+}
+
+// SetBlocksCompleted задает количество блоков напрямую (для массового импорта)
+// События не генерируются
+func (te *TaskEntry) SetBlocksCompleted(n int) error {
+	if n < 0 {
+		return errors.NewDomainErrorWithCode("blocks completed cannot be negative", errors.CodeInvalidRange)
+	}
+
+	te.blocksCompleted = n
+	te.touch()
+	return nil
+}
+
+// CompleteTask завершает начатую задачу
+func (te *TaskEntry) CompleteTask() error {
+	if !te.started {
+		return errors.NewDomainErrorWithCode("cannot complete task: task not started", errors.CodeNotStarted)
+	}
+
+	if te.completedAt != nil {
+		return errors.NewDomainErrorWithCode("task already completed", errors.CodeAlreadyCompleted)
+	}
+
+	// Незакрытый отрезок работы (с начала или последнего возобновления) входит в активное время
+	completedAt := now()
+	if te.sessionStart != nil && completedAt.After(*te.sessionStart) {
+		te.activeDuration += completedAt.Sub(*te.sessionStart)
+	}
+	te.sessionStart = nil
+	te.completedAt = &completedAt
+	te.touch()
+
+	te.addDomainEvent(&TaskCompletedEvent{
+		taskEntryID:    te.id,
+		dayNumber:      te.dayNumber,
+		activeDuration: te.activeDuration,
+		occurredOn:     completedAt,
 	})
 
 	return nil
 }
 
-// UpdateDuration обновляет продолжительность активной работы
+// maxActiveDuration верхняя граница активного времени одной задачи
+const maxActiveDuration = 24 * time.Hour
+
+// UpdateDuration обновляет продолжительность активной работы (от 0 до 24 часов)
 func (te *TaskEntry) UpdateDuration(duration time.Duration) error {
+	return te.updateDuration(duration, false)
+}
+
+// UpdateDurationMonotonic как UpdateDuration, но не дает уменьшить уже накопленное время
+// Защищает от регресса, когда длительность приходит из нескольких источников
+func (te *TaskEntry) UpdateDurationMonotonic(duration time.Duration) error {
+	return te.updateDuration(duration, true)
+}
+
+func (te *TaskEntry) updateDuration(duration time.Duration, monotonic bool) error {
 	if !te.started {
-		return errors.NewDomainError("cannot update duration: task not started")
+		return errors.NewDomainErrorWithCode("cannot update duration: task not started", errors.CodeNotStarted)
 	}
 
 	if duration < 0 {
-		return errors.NewDomainError("duration cannot be negative")
+		return errors.NewDomainErrorWithCode("duration cannot be negative", errors.CodeInvalidRange)
+	}
+
+	if duration > maxActiveDuration {
+		return errors.NewDomainErrorWithCode("duration cannot exceed 24 hours", errors.CodeInvalidRange)
+	}
+
+	if monotonic && duration < te.activeDuration {
+		return errors.NewDomainErrorWithCode("duration cannot be less than current active duration", errors.CodeInvalidRange)
+	}
+
+	if duration == te.activeDuration {
+		return nil
 	}
 
+	oldDuration := te.activeDuration
 	te.activeDuration = duration
+	te.touch()
+
+	te.addDomainEvent(&DurationUpdatedEvent{
+		taskEntryID: te.id,
+		oldDuration: oldDuration,
+		newDuration: duration,
+		occurredOn:  now(),
+	})
 	return nil
 }
 
 // SetStressAfter устанавливает уровень стресса после выполнения
 func (te *TaskEntry) SetStressAfter(stressLevel valueobjects.StressLevel) {
 	te.stressAfter = stressLevel
+	te.hasStressAfter = true
+	te.touch()
 
 	// Генерируем событие об изменении стресса
 	te.addDomainEvent(&StressLevelChangedEvent{
 		taskEntryID:  te.id,
 		stressBefore: te.stressBefore,
 		stressAfter:  stressLevel,
-		occurredOn:   time.Now(),
+		occurredOn:   now(),
+	})
+}
+
+// SetEnergy устанавливает уровень энергии; повторная установка того же уровня ничего не меняет
+func (te *TaskEntry) SetEnergy(energy valueobjects.EnergyLevel) {
+	oldEnergy := te.energy
+	if oldEnergy == energy {
+		return
+	}
+
+	te.energy = energy
+	te.touch()
+	occurredOn := now()
+
+	te.addDomainEvent(&EnergyLevelChangedEvent{
+		taskEntryID: te.id,
+		oldEnergy:   oldEnergy,
+		newEnergy:   energy,
+		occurredOn:  occurredOn,
+	})
+
+	// Низкая энергия - отдельный сигнал для аналитики, только при переходе в низкий уровень
+	// Нулевой уровень до первой установки означает, что энергия еще не записана
+	if energy.IsLow() && (oldEnergy == 0 || !oldEnergy.IsLow()) {
+		te.addDomainEvent(&LowEnergyDetectedEvent{
+			taskEntryID: te.id,
+			energy:      energy,
+			occurredOn:  occurredOn,
+		})
+	}
+}
+
+// SetMood устанавливает уровень настроения; повторная установка того же уровня ничего не меняет
+func (te *TaskEntry) SetMood(mood valueobjects.MoodLevel) {
+	oldMood := te.mood
+	if oldMood == mood {
+		return
+	}
+
+	te.mood = mood
+	te.touch()
+	occurredOn := now()
+
+	te.addDomainEvent(&MoodLevelChangedEvent{
+		taskEntryID: te.id,
+		oldMood:     oldMood,
+		newMood:     mood,
+		occurredOn:  occurredOn,
+	})
+
+	// Как и для энергии, сигнал только при переходе из хорошего (или незаписанного) настроения
+	if !mood.IsPositive() && (oldMood == 0 || oldMood.IsPositive()) {
+		te.addDomainEvent(&LowMoodDetectedEvent{
+			taskEntryID: te.id,
+			mood:        mood,
+			occurredOn:  occurredOn,
+		})
+	}
+}
+
+// RecordDistraction добавляет время отвлечения к общему
+func (te *TaskEntry) RecordDistraction(d time.Duration) error {
+	if d < 0 {
+		return errors.NewDomainErrorWithCode("distraction duration cannot be negative", errors.CodeInvalidRange)
+	}
+
+	te.distractions += d
+	te.touch()
+	occurredOn := now()
+
+	te.addDomainEvent(&DistractionRecordedEvent{
+		taskEntryID: te.id,
+		increment:   d,
+		total:       te.distractions,
+		occurredOn:  occurredOn,
+	})
+
+	// Отвлечений больше, чем работы - сигнал о проблеме с фокусом
+	if te.distractions > te.activeDuration {
+		te.addDomainEvent(&HighDistractionDetectedEvent{
+			taskEntryID:    te.id,
+			distractions:   te.distractions,
+			activeDuration: te.activeDuration,
+			occurredOn:     occurredOn,
+		})
+	}
+
+	return nil
+}
+
+// SetLightExposure устанавливает время пребывания на свету (от 0 до 24 часов)
+func (te *TaskEntry) SetLightExposure(d time.Duration) error {
+	if d < 0 {
+		return errors.NewDomainErrorWithCode("light exposure cannot be negative", errors.CodeInvalidRange)
+	}
+
+	if d > 24*time.Hour {
+		return errors.NewDomainErrorWithCode("light exposure cannot exceed 24 hours", errors.CodeInvalidRange)
+	}
+
+	te.lightExposure = d
+	te.touch()
+
+	te.addDomainEvent(&LightExposureRecordedEvent{
+		taskEntryID:   te.id,
+		lightExposure: d,
+		occurredOn:    now(),
 	})
+
+	return nil
 }
 
 // CalculateStressReduction вычисляет снижение стресса
@@ -194,70 +759,779 @@ func (te *TaskEntry) CalculateStressReduction() int {
 	return int(te.stressBefore) - int(te.stressAfter)
 }
 
-// AddNotes добавляет заметки к записи
-func (te *TaskEntry) AddNotes(notes string) {
-	te.notes = notes
+// StressChange возвращает изменение стресса с направлением и величиной
+// Как и CalculateStressReduction, сравнивает с текущим stressAfter, даже если он не записан
+func (te *TaskEntry) StressChange() valueobjects.StressChange {
+	return valueobjects.NewStressChange(te.stressBefore, te.stressAfter)
 }
 
-// DomainEvents возвращает список доменных событий
-func (te *TaskEntry) DomainEvents() []DomainEvent {
-	return te.domainEvents
+// ProductivityWeights веса составляющих оценки продуктивности
+// Каждая составляющая нормирована к 0-1, итог делится на сумму весов,
+// поэтому важны только соотношения весов
+type ProductivityWeights struct {
+	Focus           float64 // Доля активного времени: activeDuration / (activeDuration + distractions)
+	Blocks          float64 // Завершенные блоки относительно productivityBlocksTarget
+	Pomodoros       float64 // Помидорки относительно полного подхода (pomodorosPerSet)
+	StressReduction float64 // Снижение стресса по шкале 0-10 (без записанного стресса после - 0)
 }
 
-// ClearDomainEvents очищает список событий (обычно после публикации)
-func (te *TaskEntry) ClearDomainEvents() {
-	te.domainEvents = make([]DomainEvent, 0)
+// productivityBlocksTarget количество блоков, дающее полный балл за блоки
+const productivityBlocksTarget = 4
+
+// DefaultProductivityWeights веса по умолчанию: фокус 40%, блоки, помидорки и стресс по 20%
+func DefaultProductivityWeights() ProductivityWeights {
+	return ProductivityWeights{
+		Focus:           40,
+		Blocks:          20,
+		Pomodoros:       20,
+		StressReduction: 20,
+	}
 }
 
-// Приватный метод для добавления доменных событий
-func (te *TaskEntry) addDomainEvent(event DomainEvent) {
-	te.domainEvents = append(te.domainEvents, event)
+// ProductivityScore оценивает задачу от 0 до 100 с весами по умолчанию
+func (te *TaskEntry) ProductivityScore() int {
+	return te.ProductivityScoreWith(DefaultProductivityWeights())
 }
 
-// Доменные события
+// ProductivityScoreWith оценивает задачу от 0 до 100 с заданными весами
+// Не начатая задача и веса с неположительной суммой дают 0
+func (te *TaskEntry) ProductivityScoreWith(weights ProductivityWeights) int {
+	totalWeight := weights.Focus + weights.Blocks + weights.Pomodoros + weights.StressReduction
+	if !te.started || totalWeight <= 0 {
+		return 0
+	}
 
-// TaskStartedEvent событие начала задачи
-type TaskStartedEvent struct {
-	taskEntryID TaskEntryID
-	occurredOn  time.Time
+	var focus float64
+	if worked := te.activeDuration + te.distractions; worked > 0 {
+		focus = float64(te.activeDuration) / float64(worked)
+	}
+
+	blocks := math.Min(float64(te.blocksCompleted)/productivityBlocksTarget, 1)
+	pomodoros := math.Min(float64(te.pomodoroCount)/pomodorosPerSet, 1)
+
+	var stress float64
+	if te.hasStressAfter {
+		stress = math.Max(0, math.Min(float64(te.CalculateStressReduction())/valueobjects.StressLevelMax, 1))
+	}
+
+	score := (weights.Focus*focus +
+		weights.Blocks*blocks +
+		weights.Pomodoros*pomodoros +
+		weights.StressReduction*stress) / totalWeight * 100
+
+	return int(math.Round(math.Max(0, math.Min(score, 100))))
 }
 
-func (e *TaskStartedEvent) OccurredOn() time.Time {
-	return e.occurredOn
+// SetPriority устанавливает приоритет задачи
+// Событие генерируется только при фактическом изменении приоритета
+func (te *TaskEntry) SetPriority(priority valueobjects.TaskPriority) error {
+	if !priority.IsValid() {
+		return errors.NewDomainErrorWithCode("invalid task priority: "+priority.String(), errors.CodeInvalidValue)
+	}
+
+	if priority == te.priority {
+		return nil
+	}
+
+	oldPriority := te.priority
+	te.priority = priority
+	te.touch()
+
+	te.addDomainEvent(&TaskPriorityChangedEvent{
+		taskEntryID: te.id,
+		oldPriority: oldPriority,
+		newPriority: priority,
+		occurredOn:  now(),
+	})
+	return nil
 }
 
-func (e *TaskStartedEvent) EventType() string {
-	return "TaskStarted"
+// AddTag добавляет произвольный тег, приводя его к нижнему регистру
+func (te *TaskEntry) AddTag(tag string) error {
+	tag = normalizeTag(tag)
+	if tag == "" {
+		return errors.NewDomainErrorWithCode("tag cannot be empty", errors.CodeEmptyField)
+	}
+
+	if te.tagIndex(tag) >= 0 {
+		return errors.NewDomainErrorWithCode("duplicate tag: "+tag, errors.CodeDuplicate)
+	}
+
+	te.tags = append(te.tags, tag)
+	te.touch()
+	return nil
 }
 
-func (e *TaskStartedEvent) TaskEntryID() TaskEntryID {
-	return e.taskEntryID
+// RemoveTag удаляет тег; отсутствующий тег игнорируется
+func (te *TaskEntry) RemoveTag(tag string) {
+	if i := te.tagIndex(normalizeTag(tag)); i >= 0 {
+		te.tags = append(te.tags[:i], te.tags[i+1:]...)
+		te.touch()
+	}
 }
 
-// StressLevelChangedEvent событие изменения уровня стресса
-type StressLevelChangedEvent struct {
-	taskEntryID  TaskEntryID
-	stressBefore valueobjects.StressLevel
-	stressAfter  valueobjects.StressLevel
-	occurredOn   time.Time
+// tagIndex возвращает позицию нормализованного тега или -1
+func (te *TaskEntry) tagIndex(tag string) int {
+	for i, existing := range te.tags {
+		if existing == tag {
+			return i
+		}
+	}
+	return -1
 }
 
-func (e *StressLevelChangedEvent) OccurredOn() time.Time {
-	return e.occurredOn
+// AddSubtask добавляет подзадачу в чек-лист
+func (te *TaskEntry) AddSubtask(title string) error {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return errors.NewDomainErrorWithCode("subtask title cannot be empty", errors.CodeEmptyField)
+	}
+
+	te.subtasks = append(te.subtasks, Subtask{Title: title})
+	te.touch()
+	return nil
 }
 
-func (e *StressLevelChangedEvent) EventType() string {
-	return "StressLevelChanged"
+// CompleteSubtask отмечает подзадачу с индексом index выполненной
+// Когда выполнена последняя подзадача, генерируется AllSubtasksCompletedEvent
+func (te *TaskEntry) CompleteSubtask(index int) error {
+	if index < 0 || index >= len(te.subtasks) {
+		return errors.NewDomainErrorWithCode("subtask index out of range", errors.CodeInvalidRange)
+	}
+
+	if te.subtasks[index].Done {
+		return errors.NewDomainErrorWithCode("subtask already completed", errors.CodeAlreadyCompleted)
+	}
+
+	te.subtasks[index].Done = true
+	te.touch()
+
+	if te.SubtaskProgress() == 1 {
+		te.addDomainEvent(&AllSubtasksCompletedEvent{
+			taskEntryID:  te.id,
+			subtaskCount: len(te.subtasks),
+			occurredOn:   now(),
+		})
+	}
+	return nil
 }
 
-func (e *StressLevelChangedEvent) TaskEntryID() TaskEntryID {
-	return e.taskEntryID
+// SubtaskProgress возвращает долю выполненных подзадач (0, если чек-лист пуст)
+func (te *TaskEntry) SubtaskProgress() float64 {
+	if len(te.subtasks) == 0 {
+		return 0
+	}
+
+	done := 0
+	for _, subtask := range te.subtasks {
+		if subtask.Done {
+			done++
+		}
+	}
+	return float64(done) / float64(len(te.subtasks))
 }
 
-func (e *StressLevelChangedEvent) StressBefore() valueobjects.StressLevel {
-	return e.stressBefore
+// AddNotes перезаписывает заметки целиком: история начинается заново с этой заметки
+// Чтобы сохранить предыдущие заметки, используйте AppendNote
+func (te *TaskEntry) AddNotes(notes string) {
+	te.notes = notes
+	te.noteHistory = nil
+	if notes != "" {
+		te.noteHistory = []NoteEntry{{Text: notes, AddedAt: now()}}
+	}
+	te.touch()
 }
 
-func (e *StressLevelChangedEvent) StressAfter() valueobjects.StressLevel {
-	return e.stressAfter
+// AppendNote добавляет заметку в историю, не затирая предыдущие
+func (te *TaskEntry) AppendNote(text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return errors.NewDomainErrorWithCode("note cannot be empty", errors.CodeEmptyField)
+	}
+
+	note := NoteEntry{Text: text, AddedAt: now()}
+	te.appendNote(note)
+	te.touch()
+
+	te.addDomainEvent(&NoteAddedEvent{
+		taskEntryID: te.id,
+		text:        text,
+		occurredOn:  note.AddedAt,
+	})
+	return nil
+}
+
+// appendNote дописывает заметку в историю и в общий текст Notes()
+func (te *TaskEntry) appendNote(note NoteEntry) {
+	te.noteHistory = append(te.noteHistory, note)
+	if te.notes == "" {
+		te.notes = note.Text
+	} else {
+		te.notes += "\n" + note.Text
+	}
+}
+
+// touch увеличивает версию после изменения состояния
+func (te *TaskEntry) touch() {
+	te.version++
+}
+
+// copyTime копирует время по указателю, чтобы сущность не разделяла его с вызывающим кодом
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	timeCopy := *t
+	return &timeCopy
+}
+
+// normalizeTag приводит тег к каноническому виду
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// copyTags копирует срез тегов (nil остается nil)
+func copyTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+	return append([]string(nil), tags...)
+}
+
+// copySubtasks копирует чек-лист (nil остается nil)
+func copySubtasks(subtasks []Subtask) []Subtask {
+	if subtasks == nil {
+		return nil
+	}
+	return append([]Subtask(nil), subtasks...)
+}
+
+// copyNotes копирует историю заметок (nil остается nil)
+func copyNotes(notes []NoteEntry) []NoteEntry {
+	if notes == nil {
+		return nil
+	}
+	return append([]NoteEntry(nil), notes...)
+}
+
+// Доменные события
+
+// TaskEntryCreatedEvent событие создания записи задачи
+type TaskEntryCreatedEvent struct {
+	taskEntryID  TaskEntryID
+	date         time.Time
+	dayNumber    int
+	keyTask      string
+	category     valueobjects.TaskCategory
+	stressBefore valueobjects.StressLevel
+	occurredOn   time.Time
+}
+
+func (e *TaskEntryCreatedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *TaskEntryCreatedEvent) EventType() string {
+	return "TaskEntryCreated"
+}
+
+func (e *TaskEntryCreatedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *TaskEntryCreatedEvent) Date() time.Time {
+	return e.date
+}
+
+func (e *TaskEntryCreatedEvent) DayNumber() int {
+	return e.dayNumber
+}
+
+func (e *TaskEntryCreatedEvent) KeyTask() string {
+	return e.keyTask
+}
+
+func (e *TaskEntryCreatedEvent) Category() valueobjects.TaskCategory {
+	return e.category
+}
+
+func (e *TaskEntryCreatedEvent) StressBefore() valueobjects.StressLevel {
+	return e.stressBefore
+}
+
+// TaskStartedEvent событие начала задачи
+type TaskStartedEvent struct {
+	taskEntryID TaskEntryID
+	occurredOn  time.Time
+}
+
+func (e *TaskStartedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *TaskStartedEvent) EventType() string {
+	return "TaskStarted"
+}
+
+func (e *TaskStartedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+// StressLevelChangedEvent событие изменения уровня стресса
+type StressLevelChangedEvent struct {
+	taskEntryID  TaskEntryID
+	stressBefore valueobjects.StressLevel
+	stressAfter  valueobjects.StressLevel
+	occurredOn   time.Time
+}
+
+func (e *StressLevelChangedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *StressLevelChangedEvent) EventType() string {
+	return "StressLevelChanged"
+}
+
+func (e *StressLevelChangedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *StressLevelChangedEvent) StressBefore() valueobjects.StressLevel {
+	return e.stressBefore
+}
+
+func (e *StressLevelChangedEvent) StressAfter() valueobjects.StressLevel {
+	return e.stressAfter
+}
+
+// TaskCompletedEvent событие завершения задачи
+type TaskCompletedEvent struct {
+	taskEntryID    TaskEntryID
+	dayNumber      int
+	activeDuration time.Duration
+	occurredOn     time.Time
+}
+
+func (e *TaskCompletedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *TaskCompletedEvent) EventType() string {
+	return "TaskCompleted"
+}
+
+func (e *TaskCompletedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *TaskCompletedEvent) DayNumber() int {
+	return e.dayNumber
+}
+
+func (e *TaskCompletedEvent) ActiveDuration() time.Duration {
+	return e.activeDuration
+}
+
+// TaskPausedEvent событие постановки задачи на паузу
+type TaskPausedEvent struct {
+	taskEntryID    TaskEntryID
+	activeDuration time.Duration
+	occurredOn     time.Time
+}
+
+func (e *TaskPausedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *TaskPausedEvent) EventType() string {
+	return "TaskPaused"
+}
+
+func (e *TaskPausedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *TaskPausedEvent) PausedAt() time.Time {
+	return e.occurredOn
+}
+
+func (e *TaskPausedEvent) ActiveDuration() time.Duration {
+	return e.activeDuration
+}
+
+// TaskResumedEvent событие возобновления задачи после паузы
+type TaskResumedEvent struct {
+	taskEntryID TaskEntryID
+	occurredOn  time.Time
+}
+
+func (e *TaskResumedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *TaskResumedEvent) EventType() string {
+	return "TaskResumed"
+}
+
+func (e *TaskResumedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *TaskResumedEvent) ResumedAt() time.Time {
+	return e.occurredOn
+}
+
+// PomodoroCompletedEvent событие завершения помидорки
+type PomodoroCompletedEvent struct {
+	taskEntryID   TaskEntryID
+	pomodoroCount int
+	occurredOn    time.Time
+}
+
+func (e *PomodoroCompletedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *PomodoroCompletedEvent) EventType() string {
+	return "PomodoroCompleted"
+}
+
+func (e *PomodoroCompletedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *PomodoroCompletedEvent) PomodoroCount() int {
+	return e.pomodoroCount
+}
+
+// PomodoroSetCompletedEvent событие завершения подхода из четырех помидорок
+// Сигнал, что пора сделать длинный перерыв
+type PomodoroSetCompletedEvent struct {
+	taskEntryID   TaskEntryID
+	pomodoroCount int
+	occurredOn    time.Time
+}
+
+func (e *PomodoroSetCompletedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *PomodoroSetCompletedEvent) EventType() string {
+	return "PomodoroSetCompleted"
+}
+
+func (e *PomodoroSetCompletedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *PomodoroSetCompletedEvent) PomodoroCount() int {
+	return e.pomodoroCount
+}
+
+// BlockCompletedEvent событие завершения блока работы
+type BlockCompletedEvent struct {
+	taskEntryID     TaskEntryID
+	blocksCompleted int
+	occurredOn      time.Time
+}
+
+func (e *BlockCompletedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *BlockCompletedEvent) EventType() string {
+	return "BlockCompleted"
+}
+
+func (e *BlockCompletedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *BlockCompletedEvent) BlocksCompleted() int {
+	return e.blocksCompleted
+}
+
+// EnergyLevelChangedEvent событие изменения уровня энергии
+type EnergyLevelChangedEvent struct {
+	taskEntryID TaskEntryID
+	oldEnergy   valueobjects.EnergyLevel
+	newEnergy   valueobjects.EnergyLevel
+	occurredOn  time.Time
+}
+
+func (e *EnergyLevelChangedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *EnergyLevelChangedEvent) EventType() string {
+	return "EnergyLevelChanged"
+}
+
+func (e *EnergyLevelChangedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *EnergyLevelChangedEvent) OldEnergy() valueobjects.EnergyLevel {
+	return e.oldEnergy
+}
+
+func (e *EnergyLevelChangedEvent) NewEnergy() valueobjects.EnergyLevel {
+	return e.newEnergy
+}
+
+// LowEnergyDetectedEvent событие обнаружения низкого уровня энергии
+type LowEnergyDetectedEvent struct {
+	taskEntryID TaskEntryID
+	energy      valueobjects.EnergyLevel
+	occurredOn  time.Time
+}
+
+func (e *LowEnergyDetectedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *LowEnergyDetectedEvent) EventType() string {
+	return "LowEnergyDetected"
+}
+
+func (e *LowEnergyDetectedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *LowEnergyDetectedEvent) Energy() valueobjects.EnergyLevel {
+	return e.energy
+}
+
+// MoodLevelChangedEvent событие изменения уровня настроения
+type MoodLevelChangedEvent struct {
+	taskEntryID TaskEntryID
+	oldMood     valueobjects.MoodLevel
+	newMood     valueobjects.MoodLevel
+	occurredOn  time.Time
+}
+
+func (e *MoodLevelChangedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *MoodLevelChangedEvent) EventType() string {
+	return "MoodLevelChanged"
+}
+
+func (e *MoodLevelChangedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *MoodLevelChangedEvent) OldMood() valueobjects.MoodLevel {
+	return e.oldMood
+}
+
+func (e *MoodLevelChangedEvent) NewMood() valueobjects.MoodLevel {
+	return e.newMood
+}
+
+// LowMoodDetectedEvent событие обнаружения плохого настроения
+type LowMoodDetectedEvent struct {
+	taskEntryID TaskEntryID
+	mood        valueobjects.MoodLevel
+	occurredOn  time.Time
+}
+
+func (e *LowMoodDetectedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *LowMoodDetectedEvent) EventType() string {
+	return "LowMoodDetected"
+}
+
+func (e *LowMoodDetectedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *LowMoodDetectedEvent) Mood() valueobjects.MoodLevel {
+	return e.mood
+}
+
+// DistractionRecordedEvent событие записи отвлечения
+type DistractionRecordedEvent struct {
+	taskEntryID TaskEntryID
+	increment   time.Duration
+	total       time.Duration
+	occurredOn  time.Time
+}
+
+func (e *DistractionRecordedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *DistractionRecordedEvent) EventType() string {
+	return "DistractionRecorded"
+}
+
+func (e *DistractionRecordedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *DistractionRecordedEvent) Increment() time.Duration {
+	return e.increment
+}
+
+func (e *DistractionRecordedEvent) Total() time.Duration {
+	return e.total
+}
+
+// HighDistractionDetectedEvent событие превышения отвлечений над активным временем
+type HighDistractionDetectedEvent struct {
+	taskEntryID    TaskEntryID
+	distractions   time.Duration
+	activeDuration time.Duration
+	occurredOn     time.Time
+}
+
+func (e *HighDistractionDetectedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *HighDistractionDetectedEvent) EventType() string {
+	return "HighDistractionDetected"
+}
+
+func (e *HighDistractionDetectedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *HighDistractionDetectedEvent) Distractions() time.Duration {
+	return e.distractions
+}
+
+func (e *HighDistractionDetectedEvent) ActiveDuration() time.Duration {
+	return e.activeDuration
+}
+
+// LightExposureRecordedEvent событие записи времени на свету
+type LightExposureRecordedEvent struct {
+	taskEntryID   TaskEntryID
+	lightExposure time.Duration
+	occurredOn    time.Time
+}
+
+func (e *LightExposureRecordedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *LightExposureRecordedEvent) EventType() string {
+	return "LightExposureRecorded"
+}
+
+func (e *LightExposureRecordedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *LightExposureRecordedEvent) LightExposure() time.Duration {
+	return e.lightExposure
+}
+
+// TaskPriorityChangedEvent событие изменения приоритета задачи
+type TaskPriorityChangedEvent struct {
+	taskEntryID TaskEntryID
+	oldPriority valueobjects.TaskPriority
+	newPriority valueobjects.TaskPriority
+	occurredOn  time.Time
+}
+
+func (e *TaskPriorityChangedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *TaskPriorityChangedEvent) EventType() string {
+	return "TaskPriorityChanged"
+}
+
+func (e *TaskPriorityChangedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+// OldPriority возвращает прежний приоритет (пустой, если он не был задан)
+func (e *TaskPriorityChangedEvent) OldPriority() valueobjects.TaskPriority {
+	return e.oldPriority
+}
+
+func (e *TaskPriorityChangedEvent) NewPriority() valueobjects.TaskPriority {
+	return e.newPriority
+}
+
+// AllSubtasksCompletedEvent событие выполнения всех подзадач чек-листа
+type AllSubtasksCompletedEvent struct {
+	taskEntryID  TaskEntryID
+	subtaskCount int
+	occurredOn   time.Time
+}
+
+func (e *AllSubtasksCompletedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *AllSubtasksCompletedEvent) EventType() string {
+	return "AllSubtasksCompleted"
+}
+
+func (e *AllSubtasksCompletedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *AllSubtasksCompletedEvent) SubtaskCount() int {
+	return e.subtaskCount
+}
+
+// DurationUpdatedEvent событие ручного изменения активного времени задачи
+type DurationUpdatedEvent struct {
+	taskEntryID TaskEntryID
+	oldDuration time.Duration
+	newDuration time.Duration
+	occurredOn  time.Time
+}
+
+func (e *DurationUpdatedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *DurationUpdatedEvent) EventType() string {
+	return "DurationUpdated"
+}
+
+func (e *DurationUpdatedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *DurationUpdatedEvent) OldDuration() time.Duration {
+	return e.oldDuration
+}
+
+func (e *DurationUpdatedEvent) NewDuration() time.Duration {
+	return e.newDuration
+}
+
+// NoteAddedEvent событие добавления заметки в историю задачи
+type NoteAddedEvent struct {
+	taskEntryID TaskEntryID
+	text        string
+	occurredOn  time.Time
+}
+
+func (e *NoteAddedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+func (e *NoteAddedEvent) EventType() string {
+	return "NoteAdded"
+}
+
+func (e *NoteAddedEvent) TaskEntryID() TaskEntryID {
+	return e.taskEntryID
+}
+
+func (e *NoteAddedEvent) Text() string {
+	return e.text
 }
@@ -2,6 +2,9 @@ package entities
 
 import (
 	"daily-tracker/internal/domain/valueobjects"
+	"daily-tracker/pkg/errors"
+	stderrors "errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -48,6 +51,8 @@ func TestNewTaskEntry_Success(t *testing.T) {
 	if taskEntry.DayNumber() != dayNumber {
 		t.Errorf("Expected day number %d, got %d", dayNumber, taskEntry.DayNumber())
 	}
+
+	assertEventTypes(t, taskEntry.DomainEvents(), []string{"TaskEntryCreated"})
 }
 
 func TestNewTaskEntry_EmptyKeyTask(t *testing.T) {
@@ -69,6 +74,43 @@ func TestNewTaskEntry_EmptyKeyTask(t *testing.T) {
 	}
 }
 
+func TestNewTaskEntry_KeyTaskValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		keyTask     string
+		expectError bool
+		expected    string
+	}{
+		{"whitespace only", "  \t\n ", true, ""},
+		{"over length", strings.Repeat("a", MaxKeyTaskLength+1), true, ""},
+		{"trimmed", "  Написать отчет  ", false, "Написать отчет"},
+		// 500 кириллических символов - 1000 байт, но длина считается в рунах
+		{"multibyte at limit", strings.Repeat("я", MaxKeyTaskLength), false, strings.Repeat("я", MaxKeyTaskLength)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskEntry, err := NewTaskEntry("task-1", now(), 1, tt.keyTask, valueobjects.TaskCategoryWork, 5)
+
+			if tt.expectError {
+				var validation *errors.ValidationErrors
+				if !stderrors.As(err, &validation) || len(validation.ErrorsByField()["keyTask"]) != 1 {
+					t.Errorf("Expected validation error on keyTask, got %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if taskEntry.KeyTask() != tt.expected {
+				t.Errorf("Expected key task %q, got %q", tt.expected, taskEntry.KeyTask())
+			}
+		})
+	}
+}
+
 func TestNewTaskEntry_InvalidDayNumber(t *testing.T) {
 	id := TaskEntryID("test-id")
 	date := time.Now()
@@ -88,7 +130,65 @@ func TestNewTaskEntry_InvalidDayNumber(t *testing.T) {
 	}
 }
 
+func TestNewTaskEntry_FutureDate(t *testing.T) {
+	current := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+	defer SetClock(NewFixedClock(current))()
+
+	tests := []struct {
+		name        string
+		date        time.Time
+		expectError bool
+	}{
+		{"today", current, false},
+		{"within tolerance", current.Add(FutureDateTolerance), false},
+		{"far future", current.AddDate(1, 0, 0), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTaskEntry("task-1", tt.date, 1, "Написать отчет", valueobjects.TaskCategoryWork, 5)
+
+			if !tt.expectError {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			var validation *errors.ValidationErrors
+			if !stderrors.As(err, &validation) || len(validation.ErrorsByField()["date"]) != 1 {
+				t.Errorf("Expected validation error on date, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNewTaskEntry_ReportsAllViolations(t *testing.T) {
+	category, _ := valueobjects.NewTaskCategory("работа")
+
+	taskEntry, err := NewTaskEntry("test-id", time.Now(), 0, "", category, valueobjects.StressLevel(11))
+	if taskEntry != nil {
+		t.Error("Expected taskEntry to be nil when error occurs")
+	}
+
+	var validation *errors.ValidationErrors
+	if !stderrors.As(err, &validation) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+
+	byField := validation.ErrorsByField()
+	for _, field := range []string{"keyTask", "dayNumber", "stressBefore"} {
+		if len(byField[field]) != 1 {
+			t.Errorf("Expected violation for %s, got %v", field, byField)
+		}
+	}
+}
+
 func TestTaskEntry_StartTask(t *testing.T) {
+	// Фиксированные часы делают время начала детерминированным
+	startedAt := time.Date(2025, 8, 12, 9, 10, 0, 0, time.UTC)
+	defer SetClock(NewFixedClock(startedAt))()
+
 	// Подготавливаем задачу
 	taskEntry := createValidTaskEntry(t)
 
@@ -113,7 +213,11 @@ func TestTaskEntry_StartTask(t *testing.T) {
 	}
 
 	if taskEntry.StartTime() == nil {
-		t.Error("Start time should not be nil after starting task")
+		t.Fatal("Start time should not be nil after starting task")
+	}
+
+	if !taskEntry.StartTime().Equal(startedAt) {
+		t.Errorf("Expected start time %v, got %v", startedAt, *taskEntry.StartTime())
 	}
 
 	// Проверяем доменные события
@@ -127,6 +231,10 @@ func TestTaskEntry_StartTask(t *testing.T) {
 		if event.EventType() != "TaskStarted" {
 			t.Errorf("Expected TaskStarted event, got %s", event.EventType())
 		}
+
+		if !event.OccurredOn().Equal(startedAt) {
+			t.Errorf("Expected event at %v, got %v", startedAt, event.OccurredOn())
+		}
 	}
 }
 
@@ -181,6 +289,89 @@ func TestTaskEntry_UpdateDuration_NegativeDuration(t *testing.T) {
 	}
 }
 
+func TestTaskEntry_UpdateDuration_UpperBound(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration time.Duration
+		wantErr  bool
+	}{
+		{"exactly 24 hours", 24 * time.Hour, false},
+		{"over 24 hours", 24*time.Hour + time.Second, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskEntry := createValidTaskEntry(t)
+			taskEntry.StartTask()
+
+			err := taskEntry.UpdateDuration(tt.duration)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && !errors.IsDomainError(err) {
+				t.Errorf("Expected DomainError, got %T", err)
+			}
+		})
+	}
+}
+
+func TestTaskEntry_UpdateDurationMonotonic(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+	taskEntry.StartTask()
+
+	if err := taskEntry.UpdateDurationMonotonic(40 * time.Minute); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := taskEntry.UpdateDurationMonotonic(30 * time.Minute); err == nil {
+		t.Error("Expected error for decreasing duration in monotonic mode")
+	}
+	if taskEntry.ActiveDuration() != 40*time.Minute {
+		t.Errorf("Expected duration 40m to be kept, got %v", taskEntry.ActiveDuration())
+	}
+
+	// Обычный режим по-прежнему разрешает уменьшение
+	if err := taskEntry.UpdateDuration(30 * time.Minute); err != nil {
+		t.Errorf("Expected non-monotonic update to succeed, got %v", err)
+	}
+}
+
+func TestTaskEntry_UpdateDuration_EmitsEvent(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+	taskEntry.StartTask()
+	taskEntry.UpdateDuration(20 * time.Minute)
+	taskEntry.ClearDomainEvents()
+
+	if err := taskEntry.UpdateDuration(45 * time.Minute); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	events := taskEntry.DomainEvents()
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	event, ok := events[0].(*DurationUpdatedEvent)
+	if !ok {
+		t.Fatalf("Expected DurationUpdatedEvent, got %T", events[0])
+	}
+	if event.TaskEntryID() != taskEntry.ID() {
+		t.Errorf("Expected task ID %v, got %v", taskEntry.ID(), event.TaskEntryID())
+	}
+	if event.OldDuration() != 20*time.Minute {
+		t.Errorf("Expected old duration 20m, got %v", event.OldDuration())
+	}
+	if event.NewDuration() != 45*time.Minute {
+		t.Errorf("Expected new duration 45m, got %v", event.NewDuration())
+	}
+
+	// Повтор того же значения не создает событие
+	taskEntry.ClearDomainEvents()
+	taskEntry.UpdateDuration(45 * time.Minute)
+	if len(taskEntry.DomainEvents()) != 0 {
+		t.Errorf("Expected no events for unchanged duration, got %d", len(taskEntry.DomainEvents()))
+	}
+}
+
 func TestTaskEntry_SetStressAfter(t *testing.T) {
 	taskEntry := createValidTaskEntry(t)
 	stressAfter, _ := valueobjects.NewStressLevel(3)
@@ -220,87 +411,1106 @@ func TestTaskEntry_CalculateStressReduction(t *testing.T) {
 	}
 }
 
-// Вспомогательная функция для создания валидной записи задачи
-// В Go принято выносить общую логику в helper-функции
-func createValidTaskEntry(t *testing.T) *TaskEntry {
-	id := TaskEntryID("test-id-123")
-	date := time.Now()
-	dayNumber := 1
-	keyTask := "Test task"
-	category, err := valueobjects.NewTaskCategory("работа")
+func TestTaskEntryCreatedEvent_Getters(t *testing.T) {
+	taskEntry, err := NewTaskEntry("task-1", now(), 1, "Test task", valueobjects.TaskCategoryWork, 7)
 	if err != nil {
-		t.Fatalf("Failed to create category: %v", err)
+		t.Fatalf("Failed to create task entry: %v", err)
 	}
 
-	stressBefore, err := valueobjects.NewStressLevel(7)
-	if err != nil {
-		t.Fatalf("Failed to create stress level: %v", err)
+	created, ok := taskEntry.DomainEvents()[0].(*TaskEntryCreatedEvent)
+	if !ok {
+		t.Fatalf("Expected TaskEntryCreated, got %s", taskEntry.DomainEvents()[0].EventType())
 	}
 
-	taskEntry, err := NewTaskEntry(id, date, dayNumber, keyTask, category, stressBefore)
-	if err != nil {
-		t.Fatalf("Failed to create task entry: %v", err)
+	if created.TaskEntryID() != taskEntry.ID() || !created.Date().Equal(taskEntry.Date()) || created.DayNumber() != 1 {
+		t.Errorf("Expected id/date/day of the task entry, got %s %v %d", created.TaskEntryID(), created.Date(), created.DayNumber())
 	}
 
-	return taskEntry
+	if created.KeyTask() != "Test task" || created.Category() != taskEntry.Category() || created.StressBefore() != 7 {
+		t.Errorf("Expected key task, category and stress 7, got %q %s %d", created.KeyTask(), created.Category(), created.StressBefore())
+	}
 }
 
-// Бенчмарки - измерение производительности
-// Функции должны начинаться с Benchmark и принимать *testing.B
-func BenchmarkNewTaskEntry(b *testing.B) {
-	// Подготавливаем данные один раз
-	id := TaskEntryID("test-id-123")
-	date := time.Now()
-	keyTask := "Benchmark task"
-	category, _ := valueobjects.NewTaskCategory("работа")
-	stressBefore, _ := valueobjects.NewStressLevel(7)
+func TestTaskEntry_Clone_IsIndependent(t *testing.T) {
+	original := createValidTaskEntry(t)
+	original.StartTask()
+	original.AddTag("focus")
+	original.AddSubtask("Черновик")
+	original.AddNotes("before clone")
 
-	// b.N - количество итераций, определяется автоматически
-	for i := 0; i < b.N; i++ {
-		_, err := NewTaskEntry(id, date, i+1, keyTask, category, stressBefore)
-		if err != nil {
-			b.Errorf("Unexpected error: %v", err)
-		}
+	clone := original.Clone()
+	if clone.KeyTask() != original.KeyTask() || clone.Version() != original.Version() || len(clone.DomainEvents()) != len(original.DomainEvents()) {
+		t.Fatalf("Expected clone to match original, got %+v", clone.State())
+	}
+
+	startTime := *original.StartTime()
+	*clone.startTime = startTime.Add(time.Hour)
+	clone.tags[0] = "changed"
+	clone.subtasks[0].Done = true
+	clone.PauseTask()
+	clone.AddNotes("after clone")
+	clone.ClearDomainEvents()
+
+	if !original.StartTime().Equal(startTime) {
+		t.Errorf("Expected original start time %v, got %v", startTime, original.StartTime())
+	}
+
+	if !original.HasTag("focus") || original.Subtasks()[0].Done {
+		t.Error("Expected original tags and subtasks to stay unchanged")
+	}
+
+	if original.Paused() || original.Notes() != "before clone" {
+		t.Errorf("Expected original to stay running with its notes, got paused=%v notes=%q", original.Paused(), original.Notes())
+	}
+
+	if len(original.DomainEvents()) != 1 {
+		t.Errorf("Expected original to keep its events, got %d", len(original.DomainEvents()))
 	}
 }
 
-// Table-driven tests - популярный паттерн в Go
-func TestTaskEntry_StartTask_TableDriven(t *testing.T) {
+func TestTaskEntry_StressChange(t *testing.T) {
 	tests := []struct {
 		name        string
-		taskStarted bool
-		expectError bool
+		stressAfter valueobjects.StressLevel
+		text        string
 	}{
-		{
-			name:        "start fresh task",
-			taskStarted: false,
-			expectError: false,
-		},
-		{
-			name:        "start already started task",
-			taskStarted: true,
-			expectError: true,
-		},
+		{"reduction", 3, "reduced by 4"},
+		{"increase", 9, "increased by 2"},
+		{"no change", 7, "unchanged"},
 	}
 
 	for _, tt := range tests {
-		// t.Run создает подтест с именем
 		t.Run(tt.name, func(t *testing.T) {
 			taskEntry := createValidTaskEntry(t)
+			taskEntry.SetStressAfter(tt.stressAfter)
 
-			if tt.taskStarted {
-				// Предварительно запускаем задачу
-				taskEntry.StartTask()
+			change := taskEntry.StressChange()
+			if change.String() != tt.text {
+				t.Errorf("Expected %q, got %q", tt.text, change.String())
 			}
 
-			err := taskEntry.StartTask()
+			// Старый метод остается совместимым
+			if change.Reduction() != taskEntry.CalculateStressReduction() {
+				t.Errorf("Expected reduction %d, got %d", taskEntry.CalculateStressReduction(), change.Reduction())
+			}
+		})
+	}
+}
 
-			if tt.expectError && err == nil {
-				t.Error("Expected error, got nil")
+func TestReconstructTaskEntry_RestoresStateWithoutEvents(t *testing.T) {
+	startTime := time.Date(2025, 8, 12, 9, 10, 0, 0, time.UTC)
+	state := TaskEntryState{
+		ID:              TaskEntryID("stored-id"),
+		Date:            time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC),
+		DayNumber:       3,
+		KeyTask:         "Написать отчет по проекту",
+		Category:        valueobjects.TaskCategoryWork,
+		StressBefore:    8,
+		Started:         true,
+		StartTime:       &startTime,
+		ActiveDuration:  30 * time.Minute,
+		ContinuedAfter:  true,
+		StressAfter:     4,
+		Distractions:    5 * time.Minute,
+		BlocksCompleted: 2,
+		PomodoroCount:   1,
+		LightExposure:   15 * time.Minute,
+		Energy:          5,
+		Mood:            4,
+		Notes:           "Отвлекся на почту 5 мин",
+	}
+
+	taskEntry, err := ReconstructTaskEntry(state)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Восстановленная сущность не должна повторно публиковать события
+	if len(taskEntry.DomainEvents()) != 0 {
+		t.Errorf("Expected no domain events, got %d", len(taskEntry.DomainEvents()))
+	}
+
+	if !taskEntry.Started() || taskEntry.StartTime() == nil || !taskEntry.StartTime().Equal(startTime) {
+		t.Errorf("Expected task started at %v, got started=%v at %v", startTime, taskEntry.Started(), taskEntry.StartTime())
+	}
+
+	if taskEntry.ActiveDuration() != state.ActiveDuration {
+		t.Errorf("Expected active duration %v, got %v", state.ActiveDuration, taskEntry.ActiveDuration())
+	}
+
+	if taskEntry.StressAfter() != state.StressAfter {
+		t.Errorf("Expected stress after %d, got %d", state.StressAfter, taskEntry.StressAfter())
+	}
+
+	if taskEntry.BlocksCompleted() != 2 || taskEntry.PomodoroCount() != 1 {
+		t.Errorf("Expected 2 blocks and 1 pomodoro, got %d and %d", taskEntry.BlocksCompleted(), taskEntry.PomodoroCount())
+	}
+
+	if taskEntry.Notes() != state.Notes {
+		t.Errorf("Expected notes %q, got %q", state.Notes, taskEntry.Notes())
+	}
+
+	// Изменение исходного времени не должно влиять на сущность
+	startTime = startTime.Add(time.Hour)
+	if taskEntry.StartTime().Equal(startTime) {
+		t.Error("Expected start time to be copied on reconstruction")
+	}
+}
+
+func TestTaskEntry_AppendNote(t *testing.T) {
+	fixedClock := NewFixedClock(time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC))
+	defer SetClock(fixedClock)()
+
+	taskEntry := createValidTaskEntry(t)
+	taskEntry.ClearDomainEvents()
+
+	for _, text := range []string{"Начал с плана", "  Отвлекся на почту  ", "Закончил черновик"} {
+		if err := taskEntry.AppendNote(text); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		fixedClock.Advance(10 * time.Minute)
+	}
+
+	expectedNotes := "Начал с плана\nОтвлекся на почту\nЗакончил черновик"
+	if taskEntry.Notes() != expectedNotes {
+		t.Errorf("Expected notes %q, got %q", expectedNotes, taskEntry.Notes())
+	}
+
+	history := taskEntry.NoteHistory()
+	if len(history) != 3 {
+		t.Fatalf("Expected 3 notes in history, got %d", len(history))
+	}
+	if history[1].Text != "Отвлекся на почту" {
+		t.Errorf("Expected trimmed second note, got %q", history[1].Text)
+	}
+	if want := time.Date(2025, 8, 12, 9, 20, 0, 0, time.UTC); !history[2].AddedAt.Equal(want) {
+		t.Errorf("Expected third note at %v, got %v", want, history[2].AddedAt)
+	}
+
+	events := taskEntry.DomainEvents()
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+	if added, ok := events[0].(*NoteAddedEvent); !ok || added.Text() != "Начал с плана" || added.TaskEntryID() != taskEntry.ID() {
+		t.Errorf("Expected NoteAddedEvent for the first note, got %+v", events[0])
+	}
+
+	// История возвращается копией
+	history[0].Text = "changed"
+	if taskEntry.NoteHistory()[0].Text != "Начал с плана" {
+		t.Error("Expected NoteHistory to return a copy")
+	}
+
+	if err := taskEntry.AppendNote("   "); err == nil {
+		t.Error("Expected error for empty note")
+	}
+}
+
+func TestTaskEntry_AddNotes_ResetsHistory(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+	taskEntry.AppendNote("first")
+	taskEntry.AppendNote("second")
+
+	taskEntry.AddNotes("rewritten")
+	if taskEntry.Notes() != "rewritten" || len(taskEntry.NoteHistory()) != 1 {
+		t.Errorf("Expected single rewritten note, got %q with %d entries", taskEntry.Notes(), len(taskEntry.NoteHistory()))
+	}
+
+	taskEntry.AppendNote("after")
+	if taskEntry.Notes() != "rewritten\nafter" {
+		t.Errorf("Expected appended view, got %q", taskEntry.Notes())
+	}
+
+	taskEntry.AddNotes("")
+	if taskEntry.Notes() != "" || len(taskEntry.NoteHistory()) != 0 {
+		t.Errorf("Expected notes to be cleared, got %q with %d entries", taskEntry.Notes(), len(taskEntry.NoteHistory()))
+	}
+}
+
+func TestTaskEntry_Version(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+	if taskEntry.Version() != 0 {
+		t.Fatalf("Expected new task entry at version 0, got %d", taskEntry.Version())
+	}
+
+	taskEntry.StartTask()
+	taskEntry.AddNotes("first block")
+	taskEntry.SetPriority(valueobjects.TaskPriorityHigh)
+	if taskEntry.Version() != 3 {
+		t.Errorf("Expected version 3 after three changes, got %d", taskEntry.Version())
+	}
+
+	// Неудачные и холостые вызовы версию не меняют
+	taskEntry.StartTask()
+	taskEntry.SetPriority(valueobjects.TaskPriorityHigh)
+	taskEntry.RemoveTag("missing")
+	if taskEntry.Version() != 3 {
+		t.Errorf("Expected version to stay 3, got %d", taskEntry.Version())
+	}
+
+	state := taskEntry.State()
+	restored, err := ReconstructTaskEntry(state)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if restored.Version() != 3 {
+		t.Errorf("Expected reconstructed version 3, got %d", restored.Version())
+	}
+}
+
+func TestReconstructTaskEntry_Validation(t *testing.T) {
+	tests := []struct {
+		name           string
+		state          TaskEntryState
+		expectedFields []string
+	}{
+		{"empty key task", TaskEntryState{ID: "id", DayNumber: 1}, []string{"keyTask"}},
+		{"non-positive day number", TaskEntryState{ID: "id", KeyTask: "Test task"}, []string{"dayNumber"}},
+		{"both invalid", TaskEntryState{ID: "id"}, []string{"keyTask", "dayNumber"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskEntry, err := ReconstructTaskEntry(tt.state)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
 			}
 
-			if !tt.expectError && err != nil {
-				t.Errorf("Expected no error, got: %v", err)
+			if taskEntry != nil {
+				t.Error("Expected taskEntry to be nil when error occurs")
+			}
+
+			var validation *errors.ValidationErrors
+			if !stderrors.As(err, &validation) {
+				t.Fatalf("Expected ValidationErrors, got %v", err)
+			}
+
+			byField := validation.ErrorsByField()
+			if len(byField) != len(tt.expectedFields) {
+				t.Errorf("Expected %d fields, got %v", len(tt.expectedFields), byField)
+			}
+			for _, field := range tt.expectedFields {
+				if _, ok := byField[field]; !ok {
+					t.Errorf("Expected error for field %s, got %v", field, byField)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskEntry_CompleteTask(t *testing.T) {
+	// Фиксированные часы: незакрытый отрезок работы не добавляет времени
+	defer SetClock(NewFixedClock(time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)))()
+
+	taskEntry := createValidTaskEntry(t)
+	taskEntry.StartTask()
+	taskEntry.UpdateDuration(25 * time.Minute)
+	taskEntry.ClearDomainEvents()
+
+	if err := taskEntry.CompleteTask(); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !taskEntry.IsCompleted() || taskEntry.CompletedAt() == nil {
+		t.Error("Expected task to be completed with a timestamp")
+	}
+
+	events := taskEntry.DomainEvents()
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 domain event, got %d", len(events))
+	}
+
+	completed, ok := events[0].(*TaskCompletedEvent)
+	if !ok {
+		t.Fatalf("Expected TaskCompletedEvent, got %s", events[0].EventType())
+	}
+
+	if completed.ActiveDuration() != 25*time.Minute || completed.DayNumber() != 1 {
+		t.Errorf("Expected duration 25m and day 1, got %v and %d", completed.ActiveDuration(), completed.DayNumber())
+	}
+}
+
+func TestTaskEntry_CompleteTask_FoldsOpenSession(t *testing.T) {
+	fixedClock := NewFixedClock(time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC))
+	defer SetClock(fixedClock)()
+
+	taskEntry := createValidTaskEntry(t)
+	taskEntry.StartTask()
+	fixedClock.Advance(40 * time.Minute)
+	taskEntry.ClearDomainEvents()
+
+	if err := taskEntry.CompleteTask(); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if taskEntry.ActiveDuration() != 40*time.Minute {
+		t.Errorf("Expected open session of 40m to be folded in, got %v", taskEntry.ActiveDuration())
+	}
+
+	if taskEntry.sessionStart != nil {
+		t.Error("Expected session to be closed after completion")
+	}
+
+	completed, ok := taskEntry.DomainEvents()[0].(*TaskCompletedEvent)
+	if !ok || completed.ActiveDuration() != 40*time.Minute {
+		t.Errorf("Expected TaskCompletedEvent with 40m, got %v", taskEntry.DomainEvents()[0])
+	}
+
+	// Завершенную задачу нельзя поставить на паузу или возобновить
+	if err := taskEntry.PauseTask(); !hasErrorCode(err, errors.CodeAlreadyCompleted) {
+		t.Errorf("Expected %s on pause, got %v", errors.CodeAlreadyCompleted, err)
+	}
+	if err := taskEntry.ResumeTask(); !hasErrorCode(err, errors.CodeAlreadyCompleted) {
+		t.Errorf("Expected %s on resume, got %v", errors.CodeAlreadyCompleted, err)
+	}
+}
+
+func TestTaskEntry_CompleteTask_AfterResume(t *testing.T) {
+	fixedClock := NewFixedClock(time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC))
+	defer SetClock(fixedClock)()
+
+	taskEntry := createValidTaskEntry(t)
+	taskEntry.StartTask()
+	fixedClock.Advance(20 * time.Minute)
+	taskEntry.PauseTask()
+	fixedClock.Advance(time.Hour)
+	taskEntry.ResumeTask()
+	fixedClock.Advance(15 * time.Minute)
+
+	if err := taskEntry.CompleteTask(); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Пауза не считается, учитываются 20 минут до паузы и 15 после возобновления
+	if taskEntry.ActiveDuration() != 35*time.Minute {
+		t.Errorf("Expected 35m active, got %v", taskEntry.ActiveDuration())
+	}
+}
+
+// hasErrorCode проверяет, что ошибка - DomainError с указанным кодом
+func hasErrorCode(err error, code errors.ErrorCode) bool {
+	var domainErr *errors.DomainError
+	return stderrors.As(err, &domainErr) && domainErr.Code() == code.String()
+}
+
+func TestTaskEntry_CompleteTask_Errors(t *testing.T) {
+	// Незапущенную задачу нельзя завершить
+	taskEntry := createValidTaskEntry(t)
+	err := taskEntry.CompleteTask()
+	if !errors.IsDomainError(err) {
+		t.Errorf("Expected DomainError for unstarted task, got %v", err)
+	}
+
+	if taskEntry.IsCompleted() {
+		t.Error("Unstarted task should not become completed")
+	}
+
+	// Повторное завершение тоже ошибка
+	taskEntry.StartTask()
+	if err := taskEntry.CompleteTask(); err != nil {
+		t.Fatalf("First CompleteTask() should succeed, got: %v", err)
+	}
+
+	err = taskEntry.CompleteTask()
+	if !errors.IsDomainError(err) {
+		t.Errorf("Expected DomainError for completed task, got %v", err)
+	}
+}
+
+func TestTaskEntry_PauseAndResume_AccumulatesDuration(t *testing.T) {
+	// Подменяем часы, чтобы управлять временем в тесте
+	fixedClock := NewFixedClock(time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC))
+	defer SetClock(fixedClock)()
+
+	taskEntry := createValidTaskEntry(t)
+	taskEntry.StartTask()
+
+	fixedClock.Advance(10 * time.Minute)
+	if err := taskEntry.PauseTask(); err != nil {
+		t.Fatalf("Expected no error on pause, got: %v", err)
+	}
+
+	if !taskEntry.Paused() || taskEntry.ActiveDuration() != 10*time.Minute {
+		t.Errorf("Expected paused task with 10m, got paused=%v duration=%v", taskEntry.Paused(), taskEntry.ActiveDuration())
+	}
+
+	// Время на паузе не засчитывается
+	fixedClock.Advance(30 * time.Minute)
+	if err := taskEntry.ResumeTask(); err != nil {
+		t.Fatalf("Expected no error on resume, got: %v", err)
+	}
+
+	fixedClock.Advance(15 * time.Minute)
+	taskEntry.PauseTask()
+
+	if taskEntry.ActiveDuration() != 25*time.Minute {
+		t.Errorf("Expected accumulated 25m, got %v", taskEntry.ActiveDuration())
+	}
+
+	events := taskEntry.DomainEvents()
+	resumed, ok := events[2].(*TaskResumedEvent)
+	if !ok {
+		t.Fatalf("Expected TaskResumedEvent, got %s", events[2].EventType())
+	}
+
+	if !resumed.ResumedAt().Equal(time.Date(2025, 8, 12, 9, 40, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected resume timestamp %v", resumed.ResumedAt())
+	}
+
+	paused, ok := events[3].(*TaskPausedEvent)
+	if !ok || !paused.PausedAt().Equal(fixedClock.Now()) {
+		t.Errorf("Expected TaskPausedEvent at %v", fixedClock.Now())
+	}
+}
+
+func TestTaskEntry_PauseAndResume_Errors(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+
+	if err := taskEntry.PauseTask(); !errors.IsDomainError(err) {
+		t.Errorf("Expected DomainError pausing unstarted task, got %v", err)
+	}
+
+	taskEntry.StartTask()
+	if err := taskEntry.ResumeTask(); !errors.IsDomainError(err) {
+		t.Errorf("Expected DomainError resuming running task, got %v", err)
+	}
+
+	taskEntry.PauseTask()
+	if err := taskEntry.PauseTask(); !errors.IsDomainError(err) {
+		t.Errorf("Expected DomainError pausing paused task, got %v", err)
+	}
+}
+
+func TestTaskEntry_RecordPomodoro(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+
+	// До запуска задачи помидорки не засчитываются
+	if err := taskEntry.RecordPomodoro(); !errors.IsDomainError(err) {
+		t.Errorf("Expected DomainError for unstarted task, got %v", err)
+	}
+
+	if taskEntry.PomodoroCount() != 0 {
+		t.Errorf("Expected count to stay 0, got %d", taskEntry.PomodoroCount())
+	}
+
+	taskEntry.StartTask()
+	taskEntry.ClearDomainEvents()
+
+	for i := 0; i < 3; i++ {
+		if err := taskEntry.RecordPomodoro(); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+
+	if taskEntry.PomodoroCount() != 3 || len(taskEntry.DomainEvents()) != 3 {
+		t.Errorf("Expected 3 pomodoros and 3 events, got %d and %d", taskEntry.PomodoroCount(), len(taskEntry.DomainEvents()))
+	}
+
+	// Четвертая помидорка завершает подход
+	taskEntry.RecordPomodoro()
+
+	events := taskEntry.DomainEvents()
+	if len(events) != 5 {
+		t.Fatalf("Expected 5 events, got %d", len(events))
+	}
+
+	if events[3].EventType() != "PomodoroCompleted" || events[4].EventType() != "PomodoroSetCompleted" {
+		t.Errorf("Expected PomodoroCompleted then PomodoroSetCompleted, got %s and %s", events[3].EventType(), events[4].EventType())
+	}
+}
+
+func TestTaskEntry_CompleteBlock(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+
+	if err := taskEntry.CompleteBlock(); !errors.IsDomainError(err) {
+		t.Errorf("Expected DomainError for unstarted task, got %v", err)
+	}
+
+	taskEntry.StartTask()
+	taskEntry.ClearDomainEvents()
+	taskEntry.CompleteBlock()
+	taskEntry.CompleteBlock()
+
+	if taskEntry.BlocksCompleted() != 2 {
+		t.Errorf("Expected 2 blocks, got %d", taskEntry.BlocksCompleted())
+	}
+
+	events := taskEntry.DomainEvents()
+	last, ok := events[len(events)-1].(*BlockCompletedEvent)
+	if !ok || last.BlocksCompleted() != 2 {
+		t.Errorf("Expected BlockCompletedEvent with total 2, got %v", events[len(events)-1].EventType())
+	}
+}
+
+func TestTaskEntry_SetBlocksCompleted(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+
+	if err := taskEntry.SetBlocksCompleted(5); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if taskEntry.BlocksCompleted() != 5 {
+		t.Errorf("Expected 5 blocks, got %d", taskEntry.BlocksCompleted())
+	}
+
+	if err := taskEntry.SetBlocksCompleted(-1); !errors.IsDomainError(err) {
+		t.Errorf("Expected DomainError for negative count, got %v", err)
+	}
+
+	if taskEntry.BlocksCompleted() != 5 {
+		t.Errorf("Expected count to stay 5 after rejected update, got %d", taskEntry.BlocksCompleted())
+	}
+}
+
+func TestTaskEntry_SetEnergy(t *testing.T) {
+	tests := []struct {
+		name           string
+		energy         int
+		expectedEvents []string
+	}{
+		{"normal energy", 6, []string{"EnergyLevelChanged"}},
+		{"low energy", 3, []string{"EnergyLevelChanged", "LowEnergyDetected"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskEntry := createValidTaskEntry(t)
+			energy, _ := valueobjects.NewEnergyLevel(tt.energy)
+
+			taskEntry.SetEnergy(energy)
+
+			if taskEntry.Energy() != energy {
+				t.Errorf("Expected energy %d, got %d", energy, taskEntry.Energy())
+			}
+			assertEventTypes(t, taskEntry.DomainEvents(), tt.expectedEvents)
+
+			changed := taskEntry.DomainEvents()[0].(*EnergyLevelChangedEvent)
+			if changed.OldEnergy() != 0 || changed.NewEnergy() != energy {
+				t.Errorf("Expected 0 -> %d, got %d -> %d", energy, changed.OldEnergy(), changed.NewEnergy())
+			}
+		})
+	}
+}
+
+func TestTaskEntry_SetMood(t *testing.T) {
+	tests := []struct {
+		name           string
+		mood           int
+		expectedEvents []string
+	}{
+		{"positive mood", 6, []string{"MoodLevelChanged"}},
+		{"low mood", 5, []string{"MoodLevelChanged", "LowMoodDetected"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskEntry := createValidTaskEntry(t)
+			mood, _ := valueobjects.NewMoodLevel(tt.mood)
+
+			taskEntry.SetMood(mood)
+
+			if taskEntry.Mood() != mood {
+				t.Errorf("Expected mood %d, got %d", mood, taskEntry.Mood())
+			}
+			assertEventTypes(t, taskEntry.DomainEvents(), tt.expectedEvents)
+		})
+	}
+}
+
+func TestTaskEntry_SetEnergyAndMood_Transitions(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+	taskEntry.ClearDomainEvents()
+
+	taskEntry.SetEnergy(3)
+	taskEntry.SetEnergy(2)
+	taskEntry.SetEnergy(2)
+	taskEntry.SetEnergy(7)
+	taskEntry.SetEnergy(1)
+
+	// Повторный низкий уровень не дублирует сигнал, одинаковый уровень не дает событий
+	assertEventTypes(t, taskEntry.DomainEvents(), []string{
+		"EnergyLevelChanged", "LowEnergyDetected",
+		"EnergyLevelChanged",
+		"EnergyLevelChanged",
+		"EnergyLevelChanged", "LowEnergyDetected",
+	})
+
+	taskEntry.ClearDomainEvents()
+	taskEntry.SetMood(4)
+	taskEntry.SetMood(5)
+	taskEntry.SetMood(5)
+	taskEntry.SetMood(8)
+	taskEntry.SetMood(2)
+
+	assertEventTypes(t, taskEntry.DomainEvents(), []string{
+		"MoodLevelChanged", "LowMoodDetected",
+		"MoodLevelChanged",
+		"MoodLevelChanged",
+		"MoodLevelChanged", "LowMoodDetected",
+	})
+}
+
+func TestTaskEntry_SetPriority(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+
+	if taskEntry.HasPriority() {
+		t.Error("Expected new task entry to have no priority")
+	}
+
+	if err := taskEntry.SetPriority(valueobjects.TaskPriorityHigh); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if taskEntry.Priority() != valueobjects.TaskPriorityHigh || !taskEntry.HasPriority() {
+		t.Errorf("Expected priority high, got '%s'", taskEntry.Priority())
+	}
+	assertEventTypes(t, taskEntry.DomainEvents(), []string{"TaskPriorityChanged"})
+
+	changed := taskEntry.DomainEvents()[0].(*TaskPriorityChangedEvent)
+	if changed.OldPriority() != "" || changed.NewPriority() != valueobjects.TaskPriorityHigh {
+		t.Errorf("Expected '' -> high, got '%s' -> '%s'", changed.OldPriority(), changed.NewPriority())
+	}
+
+	// Повторная установка того же приоритета не генерирует событие
+	taskEntry.ClearDomainEvents()
+	if err := taskEntry.SetPriority(valueobjects.TaskPriorityHigh); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	assertEventTypes(t, taskEntry.DomainEvents(), []string{})
+}
+
+func TestTaskEntry_SetPriority_Invalid(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+
+	err := taskEntry.SetPriority(valueobjects.TaskPriority("critical"))
+	if !errors.IsDomainError(err) {
+		t.Errorf("Expected DomainError, got %v", err)
+	}
+
+	if taskEntry.HasPriority() || len(taskEntry.DomainEvents()) != 0 {
+		t.Error("Expected invalid priority to leave the task entry unchanged")
+	}
+}
+
+func TestTaskEntry_AddTag(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+
+	for _, tag := range []string{"  #DeepWork ", "утро"} {
+		if err := taskEntry.AddTag(tag); err != nil {
+			t.Fatalf("Expected no error for tag '%s', got %v", tag, err)
+		}
+	}
+
+	tags := taskEntry.Tags()
+	if len(tags) != 2 || tags[0] != "#deepwork" || tags[1] != "утро" {
+		t.Errorf("Expected normalized tags [#deepwork утро], got %v", tags)
+	}
+
+	if !taskEntry.HasTag("#DEEPWORK") {
+		t.Error("Expected HasTag to ignore case")
+	}
+
+	// Изменение копии не затрагивает сущность
+	tags[0] = "changed"
+	if !taskEntry.HasTag("#deepwork") {
+		t.Error("Expected Tags() to return a copy")
+	}
+}
+
+func TestTaskEntry_AddTag_Invalid(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+	if err := taskEntry.AddTag("focus"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		name string
+		tag  string
+	}{
+		{"empty", ""},
+		{"whitespace", "   "},
+		{"duplicate", "focus"},
+		{"duplicate after normalization", " FOCUS "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := taskEntry.AddTag(tt.tag); !errors.IsDomainError(err) {
+				t.Errorf("Expected DomainError for tag '%s', got %v", tt.tag, err)
+			}
+		})
+	}
+
+	if len(taskEntry.Tags()) != 1 {
+		t.Errorf("Expected 1 tag, got %v", taskEntry.Tags())
+	}
+}
+
+func TestTaskEntry_RemoveTag(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+	taskEntry.AddTag("focus")
+	taskEntry.AddTag("morning")
+
+	taskEntry.RemoveTag(" Focus")
+	taskEntry.RemoveTag("missing")
+
+	if taskEntry.HasTag("focus") || !taskEntry.HasTag("morning") || len(taskEntry.Tags()) != 1 {
+		t.Errorf("Expected only 'morning' to remain, got %v", taskEntry.Tags())
+	}
+}
+
+func TestTaskEntry_SubtaskProgress(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+
+	if taskEntry.SubtaskProgress() != 0 {
+		t.Errorf("Expected progress 0 without subtasks, got %v", taskEntry.SubtaskProgress())
+	}
+
+	for _, title := range []string{"План", "Черновик", "Проверка", "Отправка"} {
+		if err := taskEntry.AddSubtask(title); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	taskEntry.CompleteSubtask(0)
+	taskEntry.CompleteSubtask(2)
+
+	if taskEntry.SubtaskProgress() != 0.5 {
+		t.Errorf("Expected progress 0.5, got %v", taskEntry.SubtaskProgress())
+	}
+
+	subtasks := taskEntry.Subtasks()
+	if !subtasks[0].Done || subtasks[1].Done || subtasks[1].Title != "Черновик" {
+		t.Errorf("Unexpected subtasks: %+v", subtasks)
+	}
+	assertEventTypes(t, taskEntry.DomainEvents(), []string{})
+}
+
+func TestTaskEntry_CompleteSubtask_AllDone(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+	taskEntry.AddSubtask("План")
+	taskEntry.AddSubtask("Черновик")
+
+	taskEntry.CompleteSubtask(1)
+	assertEventTypes(t, taskEntry.DomainEvents(), []string{})
+
+	if err := taskEntry.CompleteSubtask(0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if taskEntry.SubtaskProgress() != 1 {
+		t.Errorf("Expected progress 1, got %v", taskEntry.SubtaskProgress())
+	}
+	assertEventTypes(t, taskEntry.DomainEvents(), []string{"AllSubtasksCompleted"})
+
+	event := taskEntry.DomainEvents()[0].(*AllSubtasksCompletedEvent)
+	if event.SubtaskCount() != 2 {
+		t.Errorf("Expected subtask count 2, got %d", event.SubtaskCount())
+	}
+}
+
+func TestTaskEntry_Subtask_Errors(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+
+	if err := taskEntry.AddSubtask("  "); !errors.IsDomainError(err) {
+		t.Errorf("Expected DomainError for empty title, got %v", err)
+	}
+
+	taskEntry.AddSubtask("План")
+
+	for _, index := range []int{-1, 1} {
+		if err := taskEntry.CompleteSubtask(index); !errors.IsDomainError(err) {
+			t.Errorf("Expected DomainError for index %d, got %v", index, err)
+		}
+	}
+
+	taskEntry.CompleteSubtask(0)
+	if err := taskEntry.CompleteSubtask(0); !errors.IsDomainError(err) {
+		t.Errorf("Expected DomainError when completing twice, got %v", err)
+	}
+}
+
+func TestTaskEntry_RecordDistraction(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+	taskEntry.StartTask()
+	taskEntry.UpdateDuration(30 * time.Minute)
+	taskEntry.ClearDomainEvents()
+
+	taskEntry.RecordDistraction(5 * time.Minute)
+	taskEntry.RecordDistraction(10 * time.Minute)
+
+	if taskEntry.Distractions() != 15*time.Minute {
+		t.Errorf("Expected 15m of distractions, got %v", taskEntry.Distractions())
+	}
+	assertEventTypes(t, taskEntry.DomainEvents(), []string{"DistractionRecorded", "DistractionRecorded"})
+
+	recorded := taskEntry.DomainEvents()[1].(*DistractionRecordedEvent)
+	if recorded.Increment() != 10*time.Minute || recorded.Total() != 15*time.Minute {
+		t.Errorf("Expected increment 10m and total 15m, got %v and %v", recorded.Increment(), recorded.Total())
+	}
+}
+
+func TestTaskEntry_RecordDistraction_Negative(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+
+	if err := taskEntry.RecordDistraction(-time.Minute); !errors.IsDomainError(err) {
+		t.Errorf("Expected DomainError for negative duration, got %v", err)
+	}
+
+	if taskEntry.Distractions() != 0 || len(taskEntry.DomainEvents()) != 0 {
+		t.Error("Expected rejected distraction to leave the entry unchanged")
+	}
+}
+
+func TestTaskEntry_RecordDistraction_HighDistraction(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+	taskEntry.StartTask()
+	taskEntry.UpdateDuration(20 * time.Minute)
+	taskEntry.ClearDomainEvents()
+
+	// Ровно столько же, сколько работы - еще не превышение
+	taskEntry.RecordDistraction(20 * time.Minute)
+	assertEventTypes(t, taskEntry.DomainEvents(), []string{"DistractionRecorded"})
+
+	taskEntry.ClearDomainEvents()
+	taskEntry.RecordDistraction(time.Minute)
+	assertEventTypes(t, taskEntry.DomainEvents(), []string{"DistractionRecorded", "HighDistractionDetected"})
+}
+
+func TestTaskEntry_SetLightExposure(t *testing.T) {
+	tests := []struct {
+		name        string
+		exposure    time.Duration
+		expectError bool
+	}{
+		{"zero", 0, false},
+		{"morning walk", 15 * time.Minute, false},
+		{"whole day", 24 * time.Hour, false},
+		{"negative", -time.Minute, true},
+		{"over a day", 24*time.Hour + time.Minute, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskEntry := createValidTaskEntry(t)
+
+			err := taskEntry.SetLightExposure(tt.exposure)
+
+			if tt.expectError {
+				if !errors.IsDomainError(err) {
+					t.Errorf("Expected DomainError, got %v", err)
+				}
+				if taskEntry.LightExposure() != 0 {
+					t.Errorf("Expected exposure to stay 0, got %v", taskEntry.LightExposure())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if taskEntry.LightExposure() != tt.exposure {
+				t.Errorf("Expected exposure %v, got %v", tt.exposure, taskEntry.LightExposure())
+			}
+			assertEventTypes(t, taskEntry.DomainEvents(), []string{"LightExposureRecorded"})
+		})
+	}
+}
+
+func TestTaskEntry_ProductivityScore(t *testing.T) {
+	tests := []struct {
+		name         string
+		active       time.Duration
+		distractions time.Duration
+		blocks       int
+		pomodoros    int
+		stressAfter  valueobjects.StressLevel
+		expected     int
+	}{
+		// Фокус 0.75*40 + блоки 0.5*20 + помидорки 0.5*20 + стресс 0.3*20
+		{"typical", 60 * time.Minute, 20 * time.Minute, 2, 2, 4, 56},
+		// Все составляющие на максимуме, кроме стресса: 7 -> 0 дает 0.7*20
+		{"full focus and sets", 60 * time.Minute, 0, 4, 4, 0, 94},
+		// Рост стресса не уходит в минус
+		{"stress increased", 30 * time.Minute, 30 * time.Minute, 0, 0, 10, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskEntry := createValidTaskEntry(t)
+			taskEntry.StartTask()
+			taskEntry.UpdateDuration(tt.active)
+			taskEntry.RecordDistraction(tt.distractions)
+			taskEntry.SetBlocksCompleted(tt.blocks)
+			for i := 0; i < tt.pomodoros; i++ {
+				taskEntry.RecordPomodoro()
+			}
+			taskEntry.SetStressAfter(tt.stressAfter)
+
+			if score := taskEntry.ProductivityScore(); score != tt.expected {
+				t.Errorf("Expected score %d, got %d", tt.expected, score)
+			}
+		})
+	}
+}
+
+func TestTaskEntry_ProductivityScoreWith(t *testing.T) {
+	taskEntry := createValidTaskEntry(t)
+
+	if score := taskEntry.ProductivityScore(); score != 0 {
+		t.Errorf("Expected not started task to score 0, got %d", score)
+	}
+
+	taskEntry.StartTask()
+	taskEntry.UpdateDuration(45 * time.Minute)
+	taskEntry.RecordDistraction(15 * time.Minute)
+
+	// Учитывается только фокус: 45 из 60 минут
+	if score := taskEntry.ProductivityScoreWith(ProductivityWeights{Focus: 1}); score != 75 {
+		t.Errorf("Expected focus-only score 75, got %d", score)
+	}
+
+	if score := taskEntry.ProductivityScoreWith(ProductivityWeights{}); score != 0 {
+		t.Errorf("Expected zero weights to score 0, got %d", score)
+	}
+}
+
+// Вспомогательная функция для создания валидной записи задачи
+// В Go принято выносить общую логику в helper-функции
+func createValidTaskEntry(t *testing.T) *TaskEntry {
+	id := TaskEntryID("test-id-123")
+	date := now()
+	dayNumber := 1
+	keyTask := "Test task"
+	category, err := valueobjects.NewTaskCategory("работа")
+	if err != nil {
+		t.Fatalf("Failed to create category: %v", err)
+	}
+
+	stressBefore, err := valueobjects.NewStressLevel(7)
+	if err != nil {
+		t.Fatalf("Failed to create stress level: %v", err)
+	}
+
+	taskEntry, err := NewTaskEntry(id, date, dayNumber, keyTask, category, stressBefore)
+	if err != nil {
+		t.Fatalf("Failed to create task entry: %v", err)
+	}
+
+	// Тесты проверяют события последующих операций, событие создания им не нужно
+	taskEntry.ClearDomainEvents()
+
+	return taskEntry
+}
+
+// Бенчмарки - измерение производительности
+// Функции должны начинаться с Benchmark и принимать *testing.B
+func BenchmarkNewTaskEntry(b *testing.B) {
+	// Подготавливаем данные один раз
+	id := TaskEntryID("test-id-123")
+	date := time.Now()
+	keyTask := "Benchmark task"
+	category, _ := valueobjects.NewTaskCategory("работа")
+	stressBefore, _ := valueobjects.NewStressLevel(7)
+
+	// b.N - количество итераций, определяется автоматически
+	for i := 0; i < b.N; i++ {
+		_, err := NewTaskEntry(id, date, i+1, keyTask, category, stressBefore)
+		if err != nil {
+			b.Errorf("Unexpected error: %v", err)
+		}
+	}
+}
+
+// Table-driven tests - популярный паттерн в Go
+func TestTaskEntry_StartTask_TableDriven(t *testing.T) {
+	tests := []struct {
+		name        string
+		taskStarted bool
+		expectError bool
+	}{
+		{
+			name:        "start fresh task",
+			taskStarted: false,
+			expectError: false,
+		},
+		{
+			name:        "start already started task",
+			taskStarted: true,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		// t.Run создает подтест с именем
+		t.Run(tt.name, func(t *testing.T) {
+			taskEntry := createValidTaskEntry(t)
+
+			if tt.taskStarted {
+				// Предварительно запускаем задачу
+				taskEntry.StartTask()
+			}
+
+			err := taskEntry.StartTask()
+
+			if tt.expectError && err == nil {
+				t.Error("Expected error, got nil")
+			}
+
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+// assertEventTypes проверяет типы доменных событий по порядку
+func assertEventTypes(t *testing.T, events []DomainEvent, expected []string) {
+	t.Helper()
+
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events %v, got %d", len(expected), expected, len(events))
+	}
+
+	for i, event := range events {
+		if event.EventType() != expected[i] {
+			t.Errorf("Expected event %d to be %s, got %s", i, expected[i], event.EventType())
+		}
+	}
+}
+
+func TestTaskEntry_ErrorCodes(t *testing.T) {
+	started := createValidTaskEntry(t)
+	started.StartTask()
+
+	completed := createValidTaskEntry(t)
+	completed.StartTask()
+	completed.CompleteTask()
+
+	tests := []struct {
+		name     string
+		action   func() error
+		expected errors.ErrorCode
+	}{
+		{"start started task", started.StartTask, errors.CodeAlreadyStarted},
+		{"pause unstarted task", createValidTaskEntry(t).PauseTask, errors.CodeNotStarted},
+		{"resume running task", started.ResumeTask, errors.CodeNotPaused},
+		{"complete completed task", completed.CompleteTask, errors.CodeAlreadyCompleted},
+		{"negative distraction", func() error { return started.RecordDistraction(-time.Minute) }, errors.CodeInvalidRange},
+		{"empty tag", func() error { return started.AddTag(" ") }, errors.CodeEmptyField},
+		{"invalid priority", func() error { return started.SetPriority("critical") }, errors.CodeInvalidValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var domainErr *errors.DomainError
+			if err := tt.action(); !stderrors.As(err, &domainErr) {
+				t.Fatalf("Expected DomainError, got %v", err)
+			}
+
+			if domainErr.Code() != string(tt.expected) {
+				t.Errorf("Expected code %s, got %s", tt.expected, domainErr.Code())
 			}
 		})
 	}
@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"daily-tracker/internal/domain/valueobjects"
+)
+
+// TaskOption задает необязательное поле при создании записи задачи
+// Опции применяются после валидации обязательных полей и события создания,
+// через обычные методы сущности, поэтому порождают те же события и проверки
+type TaskOption func(*TaskEntry) error
+
+// WithNotes задает заметки к задаче
+func WithNotes(notes string) TaskOption {
+	return func(te *TaskEntry) error {
+		te.AddNotes(notes)
+		return nil
+	}
+}
+
+// WithEnergy задает уровень энергии (0-10)
+func WithEnergy(energy valueobjects.EnergyLevel) TaskOption {
+	return func(te *TaskEntry) error {
+		if _, err := valueobjects.NewEnergyLevel(energy.Int()); err != nil {
+			return err
+		}
+		te.SetEnergy(energy)
+		return nil
+	}
+}
+
+// WithMood задает уровень настроения (0-10)
+func WithMood(mood valueobjects.MoodLevel) TaskOption {
+	return func(te *TaskEntry) error {
+		if _, err := valueobjects.NewMoodLevel(mood.Int()); err != nil {
+			return err
+		}
+		te.SetMood(mood)
+		return nil
+	}
+}
+
+// WithPriority задает приоритет задачи
+func WithPriority(priority valueobjects.TaskPriority) TaskOption {
+	return func(te *TaskEntry) error {
+		return te.SetPriority(priority)
+	}
+}
+
+// WithTags добавляет теги; пустые и повторяющиеся теги считаются ошибкой, как в AddTag
+func WithTags(tags ...string) TaskOption {
+	return func(te *TaskEntry) error {
+		for _, tag := range tags {
+			if err := te.AddTag(tag); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,84 @@
+package entities
+
+import (
+	"daily-tracker/internal/domain/valueobjects"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewTaskEntry_WithOptions(t *testing.T) {
+	taskEntry, err := NewTaskEntry(
+		"task-1", time.Now(), 1, "Написать отчет", valueobjects.TaskCategoryWork, 5,
+		WithNotes("после обеда"),
+		WithEnergy(7),
+		WithMood(6),
+		WithPriority(valueobjects.TaskPriorityHigh),
+		WithTags("Report", "q3"),
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if taskEntry.Notes() != "после обеда" {
+		t.Errorf("Expected notes 'после обеда', got %q", taskEntry.Notes())
+	}
+	if taskEntry.Energy() != 7 {
+		t.Errorf("Expected energy 7, got %v", taskEntry.Energy())
+	}
+	if taskEntry.Mood() != 6 {
+		t.Errorf("Expected mood 6, got %v", taskEntry.Mood())
+	}
+	if taskEntry.Priority() != valueobjects.TaskPriorityHigh {
+		t.Errorf("Expected priority high, got %v", taskEntry.Priority())
+	}
+	if want := []string{"report", "q3"}; !reflect.DeepEqual(taskEntry.Tags(), want) {
+		t.Errorf("Expected tags %v, got %v", want, taskEntry.Tags())
+	}
+
+	// Событие создания идет первым, опции добавляют свои события следом
+	events := taskEntry.DomainEvents()
+	if len(events) == 0 {
+		t.Fatal("Expected domain events, got none")
+	}
+	if _, ok := events[0].(*TaskEntryCreatedEvent); !ok {
+		t.Errorf("Expected first event TaskEntryCreatedEvent, got %T", events[0])
+	}
+}
+
+func TestNewTaskEntry_WithoutOptions(t *testing.T) {
+	taskEntry, err := NewTaskEntry("task-1", time.Now(), 1, "Написать отчет", valueobjects.TaskCategoryWork, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if taskEntry.Notes() != "" || taskEntry.Priority() != "" || len(taskEntry.Tags()) != 0 {
+		t.Errorf("Expected optional fields to be empty, got notes %q, priority %q, tags %v",
+			taskEntry.Notes(), taskEntry.Priority(), taskEntry.Tags())
+	}
+}
+
+func TestNewTaskEntry_InvalidOption(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  TaskOption
+	}{
+		{"energy out of range", WithEnergy(11)},
+		{"mood out of range", WithMood(-1)},
+		{"invalid priority", WithPriority("critical")},
+		{"empty tag", WithTags("ok", " ")},
+		{"duplicate tag", WithTags("focus", "Focus")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskEntry, err := NewTaskEntry("task-1", time.Now(), 1, "Написать отчет", valueobjects.TaskCategoryWork, 5, tt.opt)
+			if err == nil {
+				t.Error("Expected error for invalid option, got nil")
+			}
+			if taskEntry != nil {
+				t.Errorf("Expected nil task entry on error, got %+v", taskEntry)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package entities
+
+import (
+	"daily-tracker/internal/domain/events"
+	"daily-tracker/pkg/errors"
+)
+
+// ReplayTaskEntry восстанавливает запись задачи, последовательно применяя события потока
+// Поток должен начинаться с TaskEntryCreated. События не генерируются повторно.
+// Неизвестные типы событий пропускаются, а при strict возвращается ошибка.
+// Изменения без событий (SetBlocksCompleted, AddNotes, теги, подзадачи) из потока не восстановить
+func ReplayTaskEntry(history []events.DomainEvent, strict bool) (*TaskEntry, error) {
+	var te *TaskEntry
+
+	for _, stored := range history {
+		if stored == nil {
+			return nil, errors.NewDomainErrorWithCode("event stream contains nil event", errors.CodeInvalidEventStream)
+		}
+
+		event := entityEvent(stored)
+
+		if created, ok := event.(*TaskEntryCreatedEvent); ok {
+			if te != nil {
+				return nil, errors.NewDomainErrorWithCode("task entry created twice in event stream", errors.CodeInvalidEventStream)
+			}
+			te = &TaskEntry{
+				id:           created.taskEntryID,
+				date:         created.date,
+				dayNumber:    created.dayNumber,
+				keyTask:      created.keyTask,
+				category:     created.category,
+				stressBefore: created.stressBefore,
+			}
+			continue
+		}
+
+		if te == nil {
+			return nil, errors.NewDomainErrorWithCode("event stream must start with TaskEntryCreated", errors.CodeInvalidEventStream)
+		}
+
+		if stored.AggregateID() != string(te.id) {
+			return nil, errors.NewDomainErrorWithCode("event "+stored.EventID()+" belongs to another aggregate", errors.CodeInvalidEventStream)
+		}
+
+		if !te.apply(event) && strict {
+			return nil, errors.NewDomainErrorWithCode("unknown task event type: "+stored.EventType(), errors.CodeInvalidEventStream)
+		}
+	}
+
+	if te == nil {
+		return nil, errors.NewDomainErrorWithCode("event stream must start with TaskEntryCreated", errors.CodeInvalidEventStream)
+	}
+
+	return te, nil
+}
+
+// entityEvent извлекает исходное событие сущности из сохраненного
+func entityEvent(stored events.DomainEvent) events.EntityEvent {
+	if wrapper, ok := stored.(interface{ Payload() events.EntityEvent }); ok {
+		return wrapper.Payload()
+	}
+	return stored
+}
+
+// apply переносит последствия события на состояние задачи
+// Возвращает false для событий, которые задача не знает
+// Версия растет только для событий, меняющих состояние, как и при вызове методов
+func (te *TaskEntry) apply(event events.EntityEvent) bool {
+	switch e := event.(type) {
+	case *TaskStartedEvent:
+		startedAt := e.occurredOn
+		te.started = true
+		te.startTime = &startedAt
+		te.sessionStart = copyTime(&startedAt)
+	case *TaskPausedEvent:
+		te.activeDuration = e.activeDuration
+		te.paused = true
+		te.sessionStart = nil
+	case *TaskResumedEvent:
+		resumedAt := e.occurredOn
+		te.paused = false
+		te.sessionStart = &resumedAt
+	case *TaskCompletedEvent:
+		completedAt := e.occurredOn
+		te.completedAt = &completedAt
+		te.activeDuration = e.activeDuration
+		te.sessionStart = nil
+	case *PomodoroCompletedEvent:
+		te.pomodoroCount = e.pomodoroCount
+	case *PomodoroSetCompletedEvent:
+		// Сопровождает PomodoroCompleted, версию уже увеличило оно
+		te.pomodoroCount = e.pomodoroCount
+		return true
+	case *BlockCompletedEvent:
+		te.blocksCompleted = e.blocksCompleted
+	case *StressLevelChangedEvent:
+		te.stressAfter = e.stressAfter
+		te.hasStressAfter = true
+	case *EnergyLevelChangedEvent:
+		te.energy = e.newEnergy
+	case *MoodLevelChangedEvent:
+		te.mood = e.newMood
+	case *DistractionRecordedEvent:
+		te.distractions = e.total
+	case *LightExposureRecordedEvent:
+		te.lightExposure = e.lightExposure
+	case *TaskPriorityChangedEvent:
+		te.priority = e.newPriority
+	case *DurationUpdatedEvent:
+		te.activeDuration = e.newDuration
+	case *NoteAddedEvent:
+		te.appendNote(NoteEntry{Text: e.text, AddedAt: e.occurredOn})
+	case *LowEnergyDetectedEvent, *LowMoodDetectedEvent, *HighDistractionDetectedEvent,
+		*AllSubtasksCompletedEvent:
+		// Производные сигналы, состояние не меняют
+		return true
+	default:
+		return false
+	}
+	te.touch()
+	return true
+}
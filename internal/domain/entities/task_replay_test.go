@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"daily-tracker/internal/domain/events"
+	"daily-tracker/internal/domain/valueobjects"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReplayTaskEntry_RebuildsObservableState(t *testing.T) {
+	fixedClock := NewFixedClock(time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC))
+	defer SetClock(fixedClock)()
+
+	original, err := NewTaskEntry("task-1", fixedClock.Now(), 3, "Написать отчет", valueobjects.TaskCategoryWork, 8)
+	if err != nil {
+		t.Fatalf("Failed to create task entry: %v", err)
+	}
+
+	original.StartTask()
+	fixedClock.Advance(25 * time.Minute)
+	original.RecordPomodoro()
+	original.CompleteBlock()
+	original.PauseTask()
+	fixedClock.Advance(10 * time.Minute)
+	original.ResumeTask()
+	original.RecordDistraction(5 * time.Minute)
+	original.UpdateDuration(20 * time.Minute)
+	original.AppendNote("Отвлекся на почту")
+	original.SetEnergy(3)
+	original.SetMood(7)
+	original.SetLightExposure(20 * time.Minute)
+	original.SetStressAfter(4)
+	original.PauseTask()
+	original.CompleteTask()
+
+	replayed, err := ReplayTaskEntry(storableEvents(original), true)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !reflect.DeepEqual(replayed.State(), original.State()) {
+		t.Errorf("Expected replayed state\n%+v\nto equal\n%+v", replayed.State(), original.State())
+	}
+
+	if len(replayed.DomainEvents()) != 0 {
+		t.Errorf("Expected replay not to emit events, got %d", len(replayed.DomainEvents()))
+	}
+}
+
+func TestReplayTaskEntry_UnknownEvents(t *testing.T) {
+	original, _ := NewTaskEntry("task-1", time.Now(), 1, "Test task", valueobjects.TaskCategoryWork, 5)
+	history := append(storableEvents(original), events.NewBaseEvent("TaskArchived", "task-1"))
+
+	if _, err := ReplayTaskEntry(history, false); err != nil {
+		t.Errorf("Expected unknown event to be skipped, got: %v", err)
+	}
+
+	if _, err := ReplayTaskEntry(history, true); err == nil {
+		t.Error("Expected error for unknown event in strict mode, got nil")
+	}
+}
+
+func TestReplayTaskEntry_InvalidStreams(t *testing.T) {
+	original, _ := NewTaskEntry("task-1", time.Now(), 1, "Test task", valueobjects.TaskCategoryWork, 5)
+	original.StartTask()
+	history := storableEvents(original)
+
+	other, _ := NewTaskEntry("task-2", time.Now(), 1, "Other task", valueobjects.TaskCategoryWork, 5)
+	other.StartTask()
+
+	tests := []struct {
+		name    string
+		history []events.DomainEvent
+	}{
+		{"empty stream", nil},
+		{"missing creation", history[1:]},
+		{"created twice", append([]events.DomainEvent{history[0]}, history...)},
+		{"foreign aggregate", append([]events.DomainEvent{history[0]}, storableEvents(other)[1])},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReplayTaskEntry(tt.history, false); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+// storableEvents оборачивает накопленные события задачи для хранения
+func storableEvents(te *TaskEntry) []events.DomainEvent {
+	stored := make([]events.DomainEvent, 0, len(te.DomainEvents()))
+	for _, event := range te.DomainEvents() {
+		stored = append(stored, events.ToStorable(event, string(te.ID())))
+	}
+	return stored
+}
@@ -0,0 +1,36 @@
+package events
+
+// Equal сравнивает события по ID, типу, агрегату и времени возникновения
+// Два nil считаются равными; полезная нагрузка не сравнивается
+func Equal(a, b DomainEvent) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.EventID() == b.EventID() &&
+		a.EventType() == b.EventType() &&
+		a.AggregateID() == b.AggregateID() &&
+		a.OccurredOn().Equal(b.OccurredOn())
+}
+
+// DeduplicateEvents убирает события с повторяющимся EventID, сохраняя порядок первого появления
+// Используется при слиянии потоков из нескольких источников; nil-события отбрасываются
+func DeduplicateEvents(events []DomainEvent) []DomainEvent {
+	seen := make(map[string]struct{}, len(events))
+	result := make([]DomainEvent, 0, len(events))
+
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+
+		if _, duplicate := seen[event.EventID()]; duplicate {
+			continue
+		}
+
+		seen[event.EventID()] = struct{}{}
+		result = append(result, event)
+	}
+
+	return result
+}
@@ -0,0 +1,75 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeduplicateEvents_KeepsFirstSeenOrder(t *testing.T) {
+	created := NewBaseEvent("TaskCreated", "task-1")
+	started := NewBaseEvent("TaskStarted", "task-1")
+	other := NewBaseEvent("TaskStarted", "task-2")
+
+	// Тот же ID из другого источника, например после повторной доставки
+	redelivered := started
+	redelivered.OccurredAt = started.OccurredAt.Add(time.Second)
+
+	merged := []DomainEvent{created, started, nil, other, redelivered, created}
+	result := DeduplicateEvents(merged)
+
+	expected := []DomainEvent{created, started, other}
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(result))
+	}
+
+	for i := range expected {
+		if !Equal(result[i], expected[i]) {
+			t.Errorf("Event %d: expected %s for %s, got %s for %s",
+				i, expected[i].EventType(), expected[i].AggregateID(), result[i].EventType(), result[i].AggregateID())
+		}
+	}
+
+	if empty := DeduplicateEvents(nil); len(empty) != 0 {
+		t.Errorf("Expected no events for nil input, got %d", len(empty))
+	}
+}
+
+func TestEqual(t *testing.T) {
+	event := NewBaseEvent("TaskStarted", "task-1")
+
+	withType := event
+	withType.Type = "TaskPaused"
+
+	withAggregate := event
+	withAggregate.AggregateId = "task-2"
+
+	withTime := event
+	withTime.OccurredAt = event.OccurredAt.Add(time.Minute)
+
+	// То же мгновение в другой зоне - то же время возникновения
+	inOtherZone := event
+	inOtherZone.OccurredAt = event.OccurredAt.In(time.FixedZone("UTC+3", 3*60*60))
+
+	tests := []struct {
+		name     string
+		a, b     DomainEvent
+		expected bool
+	}{
+		{"same event", event, event, true},
+		{"other time zone", event, inOtherZone, true},
+		{"distinct ids", event, NewBaseEvent("TaskStarted", "task-1"), false},
+		{"different type", event, withType, false},
+		{"different aggregate", event, withAggregate, false},
+		{"different time", event, withTime, false},
+		{"one nil", event, nil, false},
+		{"both nil", nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.a, tt.b) != tt.expected {
+				t.Errorf("Expected Equal to be %v", tt.expected)
+			}
+		})
+	}
+}
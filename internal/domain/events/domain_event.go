@@ -1,8 +1,9 @@
 package events
 
 import (
+	"daily-tracker/pkg/uuid"
 	"encoding/json"
-	"strconv"
+	"fmt"
 	"time"
 )
 
@@ -67,7 +68,7 @@ type BaseEvent struct {
 // NewBaseEvent создает новое базовое событие
 func NewBaseEvent(eventType, aggregateID string) BaseEvent {
 	return BaseEvent{
-		ID:          generateEventID(), // Функцию создадим позже
+		ID:          generateEventID(),
 		Type:        eventType,
 		AggregateId: aggregateID,
 		OccurredAt:  time.Now(),
@@ -101,6 +102,11 @@ func (be BaseEvent) ToJSON() ([]byte, error) {
 	return json.Marshal(be)
 }
 
+// FromJSON базовая десериализация (вместе с ToJSON реализует Serializable)
+func (be *BaseEvent) FromJSON(data []byte) error {
+	return json.Unmarshal(data, be)
+}
+
 // EventStore интерфейс для хранения событий (Event Sourcing)
 type EventStore interface {
 	// SaveEvent сохраняет событие
@@ -109,8 +115,23 @@ type EventStore interface {
 	// GetEvents получает события для агрегата
 	GetEvents(aggregateID string) ([]DomainEvent, error)
 
-	// GetEventsByType получает события определенного типа
+	// GetEventsByType получает до limit событий определенного типа,
+	// начиная с самых свежих (по убыванию OccurredOn)
 	GetEventsByType(eventType string, limit int) ([]DomainEvent, error)
+
+	// GetEventsByTypeSince как GetEventsByType, но только события, возникшие не раньше since
+	GetEventsByTypeSince(eventType string, since time.Time, limit int) ([]DomainEvent, error)
+}
+
+// SnapshotStore интерфейс для снимков состояния агрегатов
+// Снимок избавляет от повторного применения всего потока: version - количество
+// событий агрегата, уже учтенных в снимке
+type SnapshotStore interface {
+	// SaveSnapshot сохраняет снимок состояния агрегата на версии version
+	SaveSnapshot(aggregateID string, version int, state []byte) error
+
+	// LoadSnapshot возвращает последний снимок агрегата; ok == false, если снимка нет
+	LoadSnapshot(aggregateID string) (state []byte, version int, ok bool)
 }
 
 // EventPublisher интерфейс для публикации событий
@@ -122,6 +143,35 @@ type EventPublisher interface {
 	PublishBatch(events []DomainEvent) error
 }
 
+// BatchPublishError сообщает, какие события пакета не удалось опубликовать
+// Издатели, умеющие отчитываться по каждому событию, возвращают ее из PublishBatch,
+// чтобы повторять можно было только неудавшиеся события
+type BatchPublishError struct {
+	Failed []FailedEvent
+}
+
+// FailedEvent событие пакета, которое не удалось опубликовать, и причина
+type FailedEvent struct {
+	Event DomainEvent
+	Err   error
+}
+
+func (e *BatchPublishError) Error() string {
+	if len(e.Failed) == 1 {
+		return "failed to publish 1 event: " + e.Failed[0].Err.Error()
+	}
+	return fmt.Sprintf("failed to publish %d events, first error: %v", len(e.Failed), e.Failed[0].Err)
+}
+
+// Unwrap возвращает ошибки отдельных событий для errors.Is/errors.As
+func (e *BatchPublishError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, failed := range e.Failed {
+		errs = append(errs, failed.Err)
+	}
+	return errs
+}
+
 // EventHandler интерфейс для обработчиков событий
 type EventHandler interface {
 	// Handle обрабатывает событие
@@ -143,7 +193,7 @@ type EventBus interface {
 	Publish(event DomainEvent) error
 }
 
-// Временная функция генерации ID (позже заменим на UUID)
+// generateEventID генерирует случайный UUID версии 4 для идентификатора события
 func generateEventID() string {
-	return "event-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	return uuid.NewV4()
 }
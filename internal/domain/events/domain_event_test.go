@@ -0,0 +1,30 @@
+package events
+
+import (
+	"regexp"
+	"testing"
+)
+
+var uuidV4Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestNewBaseEvent_UniqueIDs(t *testing.T) {
+	const count = 10000
+
+	seen := make(map[string]struct{}, count)
+	for i := 0; i < count; i++ {
+		event := NewBaseEvent("TaskStarted", "task-1")
+
+		if _, duplicate := seen[event.EventID()]; duplicate {
+			t.Fatalf("Duplicate event ID after %d events: %s", i, event.EventID())
+		}
+		seen[event.EventID()] = struct{}{}
+	}
+}
+
+func TestGenerateEventID_UUIDv4Format(t *testing.T) {
+	id := generateEventID()
+
+	if !uuidV4Pattern.MatchString(id) {
+		t.Errorf("Expected UUID v4, got %q", id)
+	}
+}
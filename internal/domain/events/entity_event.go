@@ -0,0 +1,43 @@
+package events
+
+import "time"
+
+// EntityEvent минимальный контракт событий, которые генерируют сущности
+// (совпадает с entities.DomainEvent, но не создает зависимость от пакета entities)
+type EntityEvent interface {
+	OccurredOn() time.Time
+	EventType() string
+}
+
+// StorableEvent адаптер, дополняющий событие сущности идентификатором,
+// ID агрегата и версией, чтобы его можно было сохранить в EventStore
+type StorableEvent struct {
+	BaseEvent
+	payload EntityEvent
+}
+
+// ToStorable оборачивает событие сущности для сохранения и публикации
+// Время события берется из исходного события, версия схемы - 1
+func ToStorable(event EntityEvent, aggregateID string) *StorableEvent {
+	return ToStorableWithID(event, aggregateID, generateEventID())
+}
+
+// ToStorableWithID как ToStorable, но с заданным ID события
+// Нужен, чтобы повторная публикация того же события сохраняла его EventID
+func ToStorableWithID(event EntityEvent, aggregateID, eventID string) *StorableEvent {
+	return &StorableEvent{
+		BaseEvent: BaseEvent{
+			ID:          eventID,
+			Type:        event.EventType(),
+			AggregateId: aggregateID,
+			OccurredAt:  event.OccurredOn(),
+			Version:     1,
+		},
+		payload: event,
+	}
+}
+
+// Payload возвращает исходное событие сущности
+func (e *StorableEvent) Payload() EntityEvent {
+	return e.payload
+}
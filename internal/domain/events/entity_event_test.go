@@ -0,0 +1,53 @@
+package events_test
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/events"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// Тесты во внешнем пакете: entities использует events для восстановления агрегатов,
+// поэтому внутренний тест с импортом entities дал бы цикл
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestToStorable_AdaptsEntityEvent(t *testing.T) {
+	startedAt := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+	defer entities.SetClock(entities.NewFixedClock(startedAt))()
+
+	task, err := entities.NewTaskEntry("task-1", startedAt, 1, "Написать отчет", "работа", 5)
+	if err != nil {
+		t.Fatalf("Failed to create task entry: %v", err)
+	}
+	task.ClearDomainEvents()
+	task.StartTask()
+	entityEvent := task.DomainEvents()[0]
+
+	var storable events.DomainEvent = events.ToStorable(entityEvent, string(task.ID()))
+
+	if storable.EventType() != "TaskStarted" || storable.AggregateID() != "task-1" {
+		t.Errorf("Expected TaskStarted for task-1, got %s for %s", storable.EventType(), storable.AggregateID())
+	}
+
+	if !storable.OccurredOn().Equal(startedAt) {
+		t.Errorf("Expected original occurrence time %v, got %v", startedAt, storable.OccurredOn())
+	}
+
+	if storable.EventVersion() != 1 || !uuidPattern.MatchString(storable.EventID()) {
+		t.Errorf("Expected version 1 and a UUID, got %d and %q", storable.EventVersion(), storable.EventID())
+	}
+
+	if storable.(*events.StorableEvent).Payload() != entityEvent {
+		t.Error("Expected payload to be the original entity event")
+	}
+}
+
+func TestToStorable_UniqueIDsPerWrap(t *testing.T) {
+	event := &entities.TaskStartedEvent{}
+
+	if events.ToStorable(event, "task-1").EventID() == events.ToStorable(event, "task-1").EventID() {
+		t.Error("Expected each adapted event to get its own ID")
+	}
+}
@@ -0,0 +1,69 @@
+package events
+
+import (
+	"daily-tracker/pkg/errors"
+	"encoding/json"
+	"sync"
+)
+
+// EventFactory создает пустой экземпляр события для десериализации
+// Должна возвращать указатель, чтобы в него можно было распаковать JSON
+type EventFactory func() DomainEvent
+
+// EventRegistry сопоставляет сохраненный тип события с конкретным Go-типом
+type EventRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]EventFactory
+}
+
+// NewEventRegistry создает пустой реестр
+func NewEventRegistry() *EventRegistry {
+	return &EventRegistry{
+		factories: make(map[string]EventFactory),
+	}
+}
+
+// Register регистрирует фабрику для типа события
+func (r *EventRegistry) Register(eventType string, factory EventFactory) error {
+	if eventType == "" {
+		return errors.NewDomainError("event type cannot be empty")
+	}
+
+	if factory == nil {
+		return errors.NewDomainError("event factory cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.factories[eventType]; exists {
+		return errors.NewDomainError("event type already registered: " + eventType)
+	}
+
+	r.factories[eventType] = factory
+	return nil
+}
+
+// Deserialize восстанавливает событие зарегистрированного типа из JSON
+// Используется json.Unmarshal, а не FromJSON: у событий со встроенным BaseEvent
+// FromJSON заполнил бы только базовые поля
+func (r *EventRegistry) Deserialize(eventType string, data []byte) (DomainEvent, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[eventType]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, errors.NewNotFoundError("event type", eventType)
+	}
+
+	event := factory()
+	if err := json.Unmarshal(data, event); err != nil {
+		return nil, errors.NewDomainErrorWrap("cannot decode "+eventType+" event", err)
+	}
+
+	if event.EventType() != eventType {
+		return nil, errors.NewDomainError("stored event type " + event.EventType() + " does not match " + eventType)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,101 @@
+package events
+
+import (
+	"daily-tracker/pkg/errors"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type taskStartedRecord struct {
+	BaseEvent
+	StartedAt time.Time `json:"started_at"`
+}
+
+type stressChangedRecord struct {
+	BaseEvent
+	StressBefore int `json:"stress_before"`
+	StressAfter  int `json:"stress_after"`
+}
+
+func newTestRegistry(t *testing.T) *EventRegistry {
+	t.Helper()
+
+	registry := NewEventRegistry()
+	if err := registry.Register("TaskStarted", func() DomainEvent { return &taskStartedRecord{} }); err != nil {
+		t.Fatalf("Failed to register event: %v", err)
+	}
+	if err := registry.Register("StressLevelChanged", func() DomainEvent { return &stressChangedRecord{} }); err != nil {
+		t.Fatalf("Failed to register event: %v", err)
+	}
+
+	return registry
+}
+
+func TestEventRegistry_RoundTrip(t *testing.T) {
+	registry := newTestRegistry(t)
+	startedAt := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		event DomainEvent
+	}{
+		{"task started", &taskStartedRecord{BaseEvent: NewBaseEvent("TaskStarted", "task-1"), StartedAt: startedAt}},
+		{"stress changed", &stressChangedRecord{BaseEvent: NewBaseEvent("StressLevelChanged", "task-1"), StressBefore: 8, StressAfter: 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.event)
+			if err != nil {
+				t.Fatalf("Failed to marshal event: %v", err)
+			}
+
+			restored, err := registry.Deserialize(tt.event.EventType(), data)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			restoredData, _ := json.Marshal(restored)
+			if string(restoredData) != string(data) {
+				t.Errorf("Expected %s, got %s", data, restoredData)
+			}
+		})
+	}
+}
+
+func TestEventRegistry_Errors(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	if _, err := registry.Deserialize("TaskCompleted", []byte(`{}`)); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected NotFoundError for unregistered type, got %v", err)
+	}
+
+	if _, err := registry.Deserialize("TaskStarted", []byte(`{`)); err == nil {
+		t.Error("Expected error for malformed JSON, got nil")
+	}
+
+	data, _ := json.Marshal(NewBaseEvent("StressLevelChanged", "task-1"))
+	if _, err := registry.Deserialize("TaskStarted", data); err == nil {
+		t.Error("Expected error for mismatched stored type, got nil")
+	}
+
+	if err := registry.Register("TaskStarted", func() DomainEvent { return &taskStartedRecord{} }); err == nil {
+		t.Error("Expected error registering a type twice, got nil")
+	}
+}
+
+func TestBaseEvent_FromJSON(t *testing.T) {
+	original := NewBaseEvent("TaskStarted", "task-1")
+	data, _ := original.ToJSON()
+
+	var restored BaseEvent
+	var serializable Serializable = &restored
+	if err := serializable.FromJSON(data); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if restored.EventID() != original.EventID() || !restored.OccurredOn().Equal(original.OccurredOn()) {
+		t.Errorf("Expected %+v, got %+v", original, restored)
+	}
+}
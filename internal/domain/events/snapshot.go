@@ -0,0 +1,45 @@
+package events
+
+import (
+	"daily-tracker/pkg/errors"
+	"fmt"
+)
+
+// ReplayFromSnapshot восстанавливает агрегат из последнего снимка и событий после него
+// restore получает состояние снимка (не вызывается, если снимка нет),
+// apply - каждое событие с позиции после версии снимка в порядке сохранения.
+// Возвращает итоговую версию - количество событий агрегата в потоке
+func ReplayFromSnapshot(
+	store EventStore,
+	snapshots SnapshotStore,
+	aggregateID string,
+	restore func(state []byte) error,
+	apply func(event DomainEvent) error,
+) (int, error) {
+	history, err := store.GetEvents(aggregateID)
+	if err != nil {
+		return 0, err
+	}
+
+	version := 0
+	if state, snapshotVersion, ok := snapshots.LoadSnapshot(aggregateID); ok {
+		if snapshotVersion > len(history) {
+			return 0, errors.NewDomainError(fmt.Sprintf(
+				"snapshot version %d is ahead of event stream with %d events", snapshotVersion, len(history)))
+		}
+
+		if err := restore(state); err != nil {
+			return 0, errors.NewDomainErrorWrap("cannot restore snapshot", err)
+		}
+		version = snapshotVersion
+	}
+
+	for _, event := range history[version:] {
+		if err := apply(event); err != nil {
+			return 0, errors.NewDomainErrorWrap("cannot apply event "+event.EventID(), err)
+		}
+		version++
+	}
+
+	return version, nil
+}
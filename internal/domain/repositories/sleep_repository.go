@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"daily-tracker/internal/domain/entities"
+	"time"
+)
+
+// SleepRepository определяет контракт для работы с записями сна
+// Обычно за ночь есть только одна запись, поэтому FindByDate возвращает одну сущность
+type SleepRepository interface {
+	// Save сохраняет запись сна, заменяя существующую запись за ту же ночь
+	Save(ctx context.Context, entry *entities.SleepEntry) error
+
+	// FindByID находит запись сна по ID
+	FindByID(ctx context.Context, id entities.SleepEntryID) (*entities.SleepEntry, error)
+
+	// FindByDate находит запись сна за дату или возвращает NotFoundError
+	FindByDate(ctx context.Context, date time.Time) (*entities.SleepEntry, error)
+
+	// FindByDateRange находит записи сна в диапазоне дат
+	FindByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*entities.SleepEntry, error)
+
+	// Delete удаляет запись сна
+	Delete(ctx context.Context, id entities.SleepEntryID) error
+
+	// Exists проверяет существование записи
+	Exists(ctx context.Context, id entities.SleepEntryID) (bool, error)
+}
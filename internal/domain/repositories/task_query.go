@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"time"
+)
+
+// TaskSortBy порядок сортировки задач в выборке
+type TaskSortBy int
+
+const (
+	// SortByDateAsc по дате, сначала старые (значение по умолчанию)
+	SortByDateAsc TaskSortBy = iota
+	// SortByDateDesc по дате, сначала новые
+	SortByDateDesc
+	// SortByStressReduction по снижению стресса, сначала наибольшее; задачи без stressAfter - в конце
+	SortByStressReduction
+	// SortByDuration по активному времени, сначала самые долгие
+	SortByDuration
+)
+
+// QueryOptions параметры постраничной выборки
+// Limit 0 означает выборку без ограничения
+type QueryOptions struct {
+	Limit  int
+	Offset int
+	SortBy TaskSortBy
+}
+
+// Page страница результатов и общее число задач, подходящих под условие
+type Page struct {
+	Tasks []*entities.TaskEntry
+	Total int
+}
+
+// PagedTaskReader постраничная выборка задач для больших историй
+type PagedTaskReader interface {
+	// FindByDateRangePaged находит задачи в диапазоне дат с сортировкой и пагинацией
+	FindByDateRangePaged(ctx context.Context, startDate, endDate time.Time, opts QueryOptions) (Page, error)
+}
+
+// TaskFilter условия выборки задач; заданные поля объединяются через И
+// Nil-поля и false не ограничивают выборку, нулевой фильтр возвращает все задачи
+type TaskFilter struct {
+	Category           *valueobjects.TaskCategory // Только задачи категории
+	StartDate          *time.Time                 // Не раньше календарного дня StartDate
+	EndDate            *time.Time                 // Не позже календарного дня EndDate
+	MinStressReduction *int                       // Снижение стресса не меньше (только с записанным стрессом после)
+	StartedOnly        bool                       // Только начатые задачи
+}
+
+// FilteredTaskReader выборка задач по произвольному набору условий
+type FilteredTaskReader interface {
+	// FindByFilter находит задачи, удовлетворяющие всем заданным условиям фильтра
+	FindByFilter(ctx context.Context, filter TaskFilter) ([]*entities.TaskEntry, error)
+}
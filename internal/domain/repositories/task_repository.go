@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
 	"time"
 )
 
@@ -10,6 +11,7 @@ import (
 // В Go интерфейсы маленькие и сфокусированные (Interface Segregation)
 type TaskRepository interface {
 	// Save сохраняет или обновляет запись задачи
+	// Обновление с версией не новее сохраненной отклоняется с ConflictError
 	// context.Context - стандартный способ передачи метаданных в Go
 	Save(ctx context.Context, task *entities.TaskEntry) error
 
@@ -46,9 +48,16 @@ type TaskStatisticsRepository interface {
 type FullTaskRepository interface {
 	TaskRepository           // Встроенный интерфейс
 	TaskStatisticsRepository // Еще один встроенный интерфейс
+	TaskBackupRepository     // Резервное копирование в файл
+}
 
-	// Дополнительные методы только для полной реализации
+// TaskBackupRepository резервное копирование всех задач в файл и восстановление из него
+type TaskBackupRepository interface {
+	// Backup записывает все задачи в JSON-файл filePath
 	Backup(ctx context.Context, filePath string) error
+
+	// Restore заменяет содержимое хранилища задачами из файла filePath
+	// Невалидный файл отклоняется целиком, текущие данные при этом не меняются
 	Restore(ctx context.Context, filePath string) error
 }
 
@@ -66,10 +75,39 @@ type TaskReader interface {
 	FindByDate(ctx context.Context, date time.Time) ([]*entities.TaskEntry, error)
 	FindByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*entities.TaskEntry, error)
 	Exists(ctx context.Context, id entities.TaskEntryID) (bool, error)
+
+	// Count считает задачи в диапазоне дат (включительно), не загружая их
+	Count(ctx context.Context, startDate, endDate time.Time) (int, error)
+}
+
+// TaskCategoryReader выборка задач по категории
+type TaskCategoryReader interface {
+	// FindByCategory находит задачи категории в диапазоне дат (включительно)
+	// Для неизвестной категории возвращает ValidationError без выполнения запроса
+	FindByCategory(ctx context.Context, category valueobjects.TaskCategory, startDate, endDate time.Time) ([]*entities.TaskEntry, error)
+}
+
+// TaskRecycleBin корзина для мягко удаленных задач
+// Удаленные задачи не видны в обычных запросах, пока их не восстановят или не очистят
+type TaskRecycleBin interface {
+	// RestoreDeleted возвращает задачу из корзины или возвращает NotFoundError
+	// (не путать с TaskBackupRepository.Restore, восстанавливающим хранилище из файла)
+	RestoreDeleted(ctx context.Context, id entities.TaskEntryID) error
+
+	// FindDeleted возвращает задачи из корзины
+	FindDeleted(ctx context.Context) ([]*entities.TaskEntry, error)
+
+	// PurgeDeleted окончательно удаляет задачи, удаленные раньше olderThan,
+	// и возвращает их количество
+	PurgeDeleted(ctx context.Context, olderThan time.Time) (int, error)
 }
 
 type TaskWriter interface {
 	Save(ctx context.Context, task *entities.TaskEntry) error
+
+	// SaveBatch сохраняет задачи по принципу "все или ничего":
+	// при ошибке хотя бы одной записи хранилище остается без изменений
+	SaveBatch(ctx context.Context, tasks []*entities.TaskEntry) error
 	Delete(ctx context.Context, id entities.TaskEntryID) error
 }
 
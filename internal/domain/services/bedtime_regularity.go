@@ -0,0 +1,45 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"math"
+	"time"
+)
+
+// BedtimeRegularity оценивает регулярность отхода ко сну: стандартное отклонение
+// времени суток отбоя. Чем меньше, тем регулярнее. Время отсчитывается от полудня,
+// чтобы 23:30 и 00:30 оказались рядом, а не на разных концах суток.
+// Возвращает 0, если записей меньше двух
+func BedtimeRegularity(entries []*entities.SleepEntry) time.Duration {
+	minutes := make([]float64, 0, len(entries))
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+		minutes = append(minutes, minutesAfterNoon(entry.Bedtime()))
+	}
+
+	if len(minutes) < 2 {
+		return 0
+	}
+
+	var sum float64
+	for _, m := range minutes {
+		sum += m
+	}
+	mean := sum / float64(len(minutes))
+
+	var variance float64
+	for _, m := range minutes {
+		variance += (m - mean) * (m - mean)
+	}
+	variance /= float64(len(minutes))
+
+	return time.Duration(math.Sqrt(variance) * float64(time.Minute))
+}
+
+// minutesAfterNoon переводит время суток в минуты после полудня (0 - 12:00, 720 - полночь)
+func minutesAfterNoon(t time.Time) float64 {
+	minuteOfDay := float64(t.Hour()*60+t.Minute()) + float64(t.Second())/60
+	return math.Mod(minuteOfDay-12*60+24*60, 24*60)
+}
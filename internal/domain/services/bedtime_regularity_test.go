@@ -0,0 +1,69 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"testing"
+	"time"
+)
+
+// nightAt создает ночь с отбоем в заданные часы и минуты; ранние часы относятся к следующим суткам
+func nightAt(t *testing.T, day, hour, minute int) *entities.SleepEntry {
+	t.Helper()
+
+	bedtime := time.Date(2025, 8, day, hour, minute, 0, 0, time.UTC)
+	if hour < 12 {
+		bedtime = bedtime.AddDate(0, 0, 1)
+	}
+	return newSleepEntry(t, bedtime, bedtime.Add(8*time.Hour), 7)
+}
+
+func TestBedtimeRegularity(t *testing.T) {
+	tests := []struct {
+		name     string
+		entries  []*entities.SleepEntry
+		expected time.Duration
+	}{
+		{
+			name:     "same bedtime every night",
+			entries:  []*entities.SleepEntry{nightAt(t, 10, 23, 0), nightAt(t, 11, 23, 0), nightAt(t, 12, 23, 0)},
+			expected: 0,
+		},
+		{
+			// 23:30 и 00:30 - разница час, отклонение 30 минут, а не почти 12 часов
+			name:     "around midnight",
+			entries:  []*entities.SleepEntry{nightAt(t, 10, 23, 30), nightAt(t, 11, 0, 30)},
+			expected: 30 * time.Minute,
+		},
+		{
+			// 21:00 и 03:00 - разница 6 часов, отклонение 3 часа
+			name:     "wildly varying",
+			entries:  []*entities.SleepEntry{nightAt(t, 10, 21, 0), nightAt(t, 11, 3, 0), nil},
+			expected: 3 * time.Hour,
+		},
+		{
+			name:     "single entry",
+			entries:  []*entities.SleepEntry{nightAt(t, 10, 23, 0)},
+			expected: 0,
+		},
+		{
+			name:     "no entries",
+			entries:  nil,
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := BedtimeRegularity(tt.entries)
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	consistent := BedtimeRegularity([]*entities.SleepEntry{nightAt(t, 10, 22, 50), nightAt(t, 11, 23, 10), nightAt(t, 12, 23, 0)})
+	varying := BedtimeRegularity([]*entities.SleepEntry{nightAt(t, 10, 21, 0), nightAt(t, 11, 1, 30), nightAt(t, 12, 23, 0)})
+	if consistent >= varying {
+		t.Errorf("Expected consistent bedtimes to score lower, got %v vs %v", consistent, varying)
+	}
+}
@@ -0,0 +1,9 @@
+package services
+
+import "time"
+
+// calendarDay приводит момент к полуночи его календарной даты, чтобы даты
+// из разных поясов можно было сравнивать и использовать как ключ
+func calendarDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+)
+
+// StressByCategory возвращает среднее снижение стресса по категориям задач
+// Учитываются только задачи с записанным уровнем стресса после;
+// категории без таких задач в результат не попадают
+func StressByCategory(tasks []*entities.TaskEntry) map[valueobjects.TaskCategory]float64 {
+	sums := make(map[valueobjects.TaskCategory]int)
+	counts := make(map[valueobjects.TaskCategory]int)
+	for _, task := range tasks {
+		if task == nil || !task.HasStressAfter() {
+			continue
+		}
+
+		sums[task.Category()] += task.CalculateStressReduction()
+		counts[task.Category()]++
+	}
+
+	averages := make(map[valueobjects.TaskCategory]float64, len(counts))
+	for category, count := range counts {
+		averages[category] = float64(sums[category]) / float64(count)
+	}
+	return averages
+}
+
+// MostStressfulCategory возвращает категорию с наименьшим средним снижением стресса
+// (отрицательное среднее - стресс в среднем растет). При равенстве выбирается
+// категория, меньшая по строковому значению. ok == false, если данных нет
+func MostStressfulCategory(tasks []*entities.TaskEntry) (category valueobjects.TaskCategory, ok bool) {
+	var worst float64
+	for candidate, average := range StressByCategory(tasks) {
+		if !ok || average < worst || (average == worst && candidate < category) {
+			category, worst, ok = candidate, average, true
+		}
+	}
+	return category, ok
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"testing"
+	"time"
+)
+
+func TestStressByCategory(t *testing.T) {
+	day := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+
+	// Задача без записанного стресса после не учитывается
+	unrated, _ := entities.NewTaskEntry("unrated", day, 1, "Test task", valueobjects.TaskCategoryWork, 9)
+
+	tasks := []*entities.TaskEntry{
+		newTaskEntry(t, day, "работа", 8, 6),
+		newTaskEntry(t, day.Add(time.Hour), "работа", 5, 7),
+		newTaskEntry(t, day.Add(2*time.Hour), "работа", 6, 6),
+		newTaskEntry(t, day, "учеба", 7, 3),
+		newTaskEntry(t, day.Add(time.Hour), "учеба", 6, 4),
+		unrated,
+		nil,
+	}
+
+	averages := StressByCategory(tasks)
+
+	expected := map[valueobjects.TaskCategory]float64{
+		valueobjects.TaskCategoryWork:  0,
+		valueobjects.TaskCategoryStudy: 3,
+	}
+	if len(averages) != len(expected) {
+		t.Fatalf("Expected %d categories, got %v", len(expected), averages)
+	}
+	for category, average := range expected {
+		if !almostEqual(averages[category], average) {
+			t.Errorf("Expected average %.2f for %s, got %.2f", average, category, averages[category])
+		}
+	}
+
+	worst, ok := MostStressfulCategory(tasks)
+	if !ok || worst != valueobjects.TaskCategoryWork {
+		t.Errorf("Expected %s to be the most stressful, got %s (ok=%v)", valueobjects.TaskCategoryWork, worst, ok)
+	}
+}
+
+func TestMostStressfulCategory_NoData(t *testing.T) {
+	unrated, _ := entities.NewTaskEntry("unrated", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC), 1, "Test task", valueobjects.TaskCategoryWork, 9)
+
+	if category, ok := MostStressfulCategory([]*entities.TaskEntry{unrated}); ok {
+		t.Errorf("Expected no category without rated tasks, got %s", category)
+	}
+
+	if averages := StressByCategory(nil); len(averages) != 0 {
+		t.Errorf("Expected empty result for no tasks, got %v", averages)
+	}
+}
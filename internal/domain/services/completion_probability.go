@@ -0,0 +1,81 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"daily-tracker/pkg/errors"
+)
+
+// Пороги сходства задач при оценке вероятности завершения
+const (
+	similarStressDelta = 2 // Допустимая разница стресса до начала
+	similarHourDelta   = 2 // Допустимая разница часа (по кругу суток)
+)
+
+// EstimateCompletionProbability оценивает вероятность (0-1) завершить задачу
+// по доле завершенных среди похожих задач истории: та же категория, стресс до начала
+// в пределах similarStressDelta и час в пределах similarHourDelta с учетом перехода через полночь.
+// Час задачи берется из времени начала, а у не начатых - из даты задачи.
+// Возвращает ошибку, если похожих задач в истории нет
+func EstimateCompletionProbability(
+	category valueobjects.TaskCategory,
+	stressBefore valueobjects.StressLevel,
+	hourOfDay int,
+	history []*entities.TaskEntry,
+) (float64, error) {
+	if !category.IsValid() {
+		return 0, errors.NewValidationError("category", "invalid task category: "+category.String())
+	}
+
+	if hourOfDay < 0 || hourOfDay > 23 {
+		return 0, errors.NewDomainError("hour of day must be between 0 and 23")
+	}
+
+	var comparable, completed int
+	for _, task := range history {
+		if task == nil || task.Category() != category {
+			continue
+		}
+
+		if abs(int(task.StressBefore())-int(stressBefore)) > similarStressDelta {
+			continue
+		}
+
+		if hourDistance(taskHour(task), hourOfDay) > similarHourDelta {
+			continue
+		}
+
+		comparable++
+		if task.IsCompleted() {
+			completed++
+		}
+	}
+
+	if comparable == 0 {
+		return 0, errors.NewDomainError("no comparable tasks in history")
+	}
+
+	return float64(completed) / float64(comparable), nil
+}
+
+// taskHour час задачи: время начала, а для не начатой - время из даты
+func taskHour(task *entities.TaskEntry) int {
+	if start := task.StartTime(); start != nil {
+		return start.Hour()
+	}
+	return task.Date().Hour()
+}
+
+// hourDistance расстояние между часами по кругу суток (23 и 1 - соседние)
+func hourDistance(a, b int) int {
+	d := abs(a - b)
+	return min(d, 24-d)
+}
+
+// abs модуль целого числа
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
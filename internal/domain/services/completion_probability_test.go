@@ -0,0 +1,95 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"daily-tracker/pkg/errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// historyTask создает задачу в заданный час; завершенная задача начинается и завершается в этот же час
+func historyTask(t *testing.T, day, hour int, category valueobjects.TaskCategory, stressBefore valueobjects.StressLevel, completed bool) *entities.TaskEntry {
+	t.Helper()
+
+	date := time.Date(2025, 8, day, hour, 0, 0, 0, time.UTC)
+	restore := entities.SetClock(entities.NewFixedClock(date))
+	defer restore()
+
+	id := entities.TaskEntryID(fmt.Sprintf("task-%d-%d", day, hour))
+	task, err := entities.NewTaskEntry(id, date, 1, "Test task", category, stressBefore)
+	if err != nil {
+		t.Fatalf("Failed to create task entry: %v", err)
+	}
+
+	if completed {
+		task.StartTask()
+		task.CompleteTask()
+	}
+	return task
+}
+
+func TestEstimateCompletionProbability_MorningVsLateNight(t *testing.T) {
+	var history []*entities.TaskEntry
+	for day := 1; day <= 5; day++ {
+		// Утренняя работа завершается в 4 случаях из 5, ночная - в 1 из 5
+		history = append(history, historyTask(t, day, 9, valueobjects.TaskCategoryWork, 6, day <= 4))
+		history = append(history, historyTask(t, day, 23, valueobjects.TaskCategoryWork, 6, day == 1))
+	}
+	// Другие категории и далекий стресс не влияют на оценку
+	history = append(history, historyTask(t, 6, 9, valueobjects.TaskCategoryStudy, 6, false))
+	history = append(history, historyTask(t, 7, 9, valueobjects.TaskCategoryWork, 1, false))
+
+	tests := []struct {
+		name     string
+		hour     int
+		stress   valueobjects.StressLevel
+		expected float64
+	}{
+		{"morning", 9, 6, 0.8},
+		{"near morning", 10, 7, 0.8},
+		{"late night", 23, 6, 0.2},
+		{"after midnight", 0, 5, 0.2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probability, err := EstimateCompletionProbability(valueobjects.TaskCategoryWork, tt.stress, tt.hour, history)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if !almostEqual(probability, tt.expected) {
+				t.Errorf("Expected probability %.2f, got %.2f", tt.expected, probability)
+			}
+		})
+	}
+}
+
+func TestEstimateCompletionProbability_Errors(t *testing.T) {
+	history := []*entities.TaskEntry{
+		historyTask(t, 1, 9, valueobjects.TaskCategoryWork, 6, true),
+		nil,
+	}
+
+	tests := []struct {
+		name     string
+		category valueobjects.TaskCategory
+		hour     int
+		check    func(error) bool
+	}{
+		{"no comparable history", valueobjects.TaskCategoryWork, 15, errors.IsDomainError},
+		{"other category", valueobjects.TaskCategoryStudy, 9, errors.IsDomainError},
+		{"invalid hour", valueobjects.TaskCategoryWork, 24, errors.IsDomainError},
+		{"invalid category", valueobjects.TaskCategory("несуществующая"), 9, errors.IsValidationError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := EstimateCompletionProbability(tt.category, 6, tt.hour, history); !tt.check(err) {
+				t.Errorf("Expected error, got: %v", err)
+			}
+		})
+	}
+}
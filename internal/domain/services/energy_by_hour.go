@@ -0,0 +1,37 @@
+package services
+
+import "daily-tracker/internal/domain/entities"
+
+// EnergyByHourOfDay возвращает средний уровень энергии по часу начала задачи (0-23)
+// Час берется из времени начала в его собственном поясе; не начатые задачи пропускаются
+func EnergyByHourOfDay(tasks []*entities.TaskEntry) map[int]float64 {
+	sums := make(map[int]int)
+	counts := make(map[int]int)
+	for _, task := range tasks {
+		if task == nil || task.StartTime() == nil {
+			continue
+		}
+
+		hour := task.StartTime().Hour()
+		sums[hour] += task.Energy().Int()
+		counts[hour]++
+	}
+
+	averages := make(map[int]float64, len(counts))
+	for hour, count := range counts {
+		averages[hour] = float64(sums[hour]) / float64(count)
+	}
+	return averages
+}
+
+// PeakEnergyHour возвращает час с наибольшей средней энергией
+// При равенстве выбирается более ранний час; ok == false, если начатых задач нет
+func PeakEnergyHour(tasks []*entities.TaskEntry) (hour int, ok bool) {
+	var peak float64
+	for candidate, average := range EnergyByHourOfDay(tasks) {
+		if !ok || average > peak || (average == peak && candidate < hour) {
+			hour, peak, ok = candidate, average, true
+		}
+	}
+	return hour, ok
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"testing"
+	"time"
+)
+
+// energeticTask создает задачу, начатую в заданный час, с указанной энергией
+func energeticTask(t *testing.T, id string, day, hour int, energy valueobjects.EnergyLevel) *entities.TaskEntry {
+	t.Helper()
+
+	task := startedTask(t, id, time.Date(2025, 8, day, hour, 15, 0, 0, time.UTC), time.Hour)
+	task.SetEnergy(energy)
+	return task
+}
+
+func TestEnergyByHourOfDay(t *testing.T) {
+	unstarted, _ := entities.NewTaskEntry("unstarted", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC), 1, "Test task", valueobjects.TaskCategoryWork, 5)
+	unstarted.SetEnergy(10)
+
+	tasks := []*entities.TaskEntry{
+		energeticTask(t, "morning-1", 11, 9, 8),
+		energeticTask(t, "morning-2", 12, 9, 6),
+		energeticTask(t, "afternoon", 12, 14, 4),
+		energeticTask(t, "evening", 12, 21, 2),
+		unstarted,
+		nil,
+	}
+
+	averages := EnergyByHourOfDay(tasks)
+
+	expected := map[int]float64{9: 7, 14: 4, 21: 2}
+	if len(averages) != len(expected) {
+		t.Fatalf("Expected %d hours, got %v", len(expected), averages)
+	}
+	for hour, average := range expected {
+		if !almostEqual(averages[hour], average) {
+			t.Errorf("Expected average %.2f at %d:00, got %.2f", average, hour, averages[hour])
+		}
+	}
+
+	peak, ok := PeakEnergyHour(tasks)
+	if !ok || peak != 9 {
+		t.Errorf("Expected peak at 9:00, got %d (ok=%v)", peak, ok)
+	}
+}
+
+func TestPeakEnergyHour_TiesAndNoData(t *testing.T) {
+	tasks := []*entities.TaskEntry{
+		energeticTask(t, "late", 12, 16, 7),
+		energeticTask(t, "early", 12, 10, 7),
+	}
+
+	if peak, ok := PeakEnergyHour(tasks); !ok || peak != 10 {
+		t.Errorf("Expected the earlier hour 10 on a tie, got %d (ok=%v)", peak, ok)
+	}
+
+	if peak, ok := PeakEnergyHour(nil); ok {
+		t.Errorf("Expected no peak without tasks, got %d", peak)
+	}
+}
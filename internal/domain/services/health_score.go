@@ -0,0 +1,79 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"math"
+)
+
+// Веса компонентов DailyHealthScore (в сумме 100)
+const (
+	healthWeightSleep        = 40.0 // Сон: оценка качества и факторы здорового сна
+	healthWeightWellbeing    = 35.0 // Среднее настроение и энергия по задачам дня
+	healthWeightStressRelief = 25.0 // Суммарное снижение стресса за день
+)
+
+// DailyHealthScore сводит сон и самочувствие за день в одно число 0-100.
+// Каждый компонент оценивается долей от 0 до 1:
+//   - сон - поровну оценка качества и доля выполненных QualityFactors;
+//   - самочувствие - среднее нормализованных настроения и энергии по задачам;
+//   - стресс - суммарное снижение стресса по задачам с записанным стрессом после,
+//     деленное на 10 и ограниченное диапазоном 0-1 (рост стресса дает 0).
+//
+// Итог - взвешенное среднее только доступных компонентов: без сна оцениваются задачи,
+// без задач - только сон, без стресса после - сон и самочувствие. Без данных возвращает 0
+func DailyHealthScore(sleep *entities.SleepEntry, tasks []*entities.TaskEntry) int {
+	var weighted, totalWeight float64
+
+	if sleep != nil {
+		weighted += healthWeightSleep * sleepComponent(sleep)
+		totalWeight += healthWeightSleep
+	}
+
+	var wellbeingSum, stressReduction float64
+	var taskCount, stressMeasured int
+	for _, task := range tasks {
+		if task == nil {
+			continue
+		}
+
+		wellbeingSum += (task.Mood().Normalized() + task.Energy().Normalized()) / 2
+		taskCount++
+
+		if task.HasStressAfter() {
+			stressReduction += float64(task.CalculateStressReduction())
+			stressMeasured++
+		}
+	}
+
+	if taskCount > 0 {
+		weighted += healthWeightWellbeing * wellbeingSum / float64(taskCount)
+		totalWeight += healthWeightWellbeing
+	}
+
+	if stressMeasured > 0 {
+		relief := math.Max(0, math.Min(stressReduction/valueobjects.StressLevelMax, 1))
+		weighted += healthWeightStressRelief * relief
+		totalWeight += healthWeightStressRelief
+	}
+
+	if totalWeight == 0 {
+		return 0
+	}
+
+	return int(math.Round(weighted / totalWeight * 100))
+}
+
+// sleepComponent оценивает ночь долей от 0 до 1
+func sleepComponent(sleep *entities.SleepEntry) float64 {
+	factors := sleep.QualityFactors()
+
+	satisfied := 0
+	for _, ok := range factors {
+		if ok {
+			satisfied++
+		}
+	}
+
+	return (sleep.SleepQuality().Normalized() + float64(satisfied)/float64(len(factors))) / 2
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"testing"
+	"time"
+)
+
+// feelingTask создает задачу дня с заданными настроением, энергией и стрессом до/после
+func feelingTask(t *testing.T, hour int, mood, energy, stressBefore, stressAfter int) *entities.TaskEntry {
+	t.Helper()
+
+	task := newTaskEntry(t, time.Date(2025, 8, 12, hour, 0, 0, 0, time.UTC), "работа", stressBefore, stressAfter)
+	task.SetMood(valueobjects.MoodLevel(mood))
+	task.SetEnergy(valueobjects.EnergyLevel(energy))
+	return task
+}
+
+func TestDailyHealthScore(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	greatNight := newSleepEntry(t, bedtime, bedtime.Add(8*time.Hour), 10)
+
+	poorNight, err := entities.NewSleepEntry("poor", bedtime.Add(time.Hour), bedtime, bedtime.Add(5*time.Hour), 2,
+		entities.WithSleepLatency(time.Hour),
+		entities.WithNightAwakenings(4),
+		entities.WithCaffeineAfterNoon(true),
+		entities.WithScreenUseBeforeBed(3*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create sleep entry: %v", err)
+	}
+
+	// Задача без записанного стресса после
+	unrated, _ := entities.NewTaskEntry("unrated", bedtime.Add(12*time.Hour), 1, "Test task", valueobjects.TaskCategoryWork, 5,
+		entities.WithMood(6), entities.WithEnergy(4))
+
+	greatDay := []*entities.TaskEntry{
+		feelingTask(t, 9, 10, 10, 8, 2),
+		feelingTask(t, 14, 10, 10, 6, 2),
+	}
+
+	tests := []struct {
+		name     string
+		sleep    *entities.SleepEntry
+		tasks    []*entities.TaskEntry
+		expected int
+	}{
+		{"great day", greatNight, greatDay, 100},
+		// Сон 0.1 * 40 + самочувствие 0.15 * 35 + рост стресса 0 * 25 = 9.25
+		{"poor day", poorNight, []*entities.TaskEntry{feelingTask(t, 9, 2, 1, 3, 6)}, 9},
+		{"no sleep entry", nil, greatDay, 100},
+		{"no tasks", poorNight, nil, 10},
+		// Без стресса после: (1.0 * 40 + 0.5 * 35) / 75
+		{"no stress measured", greatNight, []*entities.TaskEntry{unrated, nil}, 77},
+		{"no data", nil, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := DailyHealthScore(tt.sleep, tt.tasks)
+			if score != tt.expected {
+				t.Errorf("Expected score %d, got %d", tt.expected, score)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/pkg/errors"
+	"time"
+)
+
+// PeriodMetrics ключевые показатели за период
+type PeriodMetrics struct {
+	TaskCount              int
+	StartedTasks           int
+	AverageStressReduction float64
+	AverageActiveMinutes   float64
+	SleepNights            int
+	AverageSleepHours      float64
+	AverageSleepQuality    float64
+	HealthySleepNights     int
+}
+
+// PeriodComparison результат сравнения двух периодов.
+// Delta содержит разницу B - A: положительное значение означает рост показателя
+type PeriodComparison struct {
+	PeriodAStart time.Time
+	PeriodBStart time.Time
+	Length       time.Duration
+	A            PeriodMetrics
+	B            PeriodMetrics
+	Delta        PeriodMetrics
+}
+
+// ComparePeriods считает показатели для двух окон [start, start+length) и их разницу
+func ComparePeriods(
+	tasks []*entities.TaskEntry,
+	sleep []*entities.SleepEntry,
+	periodAStart, periodBStart time.Time,
+	length time.Duration,
+) (*PeriodComparison, error) {
+	if length <= 0 {
+		return nil, errors.NewDomainError("period length must be positive")
+	}
+
+	periodAEnd := periodAStart.Add(length)
+	periodBEnd := periodBStart.Add(length)
+	if periodAStart.Before(periodBEnd) && periodBStart.Before(periodAEnd) {
+		return nil, errors.NewDomainError("compared periods must not overlap")
+	}
+
+	a := calculatePeriodMetrics(tasks, sleep, periodAStart, periodAEnd)
+	b := calculatePeriodMetrics(tasks, sleep, periodBStart, periodBEnd)
+
+	return &PeriodComparison{
+		PeriodAStart: periodAStart,
+		PeriodBStart: periodBStart,
+		Length:       length,
+		A:            a,
+		B:            b,
+		Delta: PeriodMetrics{
+			TaskCount:              b.TaskCount - a.TaskCount,
+			StartedTasks:           b.StartedTasks - a.StartedTasks,
+			AverageStressReduction: b.AverageStressReduction - a.AverageStressReduction,
+			AverageActiveMinutes:   b.AverageActiveMinutes - a.AverageActiveMinutes,
+			SleepNights:            b.SleepNights - a.SleepNights,
+			AverageSleepHours:      b.AverageSleepHours - a.AverageSleepHours,
+			AverageSleepQuality:    b.AverageSleepQuality - a.AverageSleepQuality,
+			HealthySleepNights:     b.HealthySleepNights - a.HealthySleepNights,
+		},
+	}, nil
+}
+
+// calculatePeriodMetrics считает показатели по записям, попавшим в окно [start, end)
+func calculatePeriodMetrics(tasks []*entities.TaskEntry, sleep []*entities.SleepEntry, start, end time.Time) PeriodMetrics {
+	var metrics PeriodMetrics
+
+	var stressReductionSum, activeMinutesSum float64
+	var ratedTasks int
+	for _, task := range tasks {
+		if task == nil || !inWindow(task.Date(), start, end) {
+			continue
+		}
+
+		metrics.TaskCount++
+		if task.Started() {
+			metrics.StartedTasks++
+		}
+		// Снижение стресса учитывается только по задачам с записанным стрессом после
+		if task.HasStressAfter() {
+			stressReductionSum += float64(task.CalculateStressReduction())
+			ratedTasks++
+		}
+		activeMinutesSum += task.ActiveDuration().Minutes()
+	}
+
+	if ratedTasks > 0 {
+		metrics.AverageStressReduction = stressReductionSum / float64(ratedTasks)
+	}
+
+	if metrics.TaskCount > 0 {
+		metrics.AverageActiveMinutes = activeMinutesSum / float64(metrics.TaskCount)
+	}
+
+	var sleepHoursSum, sleepQualitySum float64
+	for _, entry := range sleep {
+		if entry == nil || !inWindow(entry.Date(), start, end) {
+			continue
+		}
+
+		metrics.SleepNights++
+		sleepHoursSum += entry.TotalSleepHours()
+		sleepQualitySum += float64(entry.SleepQuality().Int())
+		if entry.IsSleepHealthy() {
+			metrics.HealthySleepNights++
+		}
+	}
+
+	if metrics.SleepNights > 0 {
+		metrics.AverageSleepHours = sleepHoursSum / float64(metrics.SleepNights)
+		metrics.AverageSleepQuality = sleepQualitySum / float64(metrics.SleepNights)
+	}
+
+	return metrics
+}
+
+// inWindow проверяет попадание момента в полуинтервал [start, end)
+func inWindow(moment, start, end time.Time) bool {
+	return !moment.Before(start) && moment.Before(end)
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestComparePeriods_ImprovingMonth(t *testing.T) {
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	august := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	length := 31 * 24 * time.Hour
+
+	var tasks []*entities.TaskEntry
+	var sleep []*entities.SleepEntry
+	for day := 0; day < 3; day++ {
+		// Июль: стресс почти не снижается, сон короткий
+		julyDay := july.AddDate(0, 0, day*7)
+		tasks = append(tasks, newTaskEntry(t, julyDay, "работа", 8, 7))
+		sleep = append(sleep, newSleepEntry(t, julyDay.Add(-time.Hour), julyDay.Add(5*time.Hour), 4))
+
+		// Август: стресс снижается сильнее, сон здоровый
+		augustDay := august.AddDate(0, 0, day*7)
+		tasks = append(tasks, newTaskEntry(t, augustDay, "работа", 8, 3))
+		sleep = append(sleep, newSleepEntry(t, augustDay.Add(-time.Hour), augustDay.Add(7*time.Hour), 8))
+	}
+
+	comparison, err := ComparePeriods(tasks, sleep, july, august, length)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if comparison.A.TaskCount != 3 || comparison.B.TaskCount != 3 {
+		t.Errorf("Expected 3 tasks per period, got %d and %d", comparison.A.TaskCount, comparison.B.TaskCount)
+	}
+
+	if !almostEqual(comparison.Delta.AverageStressReduction, 4) {
+		t.Errorf("Expected stress reduction delta 4, got %f", comparison.Delta.AverageStressReduction)
+	}
+
+	if !almostEqual(comparison.Delta.AverageSleepHours, 2) {
+		t.Errorf("Expected sleep hours delta 2, got %f", comparison.Delta.AverageSleepHours)
+	}
+
+	if !almostEqual(comparison.Delta.AverageSleepQuality, 4) {
+		t.Errorf("Expected sleep quality delta 4, got %f", comparison.Delta.AverageSleepQuality)
+	}
+
+	if comparison.Delta.HealthySleepNights != 3 {
+		t.Errorf("Expected 3 more healthy nights, got %d", comparison.Delta.HealthySleepNights)
+	}
+}
+
+func TestComparePeriods_Errors(t *testing.T) {
+	start := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		periodBStart time.Time
+		length       time.Duration
+	}{
+		{"zero length", start.AddDate(0, 1, 0), 0},
+		{"negative length", start.AddDate(0, 1, 0), -time.Hour},
+		{"overlapping windows", start.AddDate(0, 0, 10), 30 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ComparePeriods(nil, nil, start, tt.periodBStart, tt.length)
+			if err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestComparePeriods_SkipsUnratedStress(t *testing.T) {
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	august := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	tasks := []*entities.TaskEntry{
+		newTaskEntry(t, july, "работа", 8, 7),
+		// Без stressAfter снижение равнялось бы stressBefore (9) и завысило бы среднее
+		newUnratedTaskEntry(t, july.Add(time.Hour), "учеба", 9),
+		newUnratedTaskEntry(t, august, "работа", 9),
+	}
+
+	comparison, err := ComparePeriods(tasks, nil, july, august, 31*24*time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if comparison.A.TaskCount != 2 || comparison.B.TaskCount != 1 {
+		t.Errorf("Expected unrated tasks to be counted, got %d and %d", comparison.A.TaskCount, comparison.B.TaskCount)
+	}
+
+	if !almostEqual(comparison.A.AverageStressReduction, 1) {
+		t.Errorf("Expected average reduction 1 over rated tasks, got %f", comparison.A.AverageStressReduction)
+	}
+
+	if comparison.B.AverageStressReduction != 0 {
+		t.Errorf("Expected 0 reduction for a period without rated tasks, got %f", comparison.B.AverageStressReduction)
+	}
+}
+
+// Вспомогательная функция для создания задачи с заданным снижением стресса
+func newTaskEntry(t *testing.T, date time.Time, category string, stressBefore, stressAfter int) *entities.TaskEntry {
+	t.Helper()
+
+	taskCategory, err := valueobjects.NewTaskCategory(category)
+	if err != nil {
+		t.Fatalf("Failed to create category: %v", err)
+	}
+
+	before, err := valueobjects.NewStressLevel(stressBefore)
+	if err != nil {
+		t.Fatalf("Failed to create stress level: %v", err)
+	}
+
+	after, err := valueobjects.NewStressLevel(stressAfter)
+	if err != nil {
+		t.Fatalf("Failed to create stress level: %v", err)
+	}
+
+	id := entities.TaskEntryID(fmt.Sprintf("task-%s-%s", date.Format("2006-01-02T15:04"), category))
+	task, err := entities.NewTaskEntry(id, date, 1, "Test task", taskCategory, before)
+	if err != nil {
+		t.Fatalf("Failed to create task entry: %v", err)
+	}
+	task.SetStressAfter(after)
+
+	return task
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
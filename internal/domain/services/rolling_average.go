@@ -0,0 +1,75 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"time"
+)
+
+// RollingAverageSleepHours средний ночной сон за window дней, заканчивающихся endDate
+// Значение дня - сумма сна всех его записей; дни без записей не учитываются
+// (не считаются нулем). Возвращает 0, если в окне нет данных или window < 1
+func RollingAverageSleepHours(entries []*entities.SleepEntry, endDate time.Time, window int) float64 {
+	if window < 1 {
+		return 0
+	}
+
+	start, end := rollingWindow(endDate, window)
+	totals := make(map[time.Time]float64)
+	for _, entry := range entries {
+		if entry == nil || !inWindow(calendarDay(entry.Date()), start, end) {
+			continue
+		}
+		totals[calendarDay(entry.Date())] += entry.TotalSleepHours()
+	}
+
+	return meanOf(totals)
+}
+
+// RollingAverageStressReduction среднее снижение стресса за window дней, заканчивающихся endDate
+// Значение дня - среднее по задачам с записанным стрессом после; дни без таких задач
+// не учитываются. Возвращает 0, если в окне нет данных или window < 1
+func RollingAverageStressReduction(tasks []*entities.TaskEntry, endDate time.Time, window int) float64 {
+	if window < 1 {
+		return 0
+	}
+
+	start, end := rollingWindow(endDate, window)
+	sums := make(map[time.Time]int)
+	counts := make(map[time.Time]int)
+	for _, task := range tasks {
+		if task == nil || !task.HasStressAfter() || !inWindow(calendarDay(task.Date()), start, end) {
+			continue
+		}
+
+		day := calendarDay(task.Date())
+		sums[day] += task.CalculateStressReduction()
+		counts[day]++
+	}
+
+	averages := make(map[time.Time]float64, len(counts))
+	for day, count := range counts {
+		averages[day] = float64(sums[day]) / float64(count)
+	}
+
+	return meanOf(averages)
+}
+
+// rollingWindow возвращает полуинтервал календарных дат [start, end) из window дней,
+// последний из которых - дата endDate
+func rollingWindow(endDate time.Time, window int) (start, end time.Time) {
+	end = calendarDay(endDate).AddDate(0, 0, 1)
+	return end.AddDate(0, 0, -window), end
+}
+
+// meanOf среднее значение по дням, 0 для пустого набора
+func meanOf(values map[time.Time]float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
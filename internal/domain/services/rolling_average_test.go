@@ -0,0 +1,92 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"testing"
+	"time"
+)
+
+func TestRollingAverageSleepHours(t *testing.T) {
+	endDate := time.Date(2025, 8, 14, 0, 0, 0, 0, time.UTC)
+
+	// Полная неделя 8-14 августа: четыре ночи по 8ч и три по 5ч, плюс ночь вне окна
+	full := []*entities.SleepEntry{
+		nightOf(t, 7, false),
+		nightOf(t, 8, true),
+		nightOf(t, 9, false),
+		nightOf(t, 10, true),
+		nightOf(t, 11, false),
+		nightOf(t, 12, true),
+		nightOf(t, 13, false),
+		nightOf(t, 14, true),
+		nil,
+	}
+
+	// Пропущенные дни не тянут среднее вниз
+	sparse := []*entities.SleepEntry{
+		nightOf(t, 9, true),
+		nightOf(t, 14, false),
+	}
+
+	tests := []struct {
+		name     string
+		entries  []*entities.SleepEntry
+		window   int
+		expected float64
+	}{
+		{"full window", full, 7, 47.0 / 7},
+		{"sparse window", sparse, 7, 6.5},
+		{"single day window", full, 1, 8},
+		{"only latest night in window", sparse, 3, 5},
+		{"no data in window", sparse[:1], 3, 0},
+		{"empty input", nil, 7, 0},
+		{"zero window", full, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RollingAverageSleepHours(tt.entries, endDate, tt.window)
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestRollingAverageStressReduction(t *testing.T) {
+	endDate := time.Date(2025, 8, 14, 18, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2025, 8, d, 9, 0, 0, 0, time.UTC) }
+
+	tasks := []*entities.TaskEntry{
+		// 14 августа: две задачи, среднее за день (4 + 2) / 2 = 3
+		newTaskEntry(t, day(14), "работа", 8, 4),
+		newTaskEntry(t, day(14).Add(time.Hour), "учеба", 6, 4),
+		// 10 августа: одна задача, снижение 1
+		newTaskEntry(t, day(10), "работа", 5, 4),
+		// 7 августа - вне недельного окна
+		newTaskEntry(t, day(7), "работа", 9, 0),
+	}
+
+	tests := []struct {
+		name     string
+		window   int
+		expected float64
+	}{
+		{"sparse week", 7, 2},
+		{"today only", 1, 3},
+		{"wider window", 8, 13.0 / 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RollingAverageStressReduction(tasks, endDate, tt.window)
+			if !almostEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	if result := RollingAverageStressReduction(nil, endDate, 7); result != 0 {
+		t.Errorf("Expected 0 for no data, got %v", result)
+	}
+}
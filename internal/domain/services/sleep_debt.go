@@ -0,0 +1,76 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/pkg/errors"
+	"sort"
+	"time"
+)
+
+// NightlySleepDebt вклад одной ночи в недосып.
+// Debt = цель - фактический сон: отрицательное значение означает избыток сна
+type NightlySleepDebt struct {
+	SleepEntryID entities.SleepEntryID
+	Date         time.Time
+	SleepHours   float64
+	Debt         float64
+}
+
+// SleepDebtReport накопленный недосып и его разбивка по ночам (в хронологическом порядке)
+type SleepDebtReport struct {
+	TargetHoursPerNight float64
+	TotalDebt           float64
+	Nights              []NightlySleepDebt
+}
+
+// CalculateSleepDebt считает накопленный недосып относительно цели на ночь.
+// Если allowSurplusOffset выключен, ночи с избытком сна просто не добавляют долга.
+// Если включен, избыток гасит уже накопленный долг, но не уходит ниже нуля:
+// выспаться "впрок" нельзя, поэтому ночи обрабатываются по порядку дат
+func CalculateSleepDebt(
+	entries []*entities.SleepEntry,
+	targetHoursPerNight float64,
+	allowSurplusOffset bool,
+) (*SleepDebtReport, error) {
+	if targetHoursPerNight <= 0 {
+		return nil, errors.NewDomainError("target sleep hours must be positive")
+	}
+
+	report := &SleepDebtReport{
+		TargetHoursPerNight: targetHoursPerNight,
+		Nights:              make([]NightlySleepDebt, 0, len(entries)),
+	}
+
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+
+		report.Nights = append(report.Nights, NightlySleepDebt{
+			SleepEntryID: entry.ID(),
+			Date:         entry.Date(),
+			SleepHours:   entry.TotalSleepHours(),
+			Debt:         targetHoursPerNight - entry.TotalSleepHours(),
+		})
+	}
+
+	sort.SliceStable(report.Nights, func(i, j int) bool {
+		return report.Nights[i].Date.Before(report.Nights[j].Date)
+	})
+
+	for _, night := range report.Nights {
+		if night.Debt >= 0 {
+			report.TotalDebt += night.Debt
+			continue
+		}
+
+		if allowSurplusOffset {
+			report.TotalDebt += night.Debt
+			if report.TotalDebt < 0 {
+				report.TotalDebt = 0
+			}
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"testing"
+	"time"
+)
+
+func TestCalculateSleepDebt_MixedNights(t *testing.T) {
+	monday := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+
+	// Подаем не по порядку: 9ч (избыток 1ч), 6ч (долг 2ч), 5ч (долг 3ч), 10ч (избыток 2ч)
+	sleep := []*entities.SleepEntry{
+		newSleepEntry(t, monday, monday.Add(9*time.Hour), 7),
+		newSleepEntry(t, monday.AddDate(0, 0, 2), monday.AddDate(0, 0, 2).Add(5*time.Hour), 7),
+		newSleepEntry(t, monday.AddDate(0, 0, 1), monday.AddDate(0, 0, 1).Add(6*time.Hour), 7),
+		newSleepEntry(t, monday.AddDate(0, 0, 3), monday.AddDate(0, 0, 3).Add(10*time.Hour), 7),
+	}
+
+	tests := []struct {
+		name               string
+		allowSurplusOffset bool
+		expectedTotal      float64
+	}{
+		// Избыток не учитывается: 2 + 3
+		{"surplus ignored", false, 5},
+		// Первый избыток нечем гасить, затем 2 + 3 - 2
+		{"surplus offsets accrued debt", true, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := CalculateSleepDebt(sleep, 8, tt.allowSurplusOffset)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if !almostEqual(report.TotalDebt, tt.expectedTotal) {
+				t.Errorf("Expected total debt %v, got %v", tt.expectedTotal, report.TotalDebt)
+			}
+
+			expectedNights := []float64{-1, 2, 3, -2}
+			if len(report.Nights) != len(expectedNights) {
+				t.Fatalf("Expected %d nights, got %d", len(expectedNights), len(report.Nights))
+			}
+
+			for i, night := range report.Nights {
+				if !almostEqual(night.Debt, expectedNights[i]) {
+					t.Errorf("Night %d: expected debt %v, got %v", i, expectedNights[i], night.Debt)
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateSleepDebt_SurplusNeverBelowZero(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 22, 0, 0, 0, time.UTC)
+	sleep := []*entities.SleepEntry{
+		newSleepEntry(t, bedtime, bedtime.Add(10*time.Hour), 8),
+	}
+
+	report, err := CalculateSleepDebt(sleep, 8, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if report.TotalDebt != 0 {
+		t.Errorf("Expected surplus-only week to have zero debt, got %v", report.TotalDebt)
+	}
+}
+
+func TestCalculateSleepDebt_InvalidTarget(t *testing.T) {
+	if _, err := CalculateSleepDebt(nil, 0, false); err == nil {
+		t.Error("Expected error for non-positive target, got nil")
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/pkg/errors"
+	"math"
+	"time"
+)
+
+// minCorrelationPoints минимальное число пар "ночь - день" для осмысленной корреляции
+const minCorrelationPoints = 3
+
+// CorrelateSleepAndProductivity считает коэффициент корреляции Пирсона между
+// ночным сном и продуктивностью следующего дня.
+// Ночь сопоставляется с задачами, дата которых совпадает с календарной датой
+// пробуждения. Продуктивность дня - среднее снижение стресса по его задачам
+// с записанным стрессом после выполнения. Ночи без таких задач пропускаются
+func CorrelateSleepAndProductivity(sleep []*entities.SleepEntry, tasks []*entities.TaskEntry) (float64, error) {
+	reductionSums := make(map[time.Time]float64)
+	taskCounts := make(map[time.Time]int)
+	for _, task := range tasks {
+		// Без stressAfter снижение равно stressBefore и завысило бы продуктивность
+		if task == nil || !task.HasStressAfter() {
+			continue
+		}
+
+		key := calendarDay(task.Date())
+		reductionSums[key] += float64(task.CalculateStressReduction())
+		taskCounts[key]++
+	}
+
+	var sleepHours, productivity []float64
+	for _, entry := range sleep {
+		if entry == nil {
+			continue
+		}
+
+		key := calendarDay(entry.WakeTime())
+		if taskCounts[key] == 0 {
+			continue
+		}
+
+		sleepHours = append(sleepHours, entry.TotalSleepHours())
+		productivity = append(productivity, reductionSums[key]/float64(taskCounts[key]))
+	}
+
+	if len(sleepHours) < minCorrelationPoints {
+		return 0, errors.NewDomainError("at least 3 nights with next-day tasks are required")
+	}
+
+	return pearsonCorrelation(sleepHours, productivity)
+}
+
+// pearsonCorrelation коэффициент корреляции Пирсона для выборок одинаковой длины
+func pearsonCorrelation(xs, ys []float64) (float64, error) {
+	n := float64(len(xs))
+
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var covariance, varianceX, varianceY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		covariance += dx * dy
+		varianceX += dx * dx
+		varianceY += dy * dy
+	}
+
+	// Постоянный ряд не дает информации о связи
+	if varianceX == 0 || varianceY == 0 {
+		return 0, errors.NewDomainError("correlation is undefined for constant values")
+	}
+
+	return covariance / math.Sqrt(varianceX*varianceY), nil
+}
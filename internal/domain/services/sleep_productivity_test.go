@@ -0,0 +1,132 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCorrelateSleepAndProductivity_PositiveCorrelation(t *testing.T) {
+	start := time.Date(2025, 8, 10, 23, 0, 0, 0, time.UTC)
+
+	var sleep []*entities.SleepEntry
+	var tasks []*entities.TaskEntry
+	// Каждый лишний час сна дает на следующий день на 1 пункт больше снижения стресса
+	for i, hours := range []int{5, 6, 7, 8} {
+		bedtime := start.AddDate(0, 0, i)
+		wakeTime := bedtime.Add(time.Duration(hours) * time.Hour)
+		sleep = append(sleep, newSleepEntry(t, bedtime, wakeTime, 7))
+
+		nextDay := time.Date(wakeTime.Year(), wakeTime.Month(), wakeTime.Day(), 10, 0, 0, 0, time.UTC)
+		tasks = append(tasks, newTaskEntry(t, nextDay, "работа", 8, 8-(hours-4)))
+		tasks = append(tasks, newTaskEntry(t, nextDay.Add(time.Hour), "учеба", 9, 9-(hours-4)))
+	}
+
+	// Ночь без задач на следующий день пропускается
+	lastBedtime := start.AddDate(0, 0, 10)
+	sleep = append(sleep, newSleepEntry(t, lastBedtime, lastBedtime.Add(4*time.Hour), 7))
+
+	correlation, err := CorrelateSleepAndProductivity(sleep, tasks)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !almostEqual(correlation, 1) {
+		t.Errorf("Expected perfect positive correlation, got %f", correlation)
+	}
+}
+
+func TestCorrelateSleepAndProductivity_NegativeCorrelation(t *testing.T) {
+	start := time.Date(2025, 8, 10, 23, 0, 0, 0, time.UTC)
+
+	var sleep []*entities.SleepEntry
+	var tasks []*entities.TaskEntry
+	reductions := []int{5, 1, 4, 0}
+	for i, hours := range []int{5, 8, 6, 9} {
+		bedtime := start.AddDate(0, 0, i)
+		wakeTime := bedtime.Add(time.Duration(hours) * time.Hour)
+		sleep = append(sleep, newSleepEntry(t, bedtime, wakeTime, 7))
+		tasks = append(tasks, newTaskEntry(t, wakeTime.Add(2*time.Hour), "работа", 8, 8-reductions[i]))
+	}
+
+	correlation, err := CorrelateSleepAndProductivity(sleep, tasks)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if correlation > -0.9 || correlation < -1 || math.IsNaN(correlation) {
+		t.Errorf("Expected strong negative correlation, got %f", correlation)
+	}
+}
+
+func TestCorrelateSleepAndProductivity_SkipsUnratedTasks(t *testing.T) {
+	start := time.Date(2025, 8, 10, 23, 0, 0, 0, time.UTC)
+
+	var sleep []*entities.SleepEntry
+	var tasks []*entities.TaskEntry
+	unratedStress := []int{10, 0, 10, 0}
+	for i, hours := range []int{5, 6, 7, 8} {
+		bedtime := start.AddDate(0, 0, i)
+		wakeTime := bedtime.Add(time.Duration(hours) * time.Hour)
+		sleep = append(sleep, newSleepEntry(t, bedtime, wakeTime, 7))
+
+		nextDay := time.Date(wakeTime.Year(), wakeTime.Month(), wakeTime.Day(), 10, 0, 0, 0, time.UTC)
+		tasks = append(tasks, newTaskEntry(t, nextDay, "работа", 8, 8-(hours-4)))
+		// Неоцененная задача исказила бы среднее, если бы ее снижение считалось равным stressBefore
+		tasks = append(tasks, newUnratedTaskEntry(t, nextDay.Add(time.Hour), "учеба", unratedStress[i]))
+	}
+
+	// День только с неоцененными задачами считается днем без задач
+	lastBedtime := start.AddDate(0, 0, 10)
+	lastWake := lastBedtime.Add(4 * time.Hour)
+	sleep = append(sleep, newSleepEntry(t, lastBedtime, lastWake, 7))
+	tasks = append(tasks, newUnratedTaskEntry(t, lastWake.Add(2*time.Hour), "работа", 10))
+
+	correlation, err := CorrelateSleepAndProductivity(sleep, tasks)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !almostEqual(correlation, 1) {
+		t.Errorf("Expected perfect positive correlation over rated tasks, got %f", correlation)
+	}
+}
+
+func TestCorrelateSleepAndProductivity_TooFewPoints(t *testing.T) {
+	bedtime := time.Date(2025, 8, 10, 23, 0, 0, 0, time.UTC)
+	sleep := []*entities.SleepEntry{
+		newSleepEntry(t, bedtime, bedtime.Add(7*time.Hour), 7),
+		newSleepEntry(t, bedtime.AddDate(0, 0, 1), bedtime.AddDate(0, 0, 1).Add(8*time.Hour), 7),
+		newSleepEntry(t, bedtime.AddDate(0, 0, 2), bedtime.AddDate(0, 0, 2).Add(6*time.Hour), 7),
+	}
+	// Задачи есть только после двух ночей из трех
+	tasks := []*entities.TaskEntry{
+		newTaskEntry(t, bedtime.Add(12*time.Hour), "работа", 8, 4),
+		newTaskEntry(t, bedtime.AddDate(0, 0, 1).Add(12*time.Hour), "работа", 8, 3),
+	}
+
+	if _, err := CorrelateSleepAndProductivity(sleep, tasks); err == nil {
+		t.Error("Expected error for fewer than 3 paired points, got nil")
+	}
+}
+
+// newUnratedTaskEntry создает задачу без записанного стресса после выполнения
+func newUnratedTaskEntry(t *testing.T, date time.Time, category string, stressBefore int) *entities.TaskEntry {
+	t.Helper()
+
+	taskCategory, err := valueobjects.NewTaskCategory(category)
+	if err != nil {
+		t.Fatalf("Failed to create category: %v", err)
+	}
+
+	id := entities.TaskEntryID(fmt.Sprintf("task-%s-%s", date.Format("2006-01-02T15:04"), category))
+	task, err := entities.NewTaskEntry(id, date, 1, "Test task", taskCategory, valueobjects.StressLevel(stressBefore))
+	if err != nil {
+		t.Fatalf("Failed to create task entry: %v", err)
+	}
+
+	return task
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/pkg/errors"
+	"time"
+)
+
+// SleepStage стадия сна
+type SleepStage string
+
+const (
+	SleepStageLight SleepStage = "light"
+	SleepStageDeep  SleepStage = "deep"
+	SleepStageREM   SleepStage = "rem"
+	SleepStageAwake SleepStage = "awake"
+)
+
+// Упрощенная модель цикла сна: взрослый проходит циклы примерно по 90 минут,
+// в каждом из которых ~55% занимает легкий сон, ~20% глубокий и ~25% REM.
+// Это грубая оценка для графиков, а не замена данным трекера.
+const (
+	sleepCycleDuration = 90 * time.Minute
+	lightSleepShare    = 0.55
+	deepSleepShare     = 0.20
+	awakeningDuration  = 5 * time.Minute
+)
+
+// StageSegment отрезок времени, проведенный в одной стадии сна
+type StageSegment struct {
+	Start time.Time
+	End   time.Time
+	Stage SleepStage
+}
+
+// Duration возвращает длительность отрезка
+func (s StageSegment) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// EstimateSleepStages разбивает окно сна на стадии по модели циклов.
+// Окно начинается после засыпания и длится totalSleepHours плюс время ночного
+// бодрствования, поэтому заканчивается в момент пробуждения. Ночные пробуждения
+// равномерно распределяются по окну и прерывают стадии: если время бодрствования
+// записано, оно делится между пробуждениями и не отнимается у сна,
+// иначе каждое пробуждение занимает awakeningDuration
+func EstimateSleepStages(entry *entities.SleepEntry) ([]StageSegment, error) {
+	if entry == nil {
+		return nil, errors.NewDomainError("sleep entry is required")
+	}
+
+	total := time.Duration(entry.TotalSleepHours() * float64(time.Hour))
+	if total <= 0 {
+		return nil, errors.NewDomainError("sleep duration must be positive")
+	}
+
+	awakeTotal := entry.TimeAwakeDuringNight()
+	awakenings := entry.NightAwakenings()
+	// Бодрствование без учтенных пробуждений показываем одним пробуждением
+	if awakeTotal > 0 && awakenings == 0 {
+		awakenings = 1
+	}
+
+	start := entry.Bedtime().Add(entry.SleepLatency())
+	end := start.Add(total + awakeTotal)
+
+	segments := buildSleepCycles(start, end)
+
+	var perAwakening time.Duration
+	if awakenings > 0 {
+		perAwakening = awakeTotal / time.Duration(awakenings)
+	}
+
+	for i := 1; i <= awakenings; i++ {
+		// Сон между пробуждениями делится поровну; предыдущие пробуждения сдвигают начало
+		from := start.Add(total*time.Duration(i)/time.Duration(awakenings+1) + perAwakening*time.Duration(i-1))
+
+		duration := awakeningDuration
+		if awakeTotal > 0 {
+			duration = perAwakening
+			// Остаток от деления достается последнему пробуждению
+			if i == awakenings {
+				duration = awakeTotal - perAwakening*time.Duration(awakenings-1)
+			}
+		}
+
+		to := from.Add(duration)
+		if to.After(end) {
+			to = end
+		}
+		segments = interruptSegments(segments, from, to)
+	}
+
+	return segments, nil
+}
+
+// buildSleepCycles заполняет окно последовательными циклами light → deep → REM,
+// последний цикл обрезается по концу окна
+func buildSleepCycles(start, end time.Time) []StageSegment {
+	lightDuration := time.Duration(float64(sleepCycleDuration) * lightSleepShare)
+	deepDuration := time.Duration(float64(sleepCycleDuration) * deepSleepShare)
+	remDuration := sleepCycleDuration - lightDuration - deepDuration
+
+	phases := []struct {
+		stage    SleepStage
+		duration time.Duration
+	}{
+		{SleepStageLight, lightDuration},
+		{SleepStageDeep, deepDuration},
+		{SleepStageREM, remDuration},
+	}
+
+	segments := make([]StageSegment, 0)
+	cursor := start
+	for cursor.Before(end) {
+		for _, phase := range phases {
+			if !cursor.Before(end) {
+				break
+			}
+			next := cursor.Add(phase.duration)
+			if next.After(end) {
+				next = end
+			}
+			segments = append(segments, StageSegment{Start: cursor, End: next, Stage: phase.stage})
+			cursor = next
+		}
+	}
+
+	return segments
+}
+
+// interruptSegments вырезает интервал [from, to) из стадий и вставляет на его место пробуждение
+func interruptSegments(segments []StageSegment, from, to time.Time) []StageSegment {
+	if !from.Before(to) {
+		return segments
+	}
+
+	result := make([]StageSegment, 0, len(segments)+2)
+	inserted := false
+	for _, segment := range segments {
+		if !segment.End.After(from) || !segment.Start.Before(to) {
+			result = append(result, segment)
+			continue
+		}
+
+		if segment.Start.Before(from) {
+			result = append(result, StageSegment{Start: segment.Start, End: from, Stage: segment.Stage})
+		}
+		if !inserted {
+			result = append(result, StageSegment{Start: from, End: to, Stage: SleepStageAwake})
+			inserted = true
+		}
+		if segment.End.After(to) {
+			result = append(result, StageSegment{Start: to, End: segment.End, Stage: segment.Stage})
+		}
+	}
+
+	return result
+}
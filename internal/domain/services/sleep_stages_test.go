@@ -0,0 +1,174 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"testing"
+	"time"
+)
+
+func TestEstimateSleepStages_CoversFullWindow(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	wakeTime := time.Date(2025, 8, 12, 7, 0, 0, 0, time.UTC)
+	entry := newSleepEntry(t, bedtime, wakeTime, 7)
+
+	segments, err := EstimateSleepStages(entry)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(segments) == 0 {
+		t.Fatal("Expected segments, got none")
+	}
+
+	if !segments[0].Start.Equal(bedtime) {
+		t.Errorf("Expected first segment to start at %v, got %v", bedtime, segments[0].Start)
+	}
+
+	if !segments[len(segments)-1].End.Equal(wakeTime) {
+		t.Errorf("Expected last segment to end at %v, got %v", wakeTime, segments[len(segments)-1].End)
+	}
+
+	// Отрезки должны идти встык, без пропусков и наложений
+	var total time.Duration
+	for i, segment := range segments {
+		if i > 0 && !segment.Start.Equal(segments[i-1].End) {
+			t.Errorf("Segment %d starts at %v, previous ends at %v", i, segment.Start, segments[i-1].End)
+		}
+		if segment.Stage == SleepStageAwake {
+			t.Errorf("Expected no awake segments without awakenings")
+		}
+		total += segment.Duration()
+	}
+
+	if total != 8*time.Hour {
+		t.Errorf("Expected segments to cover 8h, got %v", total)
+	}
+}
+
+func TestEstimateSleepStages_AwakeningsInterrupt(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	wakeTime := time.Date(2025, 8, 12, 7, 0, 0, 0, time.UTC)
+	entry := newSleepEntry(t, bedtime, wakeTime, 5)
+	entry.RecordNightAwakening()
+	entry.RecordNightAwakening()
+
+	segments, err := EstimateSleepStages(entry)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	awake := 0
+	for i, segment := range segments {
+		if i > 0 && !segment.Start.Equal(segments[i-1].End) {
+			t.Errorf("Segment %d is not contiguous with the previous one", i)
+		}
+		if segment.Stage == SleepStageAwake {
+			awake++
+		}
+	}
+
+	if awake != 2 {
+		t.Errorf("Expected 2 awake segments, got %d", awake)
+	}
+
+	if !segments[len(segments)-1].End.Equal(wakeTime) {
+		t.Errorf("Expected window to end at %v, got %v", wakeTime, segments[len(segments)-1].End)
+	}
+}
+
+func TestEstimateSleepStages_TimeAwakeDuringNight(t *testing.T) {
+	bedtime := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	wakeTime := time.Date(2025, 8, 12, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		awakenings     int
+		awake          time.Duration
+		expectedAwakes int
+	}{
+		{"split between awakenings", 2, time.Hour, 2},
+		{"uneven split", 3, 50 * time.Minute, 3},
+		{"awake time without recorded awakenings", 0, 40 * time.Minute, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := newSleepEntry(t, bedtime, wakeTime, 5)
+			entry.SetSleepLatency(30 * time.Minute)
+			for i := 0; i < tt.awakenings; i++ {
+				entry.RecordNightAwakening()
+			}
+			if err := entry.SetTimeAwakeDuringNight(tt.awake); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			segments, err := EstimateSleepStages(entry)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			start := bedtime.Add(30 * time.Minute)
+			if !segments[0].Start.Equal(start) {
+				t.Errorf("Expected first segment to start at %v, got %v", start, segments[0].Start)
+			}
+			if !segments[len(segments)-1].End.Equal(wakeTime) {
+				t.Errorf("Expected last segment to end at %v, got %v", wakeTime, segments[len(segments)-1].End)
+			}
+
+			var asleep, awake time.Duration
+			awakeSegments := 0
+			for i, segment := range segments {
+				if i > 0 && !segment.Start.Equal(segments[i-1].End) {
+					t.Errorf("Segment %d is not contiguous with the previous one", i)
+				}
+				if segment.Stage == SleepStageAwake {
+					awake += segment.Duration()
+					awakeSegments++
+				} else {
+					asleep += segment.Duration()
+				}
+			}
+
+			if awakeSegments != tt.expectedAwakes || awake != tt.awake {
+				t.Errorf("Expected %d awake segments totalling %v, got %d totalling %v",
+					tt.expectedAwakes, tt.awake, awakeSegments, awake)
+			}
+
+			expectedAsleep := time.Duration(entry.TotalSleepHours() * float64(time.Hour))
+			if asleep != expectedAsleep {
+				t.Errorf("Expected %v asleep to match total sleep, got %v", expectedAsleep, asleep)
+			}
+		})
+	}
+}
+
+func TestEstimateSleepStages_Errors(t *testing.T) {
+	if _, err := EstimateSleepStages(nil); err == nil {
+		t.Error("Expected error for nil entry")
+	}
+
+	moment := time.Date(2025, 8, 12, 7, 0, 0, 0, time.UTC)
+	entry := newSleepEntry(t, moment, moment, 5)
+	if _, err := EstimateSleepStages(entry); err == nil {
+		t.Error("Expected error for zero sleep duration")
+	}
+}
+
+// Вспомогательная функция для создания записи сна
+func newSleepEntry(t *testing.T, bedtime, wakeTime time.Time, quality int) *entities.SleepEntry {
+	t.Helper()
+
+	sleepQuality, err := valueobjects.NewSleepQuality(quality)
+	if err != nil {
+		t.Fatalf("Failed to create sleep quality: %v", err)
+	}
+
+	date := time.Date(wakeTime.Year(), wakeTime.Month(), wakeTime.Day(), 0, 0, 0, 0, wakeTime.Location())
+	entry, err := entities.NewSleepEntry(entities.SleepEntryID("sleep-"+date.Format("2006-01-02")), date, bedtime, wakeTime, sleepQuality)
+	if err != nil {
+		t.Fatalf("Failed to create sleep entry: %v", err)
+	}
+
+	return entry
+}
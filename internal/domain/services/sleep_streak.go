@@ -0,0 +1,68 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"sort"
+	"time"
+)
+
+// CurrentHealthySleepStreak считает подряд идущие дни со здоровым сном,
+// заканчивающиеся датой asOf. День без записи прерывает серию
+func CurrentHealthySleepStreak(entries []*entities.SleepEntry, asOf time.Time) int {
+	healthy := healthySleepDays(entries)
+
+	streak := 0
+	day := calendarDay(asOf)
+	for healthy[day] {
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	return streak
+}
+
+// LongestHealthySleepStreak находит самую длинную серию дней со здоровым сном
+func LongestHealthySleepStreak(entries []*entities.SleepEntry) int {
+	healthy := healthySleepDays(entries)
+
+	days := make([]time.Time, 0, len(healthy))
+	for day, ok := range healthy {
+		if ok {
+			days = append(days, day)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Before(days[j])
+	})
+
+	longest, current := 0, 0
+	for i, day := range days {
+		if i > 0 && day.Equal(days[i-1].AddDate(0, 0, 1)) {
+			current++
+		} else {
+			current = 1
+		}
+
+		if current > longest {
+			longest = current
+		}
+	}
+
+	return longest
+}
+
+// healthySleepDays отмечает календарные даты записей сна.
+// Если за день несколько записей, день здоровый, только когда здоровы все
+func healthySleepDays(entries []*entities.SleepEntry) map[time.Time]bool {
+	days := make(map[time.Time]bool)
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+
+		day := calendarDay(entry.Date())
+		healthy, seen := days[day]
+		days[day] = entry.IsSleepHealthy() && (!seen || healthy)
+	}
+	return days
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"testing"
+	"time"
+)
+
+// nightOf создает ночь, заканчивающуюся утром заданного дня августа
+func nightOf(t *testing.T, day int, healthy bool) *entities.SleepEntry {
+	t.Helper()
+
+	bedtime := time.Date(2025, 8, day-1, 23, 0, 0, 0, time.UTC)
+	if healthy {
+		return newSleepEntry(t, bedtime, bedtime.Add(8*time.Hour), 8)
+	}
+	return newSleepEntry(t, bedtime, bedtime.Add(5*time.Hour), 4)
+}
+
+func TestHealthySleepStreak_UnsortedWithGap(t *testing.T) {
+	// 10-12 здоровые, 13 пропущен, 14-15 здоровые, 16 плохой, 17 здоровый
+	entries := []*entities.SleepEntry{
+		nightOf(t, 15, true),
+		nightOf(t, 11, true),
+		nightOf(t, 17, true),
+		nightOf(t, 10, true),
+		nightOf(t, 16, false),
+		nightOf(t, 14, true),
+		nightOf(t, 12, true),
+	}
+
+	tests := []struct {
+		name     string
+		asOf     time.Time
+		expected int
+	}{
+		{"ends after gap", time.Date(2025, 8, 15, 21, 0, 0, 0, time.UTC), 2},
+		{"ends before gap", time.Date(2025, 8, 12, 8, 0, 0, 0, time.UTC), 3},
+		{"missing day", time.Date(2025, 8, 13, 8, 0, 0, 0, time.UTC), 0},
+		{"unhealthy day", time.Date(2025, 8, 16, 8, 0, 0, 0, time.UTC), 0},
+		{"single day", time.Date(2025, 8, 17, 8, 0, 0, 0, time.UTC), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streak := CurrentHealthySleepStreak(entries, tt.asOf)
+			if streak != tt.expected {
+				t.Errorf("Expected streak %d, got %d", tt.expected, streak)
+			}
+		})
+	}
+
+	if longest := LongestHealthySleepStreak(entries); longest != 3 {
+		t.Errorf("Expected longest streak 3, got %d", longest)
+	}
+}
+
+func TestHealthySleepStreak_SingleDay(t *testing.T) {
+	entries := []*entities.SleepEntry{nightOf(t, 20, true)}
+
+	if streak := CurrentHealthySleepStreak(entries, time.Date(2025, 8, 20, 0, 0, 0, 0, time.UTC)); streak != 1 {
+		t.Errorf("Expected current streak 1, got %d", streak)
+	}
+
+	if longest := LongestHealthySleepStreak(entries); longest != 1 {
+		t.Errorf("Expected longest streak 1, got %d", longest)
+	}
+
+	if longest := LongestHealthySleepStreak(nil); longest != 0 {
+		t.Errorf("Expected no streak without entries, got %d", longest)
+	}
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"sort"
+	"time"
+)
+
+// DetectPersistentSleepiness проверяет, что последние days календарных дней с записями
+// идут подряд и в каждый из них дневная сонливость не ниже threshold.
+// Записи группируются по дате и сортируются внутри; при нескольких записях за день
+// день засчитывается, только когда порог достигнут во всех. Пропуск дня или
+// недостаточное число дней дают false
+func DetectPersistentSleepiness(entries []*entities.SleepEntry, days int, threshold valueobjects.DaytimeSleepiness) bool {
+	if days < 1 {
+		return false
+	}
+
+	sleepy := make(map[time.Time]bool)
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+
+		day := calendarDay(entry.Date())
+		dayIsSleepy, seen := sleepy[day]
+		sleepy[day] = entry.DaytimeSleepiness() >= threshold && (!seen || dayIsSleepy)
+	}
+
+	if len(sleepy) < days {
+		return false
+	}
+
+	sorted := make([]time.Time, 0, len(sleepy))
+	for day := range sleepy {
+		sorted = append(sorted, day)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].After(sorted[j])
+	})
+
+	for i, day := range sorted[:days] {
+		if !sleepy[day] {
+			return false
+		}
+
+		if i > 0 && !day.Equal(sorted[i-1].AddDate(0, 0, -1)) {
+			return false
+		}
+	}
+
+	return true
+}
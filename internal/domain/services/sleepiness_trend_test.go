@@ -0,0 +1,116 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"testing"
+	"time"
+)
+
+// sleepyNightOf создает ночь перед заданным днем августа с указанной дневной сонливостью
+func sleepyNightOf(t *testing.T, day int, sleepiness valueobjects.DaytimeSleepiness) *entities.SleepEntry {
+	t.Helper()
+
+	entry := nightOf(t, day, true)
+	entry.SetDaytimeSleepiness(sleepiness)
+	return entry
+}
+
+func TestDetectPersistentSleepiness(t *testing.T) {
+	tests := []struct {
+		name     string
+		entries  []*entities.SleepEntry
+		days     int
+		expected bool
+	}{
+		{
+			name: "qualifying run in any order",
+			entries: []*entities.SleepEntry{
+				sleepyNightOf(t, 14, 8),
+				sleepyNightOf(t, 11, 2),
+				sleepyNightOf(t, 12, 7),
+				sleepyNightOf(t, 13, 9),
+			},
+			days:     3,
+			expected: true,
+		},
+		{
+			name: "broken by low sleepiness",
+			entries: []*entities.SleepEntry{
+				sleepyNightOf(t, 12, 8),
+				sleepyNightOf(t, 13, 3),
+				sleepyNightOf(t, 14, 9),
+			},
+			days:     3,
+			expected: false,
+		},
+		{
+			name: "broken by missing day",
+			entries: []*entities.SleepEntry{
+				sleepyNightOf(t, 11, 8),
+				sleepyNightOf(t, 13, 8),
+				sleepyNightOf(t, 14, 8),
+			},
+			days:     3,
+			expected: false,
+		},
+		{
+			name: "one low entry spoils the day",
+			entries: []*entities.SleepEntry{
+				sleepyNightOf(t, 13, 8),
+				sleepyNightOf(t, 14, 8),
+				sleepyNightOf(t, 14, 1),
+			},
+			days:     2,
+			expected: false,
+		},
+		{
+			name: "older low day outside the window",
+			entries: []*entities.SleepEntry{
+				sleepyNightOf(t, 12, 1),
+				sleepyNightOf(t, 13, 7),
+				sleepyNightOf(t, 14, 7),
+			},
+			days:     2,
+			expected: true,
+		},
+		{
+			name: "insufficient data",
+			entries: []*entities.SleepEntry{
+				sleepyNightOf(t, 13, 9),
+				sleepyNightOf(t, 14, 9),
+			},
+			days:     3,
+			expected: false,
+		},
+		{
+			name:     "non-positive days",
+			entries:  []*entities.SleepEntry{sleepyNightOf(t, 14, 9)},
+			days:     0,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := DetectPersistentSleepiness(tt.entries, tt.days, 7)
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDetectPersistentSleepiness_MatchesDatesAcrossZones(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	// Запись 13-го в другом поясе все равно попадает на 13-е число
+	bedtime := time.Date(2025, 8, 12, 23, 30, 0, 0, moscow)
+	other := newSleepEntry(t, bedtime, bedtime.Add(8*time.Hour), 8)
+	other.SetDaytimeSleepiness(8)
+
+	entries := []*entities.SleepEntry{other, sleepyNightOf(t, 14, 8)}
+	if !DetectPersistentSleepiness(entries, 2, 7) {
+		t.Error("Expected consecutive days to match by calendar date")
+	}
+}
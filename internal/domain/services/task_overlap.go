@@ -0,0 +1,47 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"sort"
+	"time"
+)
+
+// DetectTaskOverlaps находит пары задач одного дня, чьи окна активной работы
+// [startTime, startTime+activeDuration) пересекаются и завышают итог дня.
+// Задачи без времени начала не учитываются. Смежные окна не считаются пересечением.
+// Пары упорядочены по времени начала, внутри пары первой идет задача, начатая раньше
+func DetectTaskOverlaps(tasks []*entities.TaskEntry) [][2]*entities.TaskEntry {
+	started := make([]*entities.TaskEntry, 0, len(tasks))
+	for _, task := range tasks {
+		if task != nil && task.StartTime() != nil {
+			started = append(started, task)
+		}
+	}
+
+	sort.SliceStable(started, func(i, j int) bool {
+		return started[i].StartTime().Before(*started[j].StartTime())
+	})
+
+	overlaps := make([][2]*entities.TaskEntry, 0)
+	for i, first := range started {
+		firstEnd := taskWindowEnd(first)
+
+		for _, second := range started[i+1:] {
+			// Окна отсортированы по началу: дальше пересечений с first не будет
+			if !second.StartTime().Before(firstEnd) {
+				break
+			}
+
+			if calendarDay(first.Date()).Equal(calendarDay(second.Date())) {
+				overlaps = append(overlaps, [2]*entities.TaskEntry{first, second})
+			}
+		}
+	}
+
+	return overlaps
+}
+
+// taskWindowEnd возвращает конец окна активной работы задачи
+func taskWindowEnd(task *entities.TaskEntry) time.Time {
+	return task.StartTime().Add(task.ActiveDuration())
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"testing"
+	"time"
+)
+
+func TestDetectTaskOverlaps(t *testing.T) {
+	day := time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		tasks    []*entities.TaskEntry
+		expected [][2]string
+	}{
+		{
+			name: "clearly overlapping",
+			tasks: []*entities.TaskEntry{
+				startedTask(t, "report", day.Add(9*time.Hour), time.Hour),
+				startedTask(t, "email", day.Add(9*time.Hour+30*time.Minute), time.Hour),
+			},
+			expected: [][2]string{{"report", "email"}},
+		},
+		{
+			name: "adjacent",
+			tasks: []*entities.TaskEntry{
+				startedTask(t, "report", day.Add(9*time.Hour), time.Hour),
+				startedTask(t, "email", day.Add(10*time.Hour), time.Hour),
+			},
+		},
+		{
+			name: "disjoint",
+			tasks: []*entities.TaskEntry{
+				startedTask(t, "report", day.Add(9*time.Hour), time.Hour),
+				startedTask(t, "email", day.Add(14*time.Hour), time.Hour),
+			},
+		},
+		{
+			name: "unordered input with nested window",
+			tasks: []*entities.TaskEntry{
+				startedTask(t, "call", day.Add(10*time.Hour), 15*time.Minute),
+				startedTask(t, "report", day.Add(9*time.Hour), 3*time.Hour),
+				startedTask(t, "email", day.Add(11*time.Hour+30*time.Minute), time.Hour),
+			},
+			expected: [][2]string{{"report", "call"}, {"report", "email"}},
+		},
+		{
+			name: "not started is ignored",
+			tasks: []*entities.TaskEntry{
+				startedTask(t, "report", day.Add(9*time.Hour), time.Hour),
+				newTaskEntry(t, day.Add(9*time.Hour), "работа", 5, 5),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overlaps := DetectTaskOverlaps(tt.tasks)
+
+			if len(overlaps) != len(tt.expected) {
+				t.Fatalf("Expected %d overlaps, got %d", len(tt.expected), len(overlaps))
+			}
+
+			for i, pair := range overlaps {
+				if string(pair[0].ID()) != tt.expected[i][0] || string(pair[1].ID()) != tt.expected[i][1] {
+					t.Errorf("Expected pair %v, got [%s %s]", tt.expected[i], pair[0].ID(), pair[1].ID())
+				}
+			}
+		})
+	}
+}
+
+// startedTask создает начатую задачу с заданным окном активной работы
+func startedTask(t *testing.T, id string, start time.Time, duration time.Duration) *entities.TaskEntry {
+	t.Helper()
+
+	task, err := entities.ReconstructTaskEntry(entities.TaskEntryState{
+		ID:             entities.TaskEntryID(id),
+		Date:           calendarDay(start),
+		DayNumber:      1,
+		KeyTask:        "Test task",
+		Category:       "работа",
+		StressBefore:   5,
+		Started:        true,
+		StartTime:      &start,
+		ActiveDuration: duration,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task entry: %v", err)
+	}
+	return task
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"daily-tracker/pkg/errors"
+	"time"
+)
+
+// Summary недельная сводка по задачам и сну
+type Summary struct {
+	WeekStart              time.Time
+	WeekEnd                time.Time
+	TasksCompleted         int
+	AverageStressReduction float64 // Только по задачам с записанным стрессом после
+	AverageSleepHours      float64
+	HealthySleepNights     int
+	TopCategory            valueobjects.TaskCategory // Пусто, если задач за неделю не было
+}
+
+// WeeklySummary собирает сводку за 7 дней [weekStart, weekStart+7д).
+// Без данных за неделю возвращает нулевую, но корректную сводку
+func WeeklySummary(tasks []*entities.TaskEntry, sleep []*entities.SleepEntry, weekStart time.Time) (Summary, error) {
+	if weekStart.IsZero() {
+		return Summary{}, errors.NewDomainError("week start is required")
+	}
+
+	weekEnd := weekStart.AddDate(0, 0, 7)
+	summary := Summary{
+		WeekStart: weekStart,
+		WeekEnd:   weekEnd,
+	}
+
+	categoryCounts := make(map[valueobjects.TaskCategory]int)
+	var stressReductionSum float64
+	var stressMeasured int
+	for _, task := range tasks {
+		if task == nil || !inWindow(task.Date(), weekStart, weekEnd) {
+			continue
+		}
+
+		categoryCounts[task.Category()]++
+		if task.IsCompleted() {
+			summary.TasksCompleted++
+		}
+		if task.HasStressAfter() {
+			stressReductionSum += float64(task.CalculateStressReduction())
+			stressMeasured++
+		}
+	}
+
+	if stressMeasured > 0 {
+		summary.AverageStressReduction = stressReductionSum / float64(stressMeasured)
+	}
+
+	// При равенстве берем категорию, которая раньше по алфавиту, чтобы результат не зависел от порядка
+	for category, count := range categoryCounts {
+		topCount := categoryCounts[summary.TopCategory]
+		if count > topCount || (count == topCount && category < summary.TopCategory) {
+			summary.TopCategory = category
+		}
+	}
+
+	var sleepHoursSum float64
+	var nights int
+	for _, entry := range sleep {
+		if entry == nil || !inWindow(entry.Date(), weekStart, weekEnd) {
+			continue
+		}
+
+		nights++
+		sleepHoursSum += entry.TotalSleepHours()
+		if entry.IsSleepHealthy() {
+			summary.HealthySleepNights++
+		}
+	}
+
+	if nights > 0 {
+		summary.AverageSleepHours = sleepHoursSum / float64(nights)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"testing"
+	"time"
+)
+
+func TestWeeklySummary_Aggregates(t *testing.T) {
+	weekStart := time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)
+
+	restore := entities.SetClock(entities.NewFixedClock(weekStart.AddDate(0, 0, 7)))
+	defer restore()
+
+	completed := newTaskEntry(t, weekStart.Add(9*time.Hour), "работа", 8, 4)
+	completed.StartTask()
+	completed.CompleteTask()
+
+	tasks := []*entities.TaskEntry{
+		completed,
+		newTaskEntry(t, weekStart.AddDate(0, 0, 2), "работа", 6, 4),
+		newTaskEntry(t, weekStart.AddDate(0, 0, 3), "учеба", 5, 5),
+		// За пределами недели
+		newTaskEntry(t, weekStart.AddDate(0, 0, 7), "учеба", 9, 0),
+		newTaskEntry(t, weekStart.AddDate(0, 0, -1), "учеба", 9, 0),
+	}
+
+	bedtime := weekStart.Add(-time.Hour)
+	sleep := []*entities.SleepEntry{
+		newSleepEntry(t, bedtime, bedtime.Add(8*time.Hour), 8),
+		newSleepEntry(t, bedtime.AddDate(0, 0, 1), bedtime.AddDate(0, 0, 1).Add(6*time.Hour), 8),
+		newSleepEntry(t, bedtime.AddDate(0, 0, 2), bedtime.AddDate(0, 0, 2).Add(7*time.Hour), 7),
+		// Пробуждение уже на следующей неделе
+		newSleepEntry(t, bedtime.AddDate(0, 0, 7), bedtime.AddDate(0, 0, 7).Add(4*time.Hour), 2),
+	}
+
+	summary, err := WeeklySummary(tasks, sleep, weekStart)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if summary.TasksCompleted != 1 {
+		t.Errorf("Expected 1 completed task, got %d", summary.TasksCompleted)
+	}
+
+	// (4 + 2 + 0) / 3
+	if !almostEqual(summary.AverageStressReduction, 2) {
+		t.Errorf("Expected average stress reduction 2, got %f", summary.AverageStressReduction)
+	}
+
+	if !almostEqual(summary.AverageSleepHours, 7) {
+		t.Errorf("Expected average sleep 7h, got %f", summary.AverageSleepHours)
+	}
+
+	if summary.HealthySleepNights != 2 {
+		t.Errorf("Expected 2 healthy nights, got %d", summary.HealthySleepNights)
+	}
+
+	if summary.TopCategory != valueobjects.TaskCategoryWork {
+		t.Errorf("Expected top category %q, got %q", valueobjects.TaskCategoryWork, summary.TopCategory)
+	}
+
+	if !summary.WeekEnd.Equal(weekStart.AddDate(0, 0, 7)) {
+		t.Errorf("Expected week to end 7 days after start, got %v", summary.WeekEnd)
+	}
+}
+
+func TestWeeklySummary_NoData(t *testing.T) {
+	weekStart := time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)
+
+	summary, err := WeeklySummary(nil, nil, weekStart)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if summary.TasksCompleted != 0 || summary.AverageSleepHours != 0 || summary.TopCategory != "" {
+		t.Errorf("Expected empty summary, got %+v", summary)
+	}
+
+	if !summary.WeekStart.Equal(weekStart) {
+		t.Errorf("Expected week start %v, got %v", weekStart, summary.WeekStart)
+	}
+}
+
+func TestWeeklySummary_ZeroWeekStart(t *testing.T) {
+	if _, err := WeeklySummary(nil, nil, time.Time{}); err == nil {
+		t.Error("Expected error for zero week start, got nil")
+	}
+}
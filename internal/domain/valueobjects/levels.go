@@ -2,8 +2,11 @@ package valueobjects
 
 import (
 	"daily-tracker/pkg/errors"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 )
 
 // Value Objects в DDD - неизменяемые объекты без идентичности
@@ -20,16 +23,61 @@ const (
 // NewStressLevel конструктор с валидацией
 func NewStressLevel(level int) (StressLevel, error) {
 	if level < StressLevelMin || level > StressLevelMax {
-		return 0, errors.NewDomainError("stress level must be between 0 and 10")
+		return 0, outOfRangeError("stress level", "stressLevel", level)
 	}
 	return StressLevel(level), nil
 }
 
+// ParseStressLevel разбирает уровень стресса из строки (CSV, формы) с той же валидацией, что и NewStressLevel
+func ParseStressLevel(s string) (StressLevel, error) {
+	value, err := parseLevel(s, "stress level")
+	if err != nil {
+		return 0, err
+	}
+	return NewStressLevel(value)
+}
+
 // Int возвращает значение как int
 func (sl StressLevel) Int() int {
 	return int(sl)
 }
 
+// Compare сравнивает уровни стресса: -1, если меньше other, 0 при равенстве, 1, если больше
+func (sl StressLevel) Compare(other StressLevel) int {
+	return compareLevels(int(sl), int(other))
+}
+
+// Equals проверяет равенство с other
+func (sl StressLevel) Equals(other StressLevel) bool {
+	return sl == other
+}
+
+// Max возвращает большее из двух значений
+func (sl StressLevel) Max(other StressLevel) StressLevel {
+	if other > sl {
+		return other
+	}
+	return sl
+}
+
+// Min возвращает меньшее из двух значений
+func (sl StressLevel) Min(other StressLevel) StressLevel {
+	if other < sl {
+		return other
+	}
+	return sl
+}
+
+// Percent возвращает значение в процентах (0-100)
+func (sl StressLevel) Percent() int {
+	return levelPercent(int(sl))
+}
+
+// Normalized возвращает значение в диапазоне 0.0-1.0
+func (sl StressLevel) Normalized() float64 {
+	return levelNormalized(int(sl))
+}
+
 // String реализует интерфейс fmt.Stringer (аналог __toString() в PHP)
 func (sl StressLevel) String() string {
 	return fmt.Sprintf("%d", sl)
@@ -40,20 +88,100 @@ func (sl StressLevel) IsHigh() bool {
 	return sl >= 7
 }
 
+// MarshalJSON сериализует уровень как обычное число (обратная совместимость)
+func (sl StressLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(sl))
+}
+
+// UnmarshalJSON десериализует уровень через конструктор, чтобы проверка диапазона
+// оставалась в одном месте
+func (sl *StressLevel) UnmarshalJSON(data []byte) error {
+	value, err := decodeLevel(data, "stress level")
+	if err != nil {
+		return err
+	}
+
+	level, err := NewStressLevel(value)
+	if err != nil {
+		return err
+	}
+
+	*sl = level
+	return nil
+}
+
+// AverageStressLevels возвращает средний уровень стресса (0.0 для пустого среза)
+func AverageStressLevels(levels []StressLevel) float64 {
+	if len(levels) == 0 {
+		return 0
+	}
+
+	sum := 0
+	for _, value := range levels {
+		sum += int(value)
+	}
+	return float64(sum) / float64(len(levels))
+}
+
 // EnergyLevel представляет уровень энергии от 0 до 10
 type EnergyLevel int
 
 func NewEnergyLevel(level int) (EnergyLevel, error) {
 	if level < 0 || level > 10 {
-		return 0, errors.NewDomainError("energy level must be between 0 and 10")
+		return 0, outOfRangeError("energy level", "energyLevel", level)
 	}
 	return EnergyLevel(level), nil
 }
 
+// ParseEnergyLevel разбирает уровень энергии из строки (CSV, формы) с той же валидацией, что и NewEnergyLevel
+func ParseEnergyLevel(s string) (EnergyLevel, error) {
+	value, err := parseLevel(s, "energy level")
+	if err != nil {
+		return 0, err
+	}
+	return NewEnergyLevel(value)
+}
+
 func (el EnergyLevel) Int() int {
 	return int(el)
 }
 
+// Compare сравнивает уровни энергии: -1, если меньше other, 0 при равенстве, 1, если больше
+func (el EnergyLevel) Compare(other EnergyLevel) int {
+	return compareLevels(int(el), int(other))
+}
+
+// Equals проверяет равенство с other
+func (el EnergyLevel) Equals(other EnergyLevel) bool {
+	return el == other
+}
+
+// Max возвращает большее из двух значений
+func (el EnergyLevel) Max(other EnergyLevel) EnergyLevel {
+	if other > el {
+		return other
+	}
+	return el
+}
+
+// Min возвращает меньшее из двух значений
+func (el EnergyLevel) Min(other EnergyLevel) EnergyLevel {
+	if other < el {
+		return other
+	}
+	return el
+}
+
+// Percent возвращает значение в процентах (0-100)
+func (el EnergyLevel) Percent() int {
+	return levelPercent(int(el))
+}
+
+// Normalized возвращает значение в диапазоне 0.0-1.0
+func (el EnergyLevel) Normalized() float64 {
+	return levelNormalized(int(el))
+}
+
 func (el EnergyLevel) String() string {
 	return fmt.Sprintf("%d", el)
 }
@@ -62,20 +190,97 @@ func (el EnergyLevel) IsLow() bool {
 	return el <= 3
 }
 
+func (el EnergyLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(el))
+}
+
+func (el *EnergyLevel) UnmarshalJSON(data []byte) error {
+	value, err := decodeLevel(data, "energy level")
+	if err != nil {
+		return err
+	}
+
+	level, err := NewEnergyLevel(value)
+	if err != nil {
+		return err
+	}
+
+	*el = level
+	return nil
+}
+
+// AverageEnergyLevels возвращает средний уровень энергии (0.0 для пустого среза)
+func AverageEnergyLevels(levels []EnergyLevel) float64 {
+	if len(levels) == 0 {
+		return 0
+	}
+
+	sum := 0
+	for _, value := range levels {
+		sum += int(value)
+	}
+	return float64(sum) / float64(len(levels))
+}
+
 // MoodLevel представляет уровень настроения от 0 до 10
 type MoodLevel int
 
 func NewMoodLevel(level int) (MoodLevel, error) {
 	if level < 0 || level > 10 {
-		return 0, errors.NewDomainError("mood level must be between 0 and 10")
+		return 0, outOfRangeError("mood level", "moodLevel", level)
 	}
 	return MoodLevel(level), nil
 }
 
+// ParseMoodLevel разбирает уровень настроения из строки (CSV, формы) с той же валидацией, что и NewMoodLevel
+func ParseMoodLevel(s string) (MoodLevel, error) {
+	value, err := parseLevel(s, "mood level")
+	if err != nil {
+		return 0, err
+	}
+	return NewMoodLevel(value)
+}
+
 func (ml MoodLevel) Int() int {
 	return int(ml)
 }
 
+// Compare сравнивает уровни настроения: -1, если меньше other, 0 при равенстве, 1, если больше
+func (ml MoodLevel) Compare(other MoodLevel) int {
+	return compareLevels(int(ml), int(other))
+}
+
+// Equals проверяет равенство с other
+func (ml MoodLevel) Equals(other MoodLevel) bool {
+	return ml == other
+}
+
+// Max возвращает большее из двух значений
+func (ml MoodLevel) Max(other MoodLevel) MoodLevel {
+	if other > ml {
+		return other
+	}
+	return ml
+}
+
+// Min возвращает меньшее из двух значений
+func (ml MoodLevel) Min(other MoodLevel) MoodLevel {
+	if other < ml {
+		return other
+	}
+	return ml
+}
+
+// Percent возвращает значение в процентах (0-100)
+func (ml MoodLevel) Percent() int {
+	return levelPercent(int(ml))
+}
+
+// Normalized возвращает значение в диапазоне 0.0-1.0
+func (ml MoodLevel) Normalized() float64 {
+	return levelNormalized(int(ml))
+}
+
 func (ml MoodLevel) String() string {
 	return fmt.Sprintf("%d", ml)
 }
@@ -84,6 +289,38 @@ func (ml MoodLevel) IsPositive() bool {
 	return ml >= 6
 }
 
+func (ml MoodLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(ml))
+}
+
+func (ml *MoodLevel) UnmarshalJSON(data []byte) error {
+	value, err := decodeLevel(data, "mood level")
+	if err != nil {
+		return err
+	}
+
+	level, err := NewMoodLevel(value)
+	if err != nil {
+		return err
+	}
+
+	*ml = level
+	return nil
+}
+
+// AverageMoodLevels возвращает средний уровень настроения (0.0 для пустого среза)
+func AverageMoodLevels(levels []MoodLevel) float64 {
+	if len(levels) == 0 {
+		return 0
+	}
+
+	sum := 0
+	for _, value := range levels {
+		sum += int(value)
+	}
+	return float64(sum) / float64(len(levels))
+}
+
 // TaskCategory представляет категорию задачи
 type TaskCategory string
 
@@ -97,8 +334,15 @@ const (
 	TaskCategoryOther    TaskCategory = "другое"
 )
 
-// AllTaskCategories возвращает список всех доступных категорий
-func AllTaskCategories() []TaskCategory {
+// customTaskCategories хранит категории, зарегистрированные пользователем,
+// в порядке регистрации
+var (
+	customTaskCategoriesMu sync.RWMutex
+	customTaskCategories   []TaskCategory
+)
+
+// builtinTaskCategories возвращает предопределенные категории
+func builtinTaskCategories() []TaskCategory {
 	return []TaskCategory{
 		TaskCategoryWork,
 		TaskCategoryStudy,
@@ -109,10 +353,45 @@ func AllTaskCategories() []TaskCategory {
 	}
 }
 
+// AllTaskCategories возвращает список всех доступных категорий:
+// сначала предопределенные, затем зарегистрированные
+func AllTaskCategories() []TaskCategory {
+	customTaskCategoriesMu.RLock()
+	defer customTaskCategoriesMu.RUnlock()
+
+	return append(builtinTaskCategories(), customTaskCategories...)
+}
+
+// RegisterTaskCategory добавляет пользовательскую категорию, после чего
+// NewTaskCategory и IsValid принимают ее наравне с предопределенными
+func RegisterTaskCategory(name string) (TaskCategory, error) {
+	category := TaskCategory(normalizeTaskCategory(name))
+	if category == "" {
+		return "", errors.NewDomainErrorWithCode("task category name cannot be empty", errors.CodeEmptyField)
+	}
+
+	customTaskCategoriesMu.Lock()
+	defer customTaskCategoriesMu.Unlock()
+
+	for _, existing := range append(builtinTaskCategories(), customTaskCategories...) {
+		if existing == category {
+			return "", errors.NewDomainErrorWithCode("task category already exists: "+string(category), errors.CodeDuplicate)
+		}
+	}
+
+	customTaskCategories = append(customTaskCategories, category)
+	return category, nil
+}
+
+// normalizeTaskCategory приводит название категории к каноническому виду
+func normalizeTaskCategory(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
 // NewTaskCategory конструктор с валидацией
 func NewTaskCategory(category string) (TaskCategory, error) {
 	// Приводим к нижнему регистру для сравнения
-	category = strings.ToLower(strings.TrimSpace(category))
+	category = normalizeTaskCategory(category)
 
 	for _, validCategory := range AllTaskCategories() {
 		if strings.ToLower(string(validCategory)) == category {
@@ -120,13 +399,42 @@ func NewTaskCategory(category string) (TaskCategory, error) {
 		}
 	}
 
-	return "", errors.NewDomainError("invalid task category: " + category)
+	return "", errors.NewDomainErrorWithCode("invalid task category: "+category, errors.CodeInvalidValue)
 }
 
 func (tc TaskCategory) String() string {
 	return string(tc)
 }
 
+// taskCategoryDisplayNames содержит названия категорий для отображения по языкам
+var taskCategoryDisplayNames = map[string]map[TaskCategory]string{
+	"ru": {
+		TaskCategoryWork:     "Работа",
+		TaskCategoryStudy:    "Учеба",
+		TaskCategoryPersonal: "Личное",
+		TaskCategoryHealth:   "Здоровье",
+		TaskCategoryHobbies:  "Хобби",
+		TaskCategoryOther:    "Другое",
+	},
+	"en": {
+		TaskCategoryWork:     "Work",
+		TaskCategoryStudy:    "Study",
+		TaskCategoryPersonal: "Personal",
+		TaskCategoryHealth:   "Health",
+		TaskCategoryHobbies:  "Hobbies",
+		TaskCategoryOther:    "Other",
+	},
+}
+
+// DisplayName возвращает название категории для UI на языке lang.
+// Для неизвестного языка или категории без перевода возвращается исходное значение
+func (tc TaskCategory) DisplayName(lang string) string {
+	if name, ok := taskCategoryDisplayNames[lang][tc]; ok {
+		return name
+	}
+	return string(tc)
+}
+
 func (tc TaskCategory) IsValid() bool {
 	for _, validCategory := range AllTaskCategories() {
 		if tc == validCategory {
@@ -141,15 +449,60 @@ type SleepQuality int
 
 func NewSleepQuality(quality int) (SleepQuality, error) {
 	if quality < 0 || quality > 10 {
-		return 0, errors.NewDomainError("sleep quality must be between 0 and 10")
+		return 0, outOfRangeError("sleep quality", "sleepQuality", quality)
 	}
 	return SleepQuality(quality), nil
 }
 
+// ParseSleepQuality разбирает качество сна из строки (CSV, формы) с той же валидацией, что и NewSleepQuality
+func ParseSleepQuality(s string) (SleepQuality, error) {
+	value, err := parseLevel(s, "sleep quality")
+	if err != nil {
+		return 0, err
+	}
+	return NewSleepQuality(value)
+}
+
 func (sq SleepQuality) Int() int {
 	return int(sq)
 }
 
+// Compare сравнивает качество сна: -1, если меньше other, 0 при равенстве, 1, если больше
+func (sq SleepQuality) Compare(other SleepQuality) int {
+	return compareLevels(int(sq), int(other))
+}
+
+// Equals проверяет равенство с other
+func (sq SleepQuality) Equals(other SleepQuality) bool {
+	return sq == other
+}
+
+// Max возвращает большее из двух значений
+func (sq SleepQuality) Max(other SleepQuality) SleepQuality {
+	if other > sq {
+		return other
+	}
+	return sq
+}
+
+// Min возвращает меньшее из двух значений
+func (sq SleepQuality) Min(other SleepQuality) SleepQuality {
+	if other < sq {
+		return other
+	}
+	return sq
+}
+
+// Percent возвращает значение в процентах (0-100)
+func (sq SleepQuality) Percent() int {
+	return levelPercent(int(sq))
+}
+
+// Normalized возвращает значение в диапазоне 0.0-1.0
+func (sq SleepQuality) Normalized() float64 {
+	return levelNormalized(int(sq))
+}
+
 func (sq SleepQuality) String() string {
 	return fmt.Sprintf("%d", sq)
 }
@@ -158,20 +511,97 @@ func (sq SleepQuality) IsGood() bool {
 	return sq >= 7
 }
 
+func (sq SleepQuality) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(sq))
+}
+
+func (sq *SleepQuality) UnmarshalJSON(data []byte) error {
+	value, err := decodeLevel(data, "sleep quality")
+	if err != nil {
+		return err
+	}
+
+	level, err := NewSleepQuality(value)
+	if err != nil {
+		return err
+	}
+
+	*sq = level
+	return nil
+}
+
+// AverageSleepQualities возвращает среднее качество сна (0.0 для пустого среза)
+func AverageSleepQualities(qualities []SleepQuality) float64 {
+	if len(qualities) == 0 {
+		return 0
+	}
+
+	sum := 0
+	for _, value := range qualities {
+		sum += int(value)
+	}
+	return float64(sum) / float64(len(qualities))
+}
+
 // DaytimeSleepiness представляет дневную сонливость от 0 до 10
 type DaytimeSleepiness int
 
 func NewDaytimeSleepiness(sleepiness int) (DaytimeSleepiness, error) {
 	if sleepiness < 0 || sleepiness > 10 {
-		return 0, errors.NewDomainError("daytime sleepiness must be between 0 and 10")
+		return 0, outOfRangeError("daytime sleepiness", "daytimeSleepiness", sleepiness)
 	}
 	return DaytimeSleepiness(sleepiness), nil
 }
 
+// ParseDaytimeSleepiness разбирает дневную сонливость из строки (CSV, формы) с той же валидацией, что и NewDaytimeSleepiness
+func ParseDaytimeSleepiness(s string) (DaytimeSleepiness, error) {
+	value, err := parseLevel(s, "daytime sleepiness")
+	if err != nil {
+		return 0, err
+	}
+	return NewDaytimeSleepiness(value)
+}
+
 func (ds DaytimeSleepiness) Int() int {
 	return int(ds)
 }
 
+// Compare сравнивает дневную сонливость: -1, если меньше other, 0 при равенстве, 1, если больше
+func (ds DaytimeSleepiness) Compare(other DaytimeSleepiness) int {
+	return compareLevels(int(ds), int(other))
+}
+
+// Equals проверяет равенство с other
+func (ds DaytimeSleepiness) Equals(other DaytimeSleepiness) bool {
+	return ds == other
+}
+
+// Max возвращает большее из двух значений
+func (ds DaytimeSleepiness) Max(other DaytimeSleepiness) DaytimeSleepiness {
+	if other > ds {
+		return other
+	}
+	return ds
+}
+
+// Min возвращает меньшее из двух значений
+func (ds DaytimeSleepiness) Min(other DaytimeSleepiness) DaytimeSleepiness {
+	if other < ds {
+		return other
+	}
+	return ds
+}
+
+// Percent возвращает значение в процентах (0-100)
+func (ds DaytimeSleepiness) Percent() int {
+	return levelPercent(int(ds))
+}
+
+// Normalized возвращает значение в диапазоне 0.0-1.0
+func (ds DaytimeSleepiness) Normalized() float64 {
+	return levelNormalized(int(ds))
+}
+
 func (ds DaytimeSleepiness) String() string {
 	return fmt.Sprintf("%d", ds)
 }
@@ -179,3 +609,74 @@ func (ds DaytimeSleepiness) String() string {
 func (ds DaytimeSleepiness) IsHigh() bool {
 	return ds >= 7
 }
+
+func (ds DaytimeSleepiness) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(ds))
+}
+
+func (ds *DaytimeSleepiness) UnmarshalJSON(data []byte) error {
+	value, err := decodeLevel(data, "daytime sleepiness")
+	if err != nil {
+		return err
+	}
+
+	level, err := NewDaytimeSleepiness(value)
+	if err != nil {
+		return err
+	}
+
+	*ds = level
+	return nil
+}
+
+// compareLevels сравнивает значения уровней по шкале 0-10
+func compareLevels(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// levelPercent переводит значение шкалы 0-10 в проценты
+func levelPercent(value int) int {
+	return value * 100 / StressLevelMax
+}
+
+// levelNormalized переводит значение шкалы 0-10 в долю от 0.0 до 1.0
+func levelNormalized(value int) float64 {
+	return float64(value) / StressLevelMax
+}
+
+// decodeLevel разбирает JSON-число для уровней по шкале 0-10
+func decodeLevel(data []byte, name string) (int, error) {
+	var value int
+	if err := json.Unmarshal(data, &value); err != nil {
+		return 0, errors.NewDomainErrorWithCode(name+" must be an integer", errors.CodeInvalidFormat)
+	}
+	return value, nil
+}
+
+// parseLevel разбирает целое значение уровня из строки, обрезая пробелы
+func parseLevel(s, name string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.NewDomainErrorWithCode(name+" is required", errors.CodeEmptyField)
+	}
+
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.NewDomainErrorWrap(name+" must be an integer", err)
+	}
+	return value, nil
+}
+
+// outOfRangeError доменная ошибка выхода шкалы за пределы 0-10
+// Отклоненное значение доступно через ValidationError в цепочке ошибки
+func outOfRangeError(name, field string, value int) error {
+	return errors.NewDomainErrorWithCodeWrap(name+" must be between 0 and 10", errors.CodeInvalidRange,
+		errors.NewValidationErrorWithValue(field, "out of range", value))
+}
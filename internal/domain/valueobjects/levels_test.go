@@ -1,7 +1,12 @@
 package valueobjects
 
 import (
+	"daily-tracker/pkg/errors"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -160,6 +165,124 @@ func TestAllTaskCategories(t *testing.T) {
 	}
 }
 
+func TestTaskCategory_DisplayName(t *testing.T) {
+	tests := []struct {
+		name     string
+		category TaskCategory
+		lang     string
+		expected string
+	}{
+		{"english work", TaskCategoryWork, "en", "Work"},
+		{"english hobbies", TaskCategoryHobbies, "en", "Hobbies"},
+		{"russian work", TaskCategoryWork, "ru", "Работа"},
+		{"russian other", TaskCategoryOther, "ru", "Другое"},
+		{"unknown language", TaskCategoryStudy, "de", "учеба"},
+		{"untranslated category", TaskCategory("спорт"), "en", "спорт"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.category.DisplayName(tt.lang); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+
+	// String по-прежнему возвращает канонический ключ для хранения
+	if TaskCategoryWork.String() != "работа" {
+		t.Errorf("Expected String() to stay 'работа', got '%s'", TaskCategoryWork.String())
+	}
+}
+
+// resetCustomTaskCategories очищает реестр пользовательских категорий после теста
+func resetCustomTaskCategories(t *testing.T) {
+	t.Cleanup(func() {
+		customTaskCategoriesMu.Lock()
+		defer customTaskCategoriesMu.Unlock()
+		customTaskCategories = nil
+	})
+}
+
+func TestRegisterTaskCategory(t *testing.T) {
+	resetCustomTaskCategories(t)
+
+	category, err := RegisterTaskCategory("  Спорт ")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if category != TaskCategory("спорт") {
+		t.Errorf("Expected normalized category 'спорт', got '%s'", category)
+	}
+
+	parsed, err := NewTaskCategory("СПОРТ")
+	if err != nil {
+		t.Fatalf("Expected registered category to be accepted, got %v", err)
+	}
+
+	if parsed != category || !parsed.IsValid() {
+		t.Errorf("Expected valid category '%s', got '%s'", category, parsed)
+	}
+
+	categories := AllTaskCategories()
+	if len(categories) != 7 || categories[len(categories)-1] != category {
+		t.Errorf("Expected built-in categories followed by '%s', got %v", category, categories)
+	}
+}
+
+func TestRegisterTaskCategory_Invalid(t *testing.T) {
+	resetCustomTaskCategories(t)
+
+	if _, err := RegisterTaskCategory("чтение"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ""},
+		{"whitespace", "   "},
+		{"duplicate built-in", "Работа"},
+		{"duplicate registered", " ЧТЕНИЕ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RegisterTaskCategory(tt.input)
+			if !errors.IsDomainError(err) {
+				t.Errorf("Expected DomainError for input '%s', got %v", tt.input, err)
+			}
+		})
+	}
+
+	if len(AllTaskCategories()) != 7 {
+		t.Errorf("Expected rejected registrations to leave 7 categories, got %d", len(AllTaskCategories()))
+	}
+}
+
+func TestRegisterTaskCategory_Concurrent(t *testing.T) {
+	resetCustomTaskCategories(t)
+
+	const workers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := RegisterTaskCategory(fmt.Sprintf("категория-%d", i)); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			AllTaskCategories()
+		}(i)
+	}
+	wg.Wait()
+
+	if got := len(AllTaskCategories()); got != 6+workers {
+		t.Errorf("Expected %d categories, got %d", 6+workers, got)
+	}
+}
+
 // Бенчмарк для проверки производительности создания категории
 func BenchmarkNewTaskCategory(b *testing.B) {
 	for i := 0; i < b.N; i++ {
@@ -214,6 +337,100 @@ func TestStressLevel_String_Parallel(t *testing.T) {
 	}
 }
 
+// Тестируем JSON-сериализацию уровней
+func TestLevels_JSONRoundTrip(t *testing.T) {
+	type levels struct {
+		Stress     StressLevel       `json:"stress"`
+		Energy     EnergyLevel       `json:"energy"`
+		Mood       MoodLevel         `json:"mood"`
+		Quality    SleepQuality      `json:"quality"`
+		Sleepiness DaytimeSleepiness `json:"sleepiness"`
+	}
+
+	original := levels{Stress: 7, Energy: 0, Mood: 10, Quality: 5, Sleepiness: 3}
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+
+	// Сериализованная форма остается обычным числом
+	expectedJSON := `{"stress":7,"energy":0,"mood":10,"quality":5,"sleepiness":3}`
+	if string(data) != expectedJSON {
+		t.Errorf("Expected %s, got %s", expectedJSON, data)
+	}
+
+	var decoded levels
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+
+	if decoded != original {
+		t.Errorf("Expected %+v after round trip, got %+v", original, decoded)
+	}
+}
+
+func TestLevels_UnmarshalJSON_OutOfRange(t *testing.T) {
+	targets := []struct {
+		name   string
+		target func() interface{}
+	}{
+		{"stress level", func() interface{} { return new(StressLevel) }},
+		{"energy level", func() interface{} { return new(EnergyLevel) }},
+		{"mood level", func() interface{} { return new(MoodLevel) }},
+		{"sleep quality", func() interface{} { return new(SleepQuality) }},
+		{"daytime sleepiness", func() interface{} { return new(DaytimeSleepiness) }},
+	}
+
+	for _, tt := range targets {
+		for _, input := range []string{"11", "-1"} {
+			t.Run(tt.name+" "+input, func(t *testing.T) {
+				err := json.Unmarshal([]byte(input), tt.target())
+				if err == nil {
+					t.Fatalf("Expected error for %s, got nil", input)
+				}
+
+				if !errors.IsDomainError(err) {
+					t.Errorf("Expected DomainError, got %T: %v", err, err)
+				}
+			})
+		}
+	}
+}
+
+func TestLevelConstructors_CaptureOutOfRangeValue(t *testing.T) {
+	constructors := []struct {
+		field string
+		build func(int) error
+	}{
+		{"stressLevel", func(v int) error { _, err := NewStressLevel(v); return err }},
+		{"energyLevel", func(v int) error { _, err := NewEnergyLevel(v); return err }},
+		{"moodLevel", func(v int) error { _, err := NewMoodLevel(v); return err }},
+		{"sleepQuality", func(v int) error { _, err := NewSleepQuality(v); return err }},
+		{"daytimeSleepiness", func(v int) error { _, err := NewDaytimeSleepiness(v); return err }},
+	}
+
+	for _, tc := range constructors {
+		t.Run(tc.field, func(t *testing.T) {
+			err := tc.build(12)
+
+			var domainErr *errors.DomainError
+			if !stderrors.As(err, &domainErr) || domainErr.Code() != errors.CodeInvalidRange.String() {
+				t.Errorf("Expected DomainError with code %s, got %v", errors.CodeInvalidRange, err)
+			}
+
+			var validation *errors.ValidationError
+			if !stderrors.As(err, &validation) {
+				t.Fatalf("Expected ValidationError for %s", tc.field)
+			}
+
+			if validation.Field() != tc.field || validation.Value() != 12 {
+				t.Errorf("Expected field %s with value 12, got %s with %v", tc.field, validation.Field(), validation.Value())
+			}
+		})
+	}
+}
+
 // Пример использования testify (если добавим зависимость)
 // func TestWithTestify(t *testing.T) {
 //     assert := assert.New(t)
@@ -224,3 +441,217 @@ func TestStressLevel_String_Parallel(t *testing.T) {
 //     assert.Equal("5", sl.String())
 //     assert.False(sl.IsHigh())
 // }
+
+func TestLevels_CompareAndEquals(t *testing.T) {
+	// Для каждого типа приводим Compare/Equals/Min/Max к int, чтобы проверить единой таблицей
+	type ops struct {
+		compare func(a, b int) int
+		equals  func(a, b int) bool
+		min     func(a, b int) int
+		max     func(a, b int) int
+	}
+
+	types := map[string]ops{
+		"stress": {
+			compare: func(a, b int) int { return StressLevel(a).Compare(StressLevel(b)) },
+			equals:  func(a, b int) bool { return StressLevel(a).Equals(StressLevel(b)) },
+			min:     func(a, b int) int { return StressLevel(a).Min(StressLevel(b)).Int() },
+			max:     func(a, b int) int { return StressLevel(a).Max(StressLevel(b)).Int() },
+		},
+		"energy": {
+			compare: func(a, b int) int { return EnergyLevel(a).Compare(EnergyLevel(b)) },
+			equals:  func(a, b int) bool { return EnergyLevel(a).Equals(EnergyLevel(b)) },
+			min:     func(a, b int) int { return EnergyLevel(a).Min(EnergyLevel(b)).Int() },
+			max:     func(a, b int) int { return EnergyLevel(a).Max(EnergyLevel(b)).Int() },
+		},
+		"mood": {
+			compare: func(a, b int) int { return MoodLevel(a).Compare(MoodLevel(b)) },
+			equals:  func(a, b int) bool { return MoodLevel(a).Equals(MoodLevel(b)) },
+			min:     func(a, b int) int { return MoodLevel(a).Min(MoodLevel(b)).Int() },
+			max:     func(a, b int) int { return MoodLevel(a).Max(MoodLevel(b)).Int() },
+		},
+		"sleep quality": {
+			compare: func(a, b int) int { return SleepQuality(a).Compare(SleepQuality(b)) },
+			equals:  func(a, b int) bool { return SleepQuality(a).Equals(SleepQuality(b)) },
+			min:     func(a, b int) int { return SleepQuality(a).Min(SleepQuality(b)).Int() },
+			max:     func(a, b int) int { return SleepQuality(a).Max(SleepQuality(b)).Int() },
+		},
+		"daytime sleepiness": {
+			compare: func(a, b int) int { return DaytimeSleepiness(a).Compare(DaytimeSleepiness(b)) },
+			equals:  func(a, b int) bool { return DaytimeSleepiness(a).Equals(DaytimeSleepiness(b)) },
+			min:     func(a, b int) int { return DaytimeSleepiness(a).Min(DaytimeSleepiness(b)).Int() },
+			max:     func(a, b int) int { return DaytimeSleepiness(a).Max(DaytimeSleepiness(b)).Int() },
+		},
+	}
+
+	cases := []struct {
+		name            string
+		a, b            int
+		expectedCompare int
+		expectedMin     int
+		expectedMax     int
+	}{
+		{"less", 3, 7, -1, 3, 7},
+		{"equal", 5, 5, 0, 5, 5},
+		{"greater", 9, 2, 1, 2, 9},
+	}
+
+	for typeName, op := range types {
+		for _, tc := range cases {
+			t.Run(typeName+" "+tc.name, func(t *testing.T) {
+				if got := op.compare(tc.a, tc.b); got != tc.expectedCompare {
+					t.Errorf("Expected Compare = %d, got %d", tc.expectedCompare, got)
+				}
+
+				if got := op.equals(tc.a, tc.b); got != (tc.expectedCompare == 0) {
+					t.Errorf("Expected Equals = %v, got %v", tc.expectedCompare == 0, got)
+				}
+
+				if got := op.min(tc.a, tc.b); got != tc.expectedMin {
+					t.Errorf("Expected Min = %d, got %d", tc.expectedMin, got)
+				}
+
+				if got := op.max(tc.a, tc.b); got != tc.expectedMax {
+					t.Errorf("Expected Max = %d, got %d", tc.expectedMax, got)
+				}
+			})
+		}
+	}
+}
+
+func TestParseLevels(t *testing.T) {
+	parsers := map[string]func(string) (int, error){
+		"stress level": func(s string) (int, error) {
+			l, err := ParseStressLevel(s)
+			return l.Int(), err
+		},
+		"energy level": func(s string) (int, error) {
+			l, err := ParseEnergyLevel(s)
+			return l.Int(), err
+		},
+		"mood level": func(s string) (int, error) {
+			l, err := ParseMoodLevel(s)
+			return l.Int(), err
+		},
+		"sleep quality": func(s string) (int, error) {
+			l, err := ParseSleepQuality(s)
+			return l.Int(), err
+		},
+		"daytime sleepiness": func(s string) (int, error) {
+			l, err := ParseDaytimeSleepiness(s)
+			return l.Int(), err
+		},
+	}
+
+	cases := []struct {
+		name          string
+		input         string
+		expected      int
+		expectedError string
+	}{
+		{"valid", "7", 7, ""},
+		{"valid with whitespace", "  3\t", 3, ""},
+		{"non-numeric", "seven", 0, "must be an integer"},
+		{"out of range", "11", 0, "must be between 0 and 10"},
+		{"negative", "-1", 0, "must be between 0 and 10"},
+		{"empty", "   ", 0, "is required"},
+	}
+
+	for levelName, parse := range parsers {
+		for _, tc := range cases {
+			t.Run(levelName+" "+tc.name, func(t *testing.T) {
+				got, err := parse(tc.input)
+
+				if tc.expectedError == "" {
+					if err != nil {
+						t.Fatalf("Expected no error, got %v", err)
+					}
+					if got != tc.expected {
+						t.Errorf("Expected %d, got %d", tc.expected, got)
+					}
+					return
+				}
+
+				if !errors.IsDomainError(err) {
+					t.Fatalf("Expected DomainError, got %v", err)
+				}
+				if !strings.Contains(err.Error(), tc.expectedError) {
+					t.Errorf("Expected error containing %q, got %q", tc.expectedError, err.Error())
+				}
+			})
+		}
+	}
+}
+
+func TestLevels_PercentAndNormalized(t *testing.T) {
+	tests := []struct {
+		value              int
+		expectedPercent    int
+		expectedNormalized float64
+	}{
+		{0, 0, 0.0},
+		{5, 50, 0.5},
+		{7, 70, 0.7},
+		{10, 100, 1.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("value %d", tt.value), func(t *testing.T) {
+			sq := SleepQuality(tt.value)
+			if sq.Percent() != tt.expectedPercent || sq.Normalized() != tt.expectedNormalized {
+				t.Errorf("Expected sleep quality %d%% / %v, got %d%% / %v", tt.expectedPercent, tt.expectedNormalized, sq.Percent(), sq.Normalized())
+			}
+
+			sl := StressLevel(tt.value)
+			if sl.Percent() != tt.expectedPercent || sl.Normalized() != tt.expectedNormalized {
+				t.Errorf("Expected stress level %d%% / %v, got %d%% / %v", tt.expectedPercent, tt.expectedNormalized, sl.Percent(), sl.Normalized())
+			}
+
+			el := EnergyLevel(tt.value)
+			if el.Percent() != tt.expectedPercent || el.Normalized() != tt.expectedNormalized {
+				t.Errorf("Expected energy level %d%% / %v, got %d%% / %v", tt.expectedPercent, tt.expectedNormalized, el.Percent(), el.Normalized())
+			}
+		})
+	}
+}
+
+func TestAverageLevels(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []int
+		expected float64
+	}{
+		{"empty", nil, 0},
+		{"single element", []int{7}, 7},
+		{"several elements", []int{2, 5, 6}, 13.0 / 3},
+		{"extremes", []int{0, 10}, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stress := make([]StressLevel, 0, len(tt.values))
+			energy := make([]EnergyLevel, 0, len(tt.values))
+			mood := make([]MoodLevel, 0, len(tt.values))
+			quality := make([]SleepQuality, 0, len(tt.values))
+			for _, v := range tt.values {
+				stress = append(stress, StressLevel(v))
+				energy = append(energy, EnergyLevel(v))
+				mood = append(mood, MoodLevel(v))
+				quality = append(quality, SleepQuality(v))
+			}
+
+			results := map[string]float64{
+				"stress":        AverageStressLevels(stress),
+				"energy":        AverageEnergyLevels(energy),
+				"mood":          AverageMoodLevels(mood),
+				"sleep quality": AverageSleepQualities(quality),
+			}
+
+			for name, got := range results {
+				if got != tt.expected {
+					t.Errorf("Expected average %s %v, got %v", name, tt.expected, got)
+				}
+			}
+		})
+	}
+}
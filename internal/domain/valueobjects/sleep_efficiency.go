@@ -0,0 +1,40 @@
+package valueobjects
+
+import (
+	"daily-tracker/pkg/errors"
+	"fmt"
+)
+
+// SleepEfficiency эффективность сна в процентах (0-100):
+// доля времени сна от времени, проведенного в постели
+type SleepEfficiency float64
+
+const (
+	SleepEfficiencyMin = 0.0
+	SleepEfficiencyMax = 100.0
+
+	// SleepEfficiencyPoorThreshold порог, ниже которого эффективность считается плохой
+	SleepEfficiencyPoorThreshold = 85.0
+)
+
+// NewSleepEfficiency конструктор с валидацией
+func NewSleepEfficiency(percent float64) (SleepEfficiency, error) {
+	if percent < SleepEfficiencyMin || percent > SleepEfficiencyMax {
+		return 0, errors.NewDomainErrorWithCode("sleep efficiency must be between 0 and 100", errors.CodeInvalidRange)
+	}
+	return SleepEfficiency(percent), nil
+}
+
+// Float64 возвращает значение в процентах
+func (se SleepEfficiency) Float64() float64 {
+	return float64(se)
+}
+
+func (se SleepEfficiency) String() string {
+	return fmt.Sprintf("%.1f%%", float64(se))
+}
+
+// IsPoor проверяет, ниже ли эффективность рекомендуемых 85%
+func (se SleepEfficiency) IsPoor() bool {
+	return se < SleepEfficiencyPoorThreshold
+}
@@ -0,0 +1,41 @@
+package valueobjects
+
+import "testing"
+
+func TestNewSleepEfficiency(t *testing.T) {
+	tests := []struct {
+		name        string
+		percent     float64
+		expectError bool
+	}{
+		{"zero", 0, false},
+		{"typical", 92.5, false},
+		{"perfect", 100, false},
+		{"negative", -0.1, true},
+		{"over hundred", 100.1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			efficiency, err := NewSleepEfficiency(tt.percent)
+
+			if tt.expectError && err == nil {
+				t.Error("Expected error, got nil")
+			}
+
+			if !tt.expectError && efficiency.Float64() != tt.percent {
+				t.Errorf("Expected %v, got %v (err: %v)", tt.percent, efficiency.Float64(), err)
+			}
+		})
+	}
+}
+
+func TestSleepEfficiency_IsPoor(t *testing.T) {
+	if !SleepEfficiency(84.9).IsPoor() {
+		t.Error("Expected 84.9% to be poor")
+	}
+
+	if SleepEfficiency(85).IsPoor() {
+		t.Error("Expected 85% to not be poor")
+	}
+}
@@ -0,0 +1,76 @@
+package valueobjects
+
+import "fmt"
+
+// StressDirection направление изменения уровня стресса
+type StressDirection string
+
+const (
+	StressReduced   StressDirection = "reduced"
+	StressIncreased StressDirection = "increased"
+	StressUnchanged StressDirection = "unchanged"
+)
+
+// StressChange изменение стресса между замерами до и после задачи
+// Избавляет потребителей от ручной интерпретации знака разницы
+type StressChange struct {
+	before StressLevel
+	after  StressLevel
+}
+
+// NewStressChange создает изменение стресса по двум замерам
+func NewStressChange(before, after StressLevel) StressChange {
+	return StressChange{before: before, after: after}
+}
+
+func (sc StressChange) Before() StressLevel {
+	return sc.before
+}
+
+func (sc StressChange) After() StressLevel {
+	return sc.after
+}
+
+// Reduction возвращает разницу before - after: положительная при снижении стресса
+func (sc StressChange) Reduction() int {
+	return int(sc.before) - int(sc.after)
+}
+
+// Magnitude возвращает величину изменения без знака
+func (sc StressChange) Magnitude() int {
+	reduction := sc.Reduction()
+	if reduction < 0 {
+		return -reduction
+	}
+	return reduction
+}
+
+// Direction возвращает направление изменения
+func (sc StressChange) Direction() StressDirection {
+	switch reduction := sc.Reduction(); {
+	case reduction > 0:
+		return StressReduced
+	case reduction < 0:
+		return StressIncreased
+	default:
+		return StressUnchanged
+	}
+}
+
+// IsReduction проверяет, снизился ли стресс
+func (sc StressChange) IsReduction() bool {
+	return sc.Direction() == StressReduced
+}
+
+// IsIncrease проверяет, вырос ли стресс
+func (sc StressChange) IsIncrease() bool {
+	return sc.Direction() == StressIncreased
+}
+
+// String возвращает описание для отчетов: "reduced by 4", "increased by 2" или "unchanged"
+func (sc StressChange) String() string {
+	if sc.Direction() == StressUnchanged {
+		return string(StressUnchanged)
+	}
+	return fmt.Sprintf("%s by %d", sc.Direction(), sc.Magnitude())
+}
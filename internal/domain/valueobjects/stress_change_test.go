@@ -0,0 +1,48 @@
+package valueobjects
+
+import "testing"
+
+func TestStressChange(t *testing.T) {
+	tests := []struct {
+		name        string
+		before      StressLevel
+		after       StressLevel
+		reduction   int
+		magnitude   int
+		direction   StressDirection
+		isReduction bool
+		isIncrease  bool
+		text        string
+	}{
+		{"reduction", 8, 4, 4, 4, StressReduced, true, false, "reduced by 4"},
+		{"increase", 3, 5, -2, 2, StressIncreased, false, true, "increased by 2"},
+		{"no change", 6, 6, 0, 0, StressUnchanged, false, false, "unchanged"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := NewStressChange(tt.before, tt.after)
+
+			if change.Reduction() != tt.reduction {
+				t.Errorf("Expected reduction %d, got %d", tt.reduction, change.Reduction())
+			}
+
+			if change.Magnitude() != tt.magnitude {
+				t.Errorf("Expected magnitude %d, got %d", tt.magnitude, change.Magnitude())
+			}
+
+			if change.Direction() != tt.direction {
+				t.Errorf("Expected direction %s, got %s", tt.direction, change.Direction())
+			}
+
+			if change.IsReduction() != tt.isReduction || change.IsIncrease() != tt.isIncrease {
+				t.Errorf("Expected IsReduction=%v IsIncrease=%v, got %v and %v",
+					tt.isReduction, tt.isIncrease, change.IsReduction(), change.IsIncrease())
+			}
+
+			if change.String() != tt.text {
+				t.Errorf("Expected %q, got %q", tt.text, change.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package valueobjects
+
+import (
+	"daily-tracker/pkg/errors"
+	"strings"
+)
+
+// TaskPriority представляет приоритет задачи
+type TaskPriority string
+
+// Допустимые приоритеты в порядке возрастания важности
+const (
+	TaskPriorityLow    TaskPriority = "low"
+	TaskPriorityMedium TaskPriority = "medium"
+	TaskPriorityHigh   TaskPriority = "high"
+	TaskPriorityUrgent TaskPriority = "urgent"
+)
+
+// AllTaskPriorities возвращает список всех приоритетов
+func AllTaskPriorities() []TaskPriority {
+	return []TaskPriority{
+		TaskPriorityLow,
+		TaskPriorityMedium,
+		TaskPriorityHigh,
+		TaskPriorityUrgent,
+	}
+}
+
+// NewTaskPriority конструктор с валидацией
+func NewTaskPriority(priority string) (TaskPriority, error) {
+	priority = strings.ToLower(strings.TrimSpace(priority))
+
+	for _, validPriority := range AllTaskPriorities() {
+		if string(validPriority) == priority {
+			return validPriority, nil
+		}
+	}
+
+	return "", errors.NewDomainErrorWithCode("invalid task priority: "+priority, errors.CodeInvalidValue)
+}
+
+func (tp TaskPriority) String() string {
+	return string(tp)
+}
+
+func (tp TaskPriority) IsValid() bool {
+	for _, validPriority := range AllTaskPriorities() {
+		if tp == validPriority {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUrgent проверяет, требует ли задача немедленного внимания
+func (tp TaskPriority) IsUrgent() bool {
+	return tp == TaskPriorityUrgent
+}
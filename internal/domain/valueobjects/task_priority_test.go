@@ -0,0 +1,57 @@
+package valueobjects
+
+import (
+	"daily-tracker/pkg/errors"
+	"testing"
+)
+
+func TestNewTaskPriority_Valid(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected TaskPriority
+	}{
+		{"low", "low", TaskPriorityLow},
+		{"medium uppercase", "MEDIUM", TaskPriorityMedium},
+		{"high with spaces", " high ", TaskPriorityHigh},
+		{"urgent", "urgent", TaskPriorityUrgent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			priority, err := NewTaskPriority(tt.input)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if priority != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, priority)
+			}
+		})
+	}
+}
+
+func TestNewTaskPriority_Invalid(t *testing.T) {
+	invalidInputs := []string{"", "critical", "срочно"}
+
+	for _, input := range invalidInputs {
+		t.Run(input, func(t *testing.T) {
+			_, err := NewTaskPriority(input)
+			if !errors.IsDomainError(err) {
+				t.Errorf("Expected DomainError for input '%s', got %v", input, err)
+			}
+		})
+	}
+}
+
+func TestTaskPriority_IsUrgent(t *testing.T) {
+	for _, priority := range AllTaskPriorities() {
+		if priority.IsUrgent() != (priority == TaskPriorityUrgent) {
+			t.Errorf("Unexpected IsUrgent() = %v for %s", priority.IsUrgent(), priority)
+		}
+	}
+
+	if TaskPriority("").IsValid() {
+		t.Error("Expected empty priority to be invalid")
+	}
+}
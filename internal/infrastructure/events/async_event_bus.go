@@ -0,0 +1,205 @@
+package events
+
+import (
+	"context"
+	domainevents "daily-tracker/internal/domain/events"
+	"daily-tracker/pkg/errors"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// Проверка на этапе компиляции, что тип реализует интерфейс
+var _ domainevents.EventBus = (*AsyncEventBus)(nil)
+
+// AsyncEventBus асинхронная шина событий с пулом воркеров
+// Publish только ставит событие в буферизованную очередь; обработчики вызываются воркерами.
+// При заполненной очереди Publish ждет, пока воркеры освободят место или шина остановится
+type AsyncEventBus struct {
+	subscribers *InMemoryEventBus
+	queue       chan domainevents.DomainEvent
+	workers     int
+	logger      *log.Logger
+
+	mu         sync.RWMutex // Защищает running и stopped
+	running    bool
+	stopped    bool
+	closing    chan struct{}  // Закрывается, когда шина перестает принимать события
+	closeOnce  sync.Once      // Гарантирует однократное закрытие closing
+	publishing sync.WaitGroup // Publish, ожидающие места в очереди
+	wg         sync.WaitGroup
+}
+
+// NewAsyncEventBus создает шину с заданным числом воркеров и размером очереди
+// Ошибки и паники обработчиков пишутся в logger (nil - стандартный логгер)
+func NewAsyncEventBus(workers, queueSize int, logger *log.Logger) (*AsyncEventBus, error) {
+	if workers < 1 {
+		return nil, errors.NewDomainError("worker count must be positive")
+	}
+
+	if queueSize < 0 {
+		return nil, errors.NewDomainError("queue size cannot be negative")
+	}
+
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &AsyncEventBus{
+		subscribers: NewInMemoryEventBus(),
+		queue:       make(chan domainevents.DomainEvent, queueSize),
+		workers:     workers,
+		logger:      logger,
+		closing:     make(chan struct{}),
+	}, nil
+}
+
+// Subscribe подписывает обработчик на тип события
+func (b *AsyncEventBus) Subscribe(eventType string, handler domainevents.EventHandler) error {
+	return b.subscribers.Subscribe(eventType, handler)
+}
+
+// Unsubscribe отписывает обработчик от типа события
+func (b *AsyncEventBus) Unsubscribe(eventType string, handler domainevents.EventHandler) error {
+	return b.subscribers.Unsubscribe(eventType, handler)
+}
+
+// Start запускает воркеров. Отмена ctx останавливает их без обработки оставшейся очереди
+// и переводит шину в остановленное состояние; для корректной остановки используйте Shutdown
+func (b *AsyncEventBus) Start(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.running || b.stopped {
+		return errors.NewDomainError("event bus already started")
+	}
+	b.running = true
+
+	for i := 0; i < b.workers; i++ {
+		b.wg.Add(1)
+		go b.work(ctx)
+	}
+
+	// После отмены ctx воркеры уже не разбирают очередь - перестаем принимать события,
+	// иначе Publish заблокируется на заполненной очереди навсегда
+	go func() {
+		select {
+		case <-ctx.Done():
+			b.stop()
+		case <-b.closing:
+		}
+	}()
+
+	return nil
+}
+
+// stop помечает шину остановленной и будит Publish, ожидающие места в очереди
+// Возвращает false, если шина уже не работала
+func (b *AsyncEventBus) stop() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.running {
+		return false
+	}
+	b.running = false
+	b.stopped = true
+	b.closeOnce.Do(func() { close(b.closing) })
+	return true
+}
+
+// Publish ставит событие в очередь на обработку
+func (b *AsyncEventBus) Publish(event domainevents.DomainEvent) error {
+	if event == nil {
+		return errors.NewDomainError("event cannot be nil")
+	}
+
+	// Блокировка не удерживается во время ожидания места в очереди,
+	// чтобы Shutdown мог остановить шину
+	b.mu.RLock()
+	if !b.running {
+		b.mu.RUnlock()
+		return errors.NewDomainError("event bus is not running")
+	}
+	b.publishing.Add(1)
+	b.mu.RUnlock()
+	defer b.publishing.Done()
+
+	select {
+	case b.queue <- event:
+		return nil
+	case <-b.closing:
+		return errors.NewDomainError("event bus is not running")
+	}
+}
+
+// Shutdown перестает принимать события и ждет, пока воркеры обработают уже принятые
+// Если ctx истекает раньше, возвращает его ошибку; воркеры при этом дорабатывают в фоне
+func (b *AsyncEventBus) Shutdown(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if !b.stop() {
+		return errors.NewDomainError("event bus is not running")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		// Очередь закрывается, только когда ни один Publish уже не может в нее писать
+		b.publishing.Wait()
+		close(b.queue)
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// work обрабатывает события из очереди, пока она не закрыта или не отменен ctx
+func (b *AsyncEventBus) work(ctx context.Context) {
+	defer b.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-b.queue:
+			if !ok {
+				return
+			}
+			b.dispatch(event)
+		}
+	}
+}
+
+// dispatch вызывает обработчики события, изолируя каждого от ошибок и паник остальных
+func (b *AsyncEventBus) dispatch(event domainevents.DomainEvent) {
+	eventType := event.EventType()
+
+	for _, handler := range b.subscribers.handlersFor(eventType) {
+		if !handler.CanHandle(eventType) {
+			continue
+		}
+
+		if err := b.handleSafely(handler, event); err != nil {
+			b.logger.Printf("event bus: handler %T failed on %s (%s): %v", handler, eventType, event.EventID(), err)
+		}
+	}
+}
+
+// handleSafely вызывает обработчик и превращает панику в ошибку, чтобы воркер продолжил работу
+func (b *AsyncEventBus) handleSafely(handler domainevents.EventHandler, event domainevents.DomainEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.NewDomainErrorWithCode("handler panicked: "+fmt.Sprint(r), errors.CodeHandlerPanic)
+		}
+	}()
+
+	return handler.Handle(event)
+}
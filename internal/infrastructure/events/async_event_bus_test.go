@@ -0,0 +1,278 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	domainevents "daily-tracker/internal/domain/events"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAsyncEventBus_DeliversAllEvents(t *testing.T) {
+	bus := newStartedAsyncBus(t, 4, 8, nil)
+	handler := &recordingHandler{}
+	bus.Subscribe("TaskStarted", handler)
+
+	for i := 0; i < 50; i++ {
+		if err := bus.Publish(newTestEvent("TaskStarted", fmt.Sprintf("task-%d", i))); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+
+	if err := bus.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected no error on shutdown, got: %v", err)
+	}
+
+	// Воркеры работают параллельно, поэтому проверяем набор, а не порядок
+	seen := make(map[string]bool)
+	for _, event := range handler.events {
+		seen[event.AggregateID()] = true
+	}
+	if len(seen) != 50 {
+		t.Errorf("Expected 50 distinct events delivered, got %d", len(seen))
+	}
+}
+
+func TestAsyncEventBus_ShutdownDrainsQueue(t *testing.T) {
+	bus := newStartedAsyncBus(t, 1, 10, nil)
+	handler := &slowHandler{delay: 5 * time.Millisecond}
+	bus.Subscribe("TaskStarted", handler)
+
+	for i := 0; i < 10; i++ {
+		bus.Publish(newTestEvent("TaskStarted", "task-1"))
+	}
+
+	if err := bus.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected no error on shutdown, got: %v", err)
+	}
+
+	if handler.handled() != 10 {
+		t.Errorf("Expected all 10 queued events to be handled before shutdown returns, got %d", handler.handled())
+	}
+
+	if err := bus.Publish(newTestEvent("TaskStarted", "task-1")); err == nil {
+		t.Error("Expected error publishing after shutdown, got nil")
+	}
+}
+
+func TestAsyncEventBus_ShutdownTimeout(t *testing.T) {
+	bus := newStartedAsyncBus(t, 1, 1, nil)
+	release := make(chan struct{})
+	defer close(release)
+	bus.Subscribe("TaskStarted", &blockingHandler{release: release})
+	bus.Publish(newTestEvent("TaskStarted", "task-1"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := bus.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestAsyncEventBus_StartContextCancelUnblocksPublish(t *testing.T) {
+	bus, _ := NewAsyncEventBus(1, 1, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bus.Start(ctx)
+
+	release := make(chan struct{})
+	defer close(release)
+	bus.Subscribe("TaskStarted", &blockingHandler{release: release})
+
+	// Первое событие занимает воркер, второе заполняет очередь
+	bus.Publish(newTestEvent("TaskStarted", "task-1"))
+	waitFor(t, func() bool { return len(bus.queue) == 0 })
+	bus.Publish(newTestEvent("TaskStarted", "task-2"))
+
+	published := make(chan error, 1)
+	go func() {
+		published <- bus.Publish(newTestEvent("TaskStarted", "task-3"))
+	}()
+
+	cancel()
+
+	select {
+	case err := <-published:
+		if err == nil {
+			t.Error("Expected error publishing after start ctx is cancelled, got nil")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected blocked Publish to return after start ctx is cancelled")
+	}
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer shutdownCancel()
+
+	finished := make(chan struct{})
+	go func() {
+		bus.Shutdown(shutdownCtx)
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Shutdown to return instead of hanging")
+	}
+}
+
+func TestAsyncEventBus_ShutdownWithBlockedPublish(t *testing.T) {
+	bus := newStartedAsyncBus(t, 1, 1, nil)
+	release := make(chan struct{})
+	defer close(release)
+	bus.Subscribe("TaskStarted", &blockingHandler{release: release})
+
+	bus.Publish(newTestEvent("TaskStarted", "task-1"))
+	waitFor(t, func() bool { return len(bus.queue) == 0 })
+	bus.Publish(newTestEvent("TaskStarted", "task-2"))
+
+	published := make(chan error, 1)
+	go func() {
+		published <- bus.Publish(newTestEvent("TaskStarted", "task-3"))
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	if err := bus.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected deadline exceeded while handler is blocked, got %v", err)
+	}
+
+	select {
+	case err := <-published:
+		if err == nil {
+			t.Error("Expected blocked Publish to fail after shutdown, got nil")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected blocked Publish to return after shutdown")
+	}
+}
+
+func TestAsyncEventBus_ShutdownHonoursExpiredContext(t *testing.T) {
+	bus := newStartedAsyncBus(t, 1, 1, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := bus.Shutdown(ctx); err != context.Canceled {
+		t.Errorf("Expected context canceled, got %v", err)
+	}
+
+	if err := bus.Shutdown(context.Background()); err != nil {
+		t.Errorf("Expected bus to still be running after rejected shutdown, got %v", err)
+	}
+}
+
+func TestAsyncEventBus_RecoversHandlerPanics(t *testing.T) {
+	var logs bytes.Buffer
+	bus := newStartedAsyncBus(t, 1, 4, log.New(&logs, "", 0))
+	handler := &recordingHandler{}
+	bus.Subscribe("TaskStarted", &panickingHandler{})
+	bus.Subscribe("TaskStarted", handler)
+
+	bus.Publish(newTestEvent("TaskStarted", "task-1"))
+	bus.Publish(newTestEvent("TaskStarted", "task-2"))
+
+	if err := bus.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected no error on shutdown, got: %v", err)
+	}
+
+	// Единственный воркер пережил обе паники и вызвал соседний обработчик
+	if handler.count() != 2 {
+		t.Errorf("Expected healthy handler to receive 2 events, got %d", handler.count())
+	}
+
+	if strings.Count(logs.String(), "handler panicked: boom") != 2 {
+		t.Errorf("Expected both panics to be logged, got %q", logs.String())
+	}
+}
+
+func TestAsyncEventBus_Lifecycle(t *testing.T) {
+	if _, err := NewAsyncEventBus(0, 1, nil); err == nil {
+		t.Error("Expected error for zero workers, got nil")
+	}
+
+	bus, _ := NewAsyncEventBus(1, 1, nil)
+	if err := bus.Publish(newTestEvent("TaskStarted", "task-1")); err == nil {
+		t.Error("Expected error publishing before start, got nil")
+	}
+
+	bus.Start(context.Background())
+	if err := bus.Start(context.Background()); err == nil {
+		t.Error("Expected error starting twice, got nil")
+	}
+	bus.Shutdown(context.Background())
+}
+
+func newStartedAsyncBus(t *testing.T, workers, queueSize int, logger *log.Logger) *AsyncEventBus {
+	t.Helper()
+
+	bus, err := NewAsyncEventBus(workers, queueSize, logger)
+	if err != nil {
+		t.Fatalf("Failed to create event bus: %v", err)
+	}
+
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start event bus: %v", err)
+	}
+
+	return bus
+}
+
+type slowHandler struct {
+	mu    sync.Mutex
+	delay time.Duration
+	count int
+}
+
+func (h *slowHandler) Handle(event domainevents.DomainEvent) error {
+	time.Sleep(h.delay)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.count++
+	return nil
+}
+
+func (h *slowHandler) CanHandle(eventType string) bool { return true }
+
+func (h *slowHandler) handled() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count
+}
+
+// waitFor ждет выполнения условия, например пока воркер заберет событие из очереди
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("Condition was not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+type blockingHandler struct {
+	release chan struct{}
+}
+
+func (h *blockingHandler) Handle(event domainevents.DomainEvent) error {
+	<-h.release
+	return nil
+}
+
+func (h *blockingHandler) CanHandle(eventType string) bool { return true }
+
+type panickingHandler struct{}
+
+func (h *panickingHandler) Handle(event domainevents.DomainEvent) error {
+	panic("boom")
+}
+
+func (h *panickingHandler) CanHandle(eventType string) bool { return true }
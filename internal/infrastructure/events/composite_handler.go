@@ -0,0 +1,77 @@
+package events
+
+import (
+	domainevents "daily-tracker/internal/domain/events"
+	"daily-tracker/pkg/errors"
+	"sync"
+)
+
+// Проверка на этапе компиляции, что тип реализует интерфейс
+var _ domainevents.EventHandler = (*CompositeEventHandler)(nil)
+
+// CompositeEventHandler один подписчик, который направляет событие
+// в функцию, зарегистрированную для его типа
+type CompositeEventHandler struct {
+	mu       sync.RWMutex
+	handlers map[string]func(domainevents.DomainEvent) error
+	strict   bool // Возвращать ли ошибку для незарегистрированных типов
+}
+
+// NewCompositeEventHandler создает обработчик без зарегистрированных типов
+// При strict событие незарегистрированного типа дает NotFoundError, иначе игнорируется
+func NewCompositeEventHandler(strict bool) *CompositeEventHandler {
+	return &CompositeEventHandler{
+		handlers: make(map[string]func(domainevents.DomainEvent) error),
+		strict:   strict,
+	}
+}
+
+// Register назначает функцию обработки для типа события
+func (h *CompositeEventHandler) Register(eventType string, handle func(domainevents.DomainEvent) error) error {
+	if eventType == "" {
+		return errors.NewDomainError("event type cannot be empty")
+	}
+
+	if handle == nil {
+		return errors.NewDomainError("event handler func cannot be nil")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, exists := h.handlers[eventType]; exists {
+		return errors.NewDomainError("event type already registered: " + eventType)
+	}
+
+	h.handlers[eventType] = handle
+	return nil
+}
+
+// Handle вызывает функцию, зарегистрированную для типа события
+func (h *CompositeEventHandler) Handle(event domainevents.DomainEvent) error {
+	if event == nil {
+		return errors.NewDomainError("event cannot be nil")
+	}
+
+	h.mu.RLock()
+	handle, ok := h.handlers[event.EventType()]
+	h.mu.RUnlock()
+
+	if !ok {
+		if h.strict {
+			return errors.NewNotFoundError("event handler", event.EventType())
+		}
+		return nil
+	}
+
+	return handle(event)
+}
+
+// CanHandle проверяет, зарегистрирована ли функция для типа события
+func (h *CompositeEventHandler) CanHandle(eventType string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	_, ok := h.handlers[eventType]
+	return ok
+}
@@ -0,0 +1,100 @@
+package events
+
+import (
+	domainevents "daily-tracker/internal/domain/events"
+	"daily-tracker/pkg/errors"
+	stderrors "errors"
+	"testing"
+)
+
+func TestCompositeEventHandler_RoutesByType(t *testing.T) {
+	handler := NewCompositeEventHandler(false)
+
+	var started, completed []string
+	handler.Register("TaskStarted", func(event domainevents.DomainEvent) error {
+		started = append(started, event.AggregateID())
+		return nil
+	})
+	handler.Register("TaskCompleted", func(event domainevents.DomainEvent) error {
+		completed = append(completed, event.AggregateID())
+		return nil
+	})
+
+	// Один подписчик на оба типа
+	bus := NewInMemoryEventBus()
+	bus.Subscribe("TaskStarted", handler)
+	bus.Subscribe("TaskCompleted", handler)
+
+	bus.Publish(newTestEvent("TaskStarted", "task-1"))
+	bus.Publish(newTestEvent("TaskCompleted", "task-1"))
+	bus.Publish(newTestEvent("TaskStarted", "task-2"))
+
+	if len(started) != 2 || started[0] != "task-1" || started[1] != "task-2" {
+		t.Errorf("Expected TaskStarted for task-1 and task-2, got %v", started)
+	}
+
+	if len(completed) != 1 || completed[0] != "task-1" {
+		t.Errorf("Expected TaskCompleted for task-1, got %v", completed)
+	}
+
+	if !handler.CanHandle("TaskStarted") || handler.CanHandle("TaskPaused") {
+		t.Error("Expected CanHandle to reflect registered types")
+	}
+}
+
+func TestCompositeEventHandler_UnregisteredTypes(t *testing.T) {
+	tests := []struct {
+		name      string
+		strict    bool
+		expectErr bool
+	}{
+		{"lenient ignores", false, false},
+		{"strict rejects", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewCompositeEventHandler(tt.strict)
+			err := handler.Handle(newTestEvent("TaskPaused", "task-1"))
+
+			if tt.expectErr && !errors.IsNotFoundError(err) {
+				t.Errorf("Expected NotFoundError, got: %v", err)
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestCompositeEventHandler_Register(t *testing.T) {
+	handler := NewCompositeEventHandler(false)
+	handlerErr := stderrors.New("boom")
+	failing := func(domainevents.DomainEvent) error { return handlerErr }
+
+	if err := handler.Register("TaskStarted", failing); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if err := handler.Handle(newTestEvent("TaskStarted", "task-1")); !stderrors.Is(err, handlerErr) {
+		t.Errorf("Expected handler error to be returned, got: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		eventType string
+		handle    func(domainevents.DomainEvent) error
+	}{
+		{"empty type", "", failing},
+		{"nil func", "TaskCompleted", nil},
+		{"duplicate type", "TaskStarted", failing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := handler.Register(tt.eventType, tt.handle); !errors.IsDomainError(err) {
+				t.Errorf("Expected DomainError, got: %v", err)
+			}
+		})
+	}
+}
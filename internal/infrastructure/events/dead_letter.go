@@ -0,0 +1,45 @@
+package events
+
+import (
+	domainevents "daily-tracker/internal/domain/events"
+	"sync"
+)
+
+// DeadLetterSink принимает события, которые обработчик не смог обработать
+// за отведенное число попыток
+type DeadLetterSink interface {
+	Store(event domainevents.DomainEvent, handlerErr error)
+}
+
+// DeadLetter событие, ушедшее в dead-letter, и ошибка последней попытки
+type DeadLetter struct {
+	Event domainevents.DomainEvent
+	Err   error
+}
+
+// InMemoryDeadLetterSink хранит недоставленные события в памяти для просмотра
+type InMemoryDeadLetterSink struct {
+	mu      sync.Mutex
+	letters []DeadLetter
+}
+
+// NewInMemoryDeadLetterSink создает пустое хранилище недоставленных событий
+func NewInMemoryDeadLetterSink() *InMemoryDeadLetterSink {
+	return &InMemoryDeadLetterSink{}
+}
+
+// Store сохраняет недоставленное событие
+func (s *InMemoryDeadLetterSink) Store(event domainevents.DomainEvent, handlerErr error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.letters = append(s.letters, DeadLetter{Event: event, Err: handlerErr})
+}
+
+// List возвращает копию недоставленных событий в порядке поступления
+func (s *InMemoryDeadLetterSink) List() []DeadLetter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]DeadLetter(nil), s.letters...)
+}
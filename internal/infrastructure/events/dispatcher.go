@@ -0,0 +1,38 @@
+package events
+
+import (
+	"context"
+	"daily-tracker/internal/domain/entities"
+	domainevents "daily-tracker/internal/domain/events"
+	"daily-tracker/pkg/errors"
+)
+
+// DispatchEvents публикует накопленные события агрегата и очищает их
+// Каждое событие оборачивается в StorableEvent с ID агрегата и публикуется по одному.
+// EventID берется из агрегата, поэтому при повторной попытке уже опубликованные
+// события уходят с теми же ID и получатели могут отбросить дубликаты.
+// События очищаются, только если опубликованы все: при ошибке или отмене контекста
+// агрегат сохраняет весь список для повторной попытки (доставка "хотя бы один раз")
+func DispatchEvents(ctx context.Context, root entities.AggregateRoot, publisher domainevents.EventPublisher) error {
+	if root == nil {
+		return errors.NewDomainError("aggregate root cannot be nil")
+	}
+
+	if publisher == nil {
+		return errors.NewDomainError("publisher cannot be nil")
+	}
+
+	ids := root.DomainEventIDs()
+	for i, event := range root.DomainEvents() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := publisher.Publish(domainevents.ToStorableWithID(event, root.AggregateID(), ids[i])); err != nil {
+			return errors.NewDomainErrorWrap("failed to publish "+event.EventType(), err)
+		}
+	}
+
+	root.ClearDomainEvents()
+	return nil
+}
@@ -0,0 +1,150 @@
+package events
+
+import (
+	"context"
+	"daily-tracker/internal/domain/entities"
+	domainevents "daily-tracker/internal/domain/events"
+	"daily-tracker/internal/domain/valueobjects"
+	stderrors "errors"
+	"testing"
+	"time"
+)
+
+func TestDispatchEvents_PublishesAndClears(t *testing.T) {
+	task := newDispatchTask(t)
+	publisher := &failAfterPublisher{failAt: -1}
+
+	if err := DispatchEvents(context.Background(), task, publisher); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(publisher.published) != 2 {
+		t.Fatalf("Expected 2 published events, got %d", len(publisher.published))
+	}
+	for i, eventType := range []string{"TaskEntryCreated", "TaskStarted"} {
+		event := publisher.published[i]
+		if event.EventType() != eventType || event.AggregateID() != "task-1" {
+			t.Errorf("Expected %s for task-1, got %s for %s", eventType, event.EventType(), event.AggregateID())
+		}
+	}
+
+	if len(task.DomainEvents()) != 0 {
+		t.Errorf("Expected events to be cleared, got %d", len(task.DomainEvents()))
+	}
+}
+
+func TestDispatchEvents_PartialFailureKeepsEvents(t *testing.T) {
+	task := newDispatchTask(t)
+	publisher := &failAfterPublisher{failAt: 1}
+
+	err := DispatchEvents(context.Background(), task, publisher)
+	if !stderrors.Is(err, errBrokerUnavailable) {
+		t.Fatalf("Expected wrapped broker error, got %v", err)
+	}
+
+	if len(publisher.published) != 1 {
+		t.Errorf("Expected 1 event published before failure, got %d", len(publisher.published))
+	}
+
+	// Ни одно событие не очищено - все остаются для повторной попытки
+	if len(task.DomainEvents()) != 2 {
+		t.Errorf("Expected 2 events kept for retry, got %d", len(task.DomainEvents()))
+	}
+}
+
+func TestDispatchEvents_CancelledContextKeepsEvents(t *testing.T) {
+	task := newDispatchTask(t)
+	publisher := &failAfterPublisher{failAt: -1}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := DispatchEvents(ctx, task, publisher); !stderrors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	if len(publisher.published) != 0 || len(task.DomainEvents()) != 2 {
+		t.Errorf("Expected nothing published and 2 events kept, got %d and %d",
+			len(publisher.published), len(task.DomainEvents()))
+	}
+}
+
+func TestDispatchEvents_RetryKeepsEventIDs(t *testing.T) {
+	task := newDispatchTask(t)
+	publisher := &failAfterPublisher{failAt: 1}
+
+	if err := DispatchEvents(context.Background(), task, publisher); !stderrors.Is(err, errBrokerUnavailable) {
+		t.Fatalf("Expected wrapped broker error, got %v", err)
+	}
+
+	// Повторная попытка снова публикует первое событие - с тем же EventID
+	if err := DispatchEvents(context.Background(), task, publisher); err != nil {
+		t.Fatalf("Expected retry to succeed, got %v", err)
+	}
+
+	if len(publisher.published) != 3 {
+		t.Fatalf("Expected 3 published events (1 + retry of 2), got %d", len(publisher.published))
+	}
+
+	if publisher.published[0].EventID() != publisher.published[1].EventID() {
+		t.Errorf("Expected identical IDs across retries, got %s and %s",
+			publisher.published[0].EventID(), publisher.published[1].EventID())
+	}
+
+	if publisher.published[1].EventID() == publisher.published[2].EventID() {
+		t.Error("Expected distinct events to keep distinct IDs")
+	}
+
+	if deduplicated := domainevents.DeduplicateEvents(publisher.published); len(deduplicated) != 2 {
+		t.Errorf("Expected 2 events after deduplication, got %d", len(deduplicated))
+	}
+}
+
+func TestDispatchEvents_NilArguments(t *testing.T) {
+	if err := DispatchEvents(context.Background(), newDispatchTask(t), nil); err == nil {
+		t.Error("Expected error for nil publisher, got nil")
+	}
+
+	if err := DispatchEvents(context.Background(), nil, &failAfterPublisher{failAt: -1}); err == nil {
+		t.Error("Expected error for nil aggregate, got nil")
+	}
+}
+
+func newDispatchTask(t *testing.T) *entities.TaskEntry {
+	t.Helper()
+
+	task, err := entities.NewTaskEntry("task-1", time.Now(), 1, "Написать отчет", valueobjects.TaskCategoryWork, 5)
+	if err != nil {
+		t.Fatalf("Failed to create task entry: %v", err)
+	}
+	task.StartTask()
+	return task
+}
+
+// failAfterPublisher публикует события, пока не дойдет до вызова с номером failAt (с нуля)
+// Отрицательный failAt означает, что издатель никогда не падает
+type failAfterPublisher struct {
+	failAt    int
+	calls     int
+	published []domainevents.DomainEvent
+}
+
+func (p *failAfterPublisher) Publish(event domainevents.DomainEvent) error {
+	call := p.calls
+	p.calls++
+	if call == p.failAt {
+		return errBrokerUnavailable
+	}
+
+	p.published = append(p.published, event)
+	return nil
+}
+
+func (p *failAfterPublisher) PublishBatch(events []domainevents.DomainEvent) error {
+	for _, event := range events {
+		if err := p.Publish(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
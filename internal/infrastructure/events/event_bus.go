@@ -0,0 +1,144 @@
+package events
+
+import (
+	domainevents "daily-tracker/internal/domain/events"
+	"daily-tracker/pkg/errors"
+	stderrors "errors"
+	"fmt"
+	"sync"
+)
+
+// Проверка на этапе компиляции, что тип реализует интерфейс
+var _ domainevents.EventBus = (*InMemoryEventBus)(nil)
+
+// InMemoryEventBus синхронная шина событий в памяти процесса
+// Publish вызывает обработчики по очереди в горутине вызывающего
+type InMemoryEventBus struct {
+	mu          sync.RWMutex
+	handlers    map[string][]domainevents.EventHandler
+	deadLetters DeadLetterSink // Куда отправлять события, которые обработчик так и не принял
+	maxAttempts int            // Попыток доставки одному обработчику
+}
+
+// NewInMemoryEventBus создает шину без подписчиков
+// По умолчанию каждому обработчику делается одна попытка, dead-letter отключен
+func NewInMemoryEventBus() *InMemoryEventBus {
+	return &InMemoryEventBus{
+		handlers:    make(map[string][]domainevents.EventHandler),
+		maxAttempts: 1,
+	}
+}
+
+// SetDeadLetterSink включает повторную доставку: обработчику делается до maxAttempts попыток,
+// после чего событие и последняя ошибка передаются в sink. nil отключает dead-letter
+func (b *InMemoryEventBus) SetDeadLetterSink(sink DeadLetterSink, maxAttempts int) error {
+	if maxAttempts < 1 {
+		return errors.NewDomainError("max delivery attempts must be positive")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.deadLetters = sink
+	b.maxAttempts = maxAttempts
+	return nil
+}
+
+// Subscribe подписывает обработчик на тип события
+func (b *InMemoryEventBus) Subscribe(eventType string, handler domainevents.EventHandler) error {
+	if eventType == "" {
+		return errors.NewDomainError("event type cannot be empty")
+	}
+
+	if handler == nil {
+		return errors.NewDomainError("event handler cannot be nil")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+	return nil
+}
+
+// Unsubscribe отписывает обработчик от типа события
+// Обработчик ищется по идентичности, поэтому подписывать стоит указатели
+func (b *InMemoryEventBus) Unsubscribe(eventType string, handler domainevents.EventHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	handlers := b.handlers[eventType]
+	for i, subscribed := range handlers {
+		if subscribed != handler {
+			continue
+		}
+
+		// Новый срез, чтобы не портить снимок, который может обходить Publish
+		remaining := make([]domainevents.EventHandler, 0, len(handlers)-1)
+		remaining = append(remaining, handlers[:i]...)
+		remaining = append(remaining, handlers[i+1:]...)
+
+		if len(remaining) == 0 {
+			delete(b.handlers, eventType)
+		} else {
+			b.handlers[eventType] = remaining
+		}
+		return nil
+	}
+
+	return errors.NewNotFoundError("event handler", eventType)
+}
+
+// Publish передает событие всем подписанным обработчикам, которые могут его обработать
+// Ошибка одного обработчика не мешает остальным; все ошибки возвращаются вместе,
+// даже если событие ушло в dead-letter
+func (b *InMemoryEventBus) Publish(event domainevents.DomainEvent) error {
+	if event == nil {
+		return errors.NewDomainError("event cannot be nil")
+	}
+
+	eventType := event.EventType()
+
+	b.mu.RLock()
+	sink, maxAttempts := b.deadLetters, b.maxAttempts
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range b.handlersFor(eventType) {
+		if !handler.CanHandle(eventType) {
+			continue
+		}
+
+		err := deliver(handler, event, maxAttempts)
+		if err == nil {
+			continue
+		}
+
+		if sink != nil {
+			sink.Store(event, err)
+		}
+		errs = append(errs, fmt.Errorf("handle %s: %w", eventType, err))
+	}
+
+	return stderrors.Join(errs...)
+}
+
+// deliver вызывает обработчик до maxAttempts раз и возвращает последнюю ошибку
+func deliver(handler domainevents.EventHandler, event domainevents.DomainEvent, maxAttempts int) error {
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if err = handler.Handle(event); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// handlersFor возвращает снимок обработчиков типа события
+// Срез не меняется на месте (Unsubscribe создает новый), поэтому его можно обходить без блокировки
+func (b *InMemoryEventBus) handlersFor(eventType string) []domainevents.EventHandler {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.handlers[eventType]
+}
@@ -0,0 +1,188 @@
+package events
+
+import (
+	domainevents "daily-tracker/internal/domain/events"
+	"daily-tracker/pkg/errors"
+	stderrors "errors"
+	"sync"
+	"testing"
+)
+
+func TestInMemoryEventBus_SubscribeAndPublish(t *testing.T) {
+	bus := NewInMemoryEventBus()
+	started := &recordingHandler{}
+	completed := &recordingHandler{}
+
+	bus.Subscribe("TaskStarted", started)
+	bus.Subscribe("TaskCompleted", completed)
+
+	if err := bus.Publish(newTestEvent("TaskStarted", "task-1")); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if started.count() != 1 || completed.count() != 0 {
+		t.Errorf("Expected only TaskStarted handler to run, got %d and %d", started.count(), completed.count())
+	}
+}
+
+func TestInMemoryEventBus_SkipsHandlersThatCannotHandle(t *testing.T) {
+	bus := NewInMemoryEventBus()
+	handler := &recordingHandler{rejects: true}
+	bus.Subscribe("TaskStarted", handler)
+
+	bus.Publish(newTestEvent("TaskStarted", "task-1"))
+
+	if handler.count() != 0 {
+		t.Errorf("Expected handler to be skipped, got %d calls", handler.count())
+	}
+}
+
+func TestInMemoryEventBus_Unsubscribe(t *testing.T) {
+	bus := NewInMemoryEventBus()
+	first := &recordingHandler{}
+	second := &recordingHandler{}
+	bus.Subscribe("TaskStarted", first)
+	bus.Subscribe("TaskStarted", second)
+
+	if err := bus.Unsubscribe("TaskStarted", first); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	bus.Publish(newTestEvent("TaskStarted", "task-1"))
+
+	if first.count() != 0 || second.count() != 1 {
+		t.Errorf("Expected only remaining handler to run, got %d and %d", first.count(), second.count())
+	}
+
+	if err := bus.Unsubscribe("TaskStarted", first); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected NotFoundError for unknown handler, got %v", err)
+	}
+}
+
+func TestInMemoryEventBus_AggregatesHandlerErrors(t *testing.T) {
+	bus := NewInMemoryEventBus()
+	firstErr := stderrors.New("first failed")
+	secondErr := stderrors.New("second failed")
+	healthy := &recordingHandler{}
+
+	bus.Subscribe("TaskStarted", &recordingHandler{err: firstErr})
+	bus.Subscribe("TaskStarted", healthy)
+	bus.Subscribe("TaskStarted", &recordingHandler{err: secondErr})
+
+	err := bus.Publish(newTestEvent("TaskStarted", "task-1"))
+
+	if !stderrors.Is(err, firstErr) || !stderrors.Is(err, secondErr) {
+		t.Errorf("Expected both handler errors, got %v", err)
+	}
+
+	if healthy.count() != 1 {
+		t.Errorf("Expected failures not to stop other handlers, got %d calls", healthy.count())
+	}
+}
+
+func TestInMemoryEventBus_SubscribeValidation(t *testing.T) {
+	bus := NewInMemoryEventBus()
+
+	if err := bus.Subscribe("", &recordingHandler{}); err == nil {
+		t.Error("Expected error for empty event type, got nil")
+	}
+
+	if err := bus.Subscribe("TaskStarted", nil); err == nil {
+		t.Error("Expected error for nil handler, got nil")
+	}
+}
+
+// recordingHandler считает вызовы и возвращает заданную ошибку
+type recordingHandler struct {
+	mu      sync.Mutex
+	events  []domainevents.DomainEvent
+	err     error
+	rejects bool
+}
+
+func (h *recordingHandler) Handle(event domainevents.DomainEvent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event)
+	return h.err
+}
+
+func (h *recordingHandler) CanHandle(eventType string) bool {
+	return !h.rejects
+}
+
+func (h *recordingHandler) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.events)
+}
+
+func newTestEvent(eventType, aggregateID string) domainevents.DomainEvent {
+	return domainevents.NewBaseEvent(eventType, aggregateID)
+}
+
+func TestInMemoryEventBus_DeadLetter(t *testing.T) {
+	bus := NewInMemoryEventBus()
+	sink := NewInMemoryDeadLetterSink()
+	if err := bus.SetDeadLetterSink(sink, 3); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	handlerErr := stderrors.New("always fails")
+	failing := &recordingHandler{err: handlerErr}
+	healthy := &recordingHandler{}
+	bus.Subscribe("TaskStarted", failing)
+	bus.Subscribe("TaskStarted", healthy)
+
+	event := newTestEvent("TaskStarted", "task-1")
+	if err := bus.Publish(event); !stderrors.Is(err, handlerErr) {
+		t.Errorf("Expected handler error to be reported, got %v", err)
+	}
+
+	if failing.count() != 3 || healthy.count() != 1 {
+		t.Errorf("Expected 3 attempts for failing handler and 1 for healthy, got %d and %d",
+			failing.count(), healthy.count())
+	}
+
+	letters := sink.List()
+	if len(letters) != 1 {
+		t.Fatalf("Expected event in dead-letter exactly once, got %d", len(letters))
+	}
+
+	if letters[0].Event.EventID() != event.EventID() || !stderrors.Is(letters[0].Err, handlerErr) {
+		t.Errorf("Expected dead letter for %s with handler error, got %+v", event.EventID(), letters[0])
+	}
+}
+
+func TestInMemoryEventBus_DeadLetterSkipsRecoveredHandler(t *testing.T) {
+	bus := NewInMemoryEventBus()
+	sink := NewInMemoryDeadLetterSink()
+	bus.SetDeadLetterSink(sink, 3)
+	bus.Subscribe("TaskStarted", &flakyHandler{failuresLeft: 2})
+
+	if err := bus.Publish(newTestEvent("TaskStarted", "task-1")); err != nil {
+		t.Errorf("Expected handler to succeed on retry, got %v", err)
+	}
+
+	if len(sink.List()) != 0 {
+		t.Errorf("Expected no dead letters, got %d", len(sink.List()))
+	}
+
+	if err := bus.SetDeadLetterSink(sink, 0); err == nil {
+		t.Error("Expected error for zero attempts, got nil")
+	}
+}
+
+type flakyHandler struct {
+	failuresLeft int
+}
+
+func (h *flakyHandler) Handle(event domainevents.DomainEvent) error {
+	if h.failuresLeft > 0 {
+		h.failuresLeft--
+		return stderrors.New("temporary failure")
+	}
+	return nil
+}
+
+func (h *flakyHandler) CanHandle(eventType string) bool { return true }
@@ -0,0 +1,144 @@
+package events
+
+import (
+	domainevents "daily-tracker/internal/domain/events"
+	"daily-tracker/pkg/errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Проверка на этапе компиляции, что тип реализует интерфейс
+var _ domainevents.EventStore = (*InMemoryEventStore)(nil)
+var _ domainevents.SnapshotStore = (*InMemoryEventStore)(nil)
+
+// InMemoryEventStore хранит события в памяти в порядке сохранения
+// Подходит для тестов и экспериментов с event sourcing
+type InMemoryEventStore struct {
+	mu          sync.RWMutex
+	events      []domainevents.DomainEvent            // Все события в порядке сохранения
+	byAggregate map[string][]domainevents.DomainEvent // События каждого агрегата в порядке сохранения
+	snapshots   map[string]snapshot                   // Последний снимок каждого агрегата
+}
+
+// snapshot сохраненное состояние агрегата и количество учтенных в нем событий
+type snapshot struct {
+	version int
+	state   []byte
+}
+
+// NewInMemoryEventStore создает пустое хранилище событий
+func NewInMemoryEventStore() *InMemoryEventStore {
+	return &InMemoryEventStore{
+		byAggregate: make(map[string][]domainevents.DomainEvent),
+		snapshots:   make(map[string]snapshot),
+	}
+}
+
+// SaveEvent добавляет событие в конец потока его агрегата
+func (s *InMemoryEventStore) SaveEvent(event domainevents.DomainEvent) error {
+	if event == nil {
+		return errors.NewDomainError("event cannot be nil")
+	}
+
+	if event.AggregateID() == "" {
+		return errors.NewDomainError("event aggregate id cannot be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, event)
+	s.byAggregate[event.AggregateID()] = append(s.byAggregate[event.AggregateID()], event)
+	return nil
+}
+
+// GetEvents возвращает копию потока событий агрегата в порядке сохранения
+// Для неизвестного агрегата возвращает пустой список
+func (s *InMemoryEventStore) GetEvents(aggregateID string) ([]domainevents.DomainEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stream := s.byAggregate[aggregateID]
+	result := make([]domainevents.DomainEvent, len(stream))
+	copy(result, stream)
+	return result, nil
+}
+
+// GetEventsByType возвращает до limit событий типа, начиная с самых свежих
+// limit <= 0 означает без ограничения
+func (s *InMemoryEventStore) GetEventsByType(eventType string, limit int) ([]domainevents.DomainEvent, error) {
+	return s.GetEventsByTypeSince(eventType, time.Time{}, limit)
+}
+
+// GetEventsByTypeSince возвращает до limit событий типа, возникших не раньше since,
+// по убыванию OccurredOn; при равном времени первым идет сохраненное позже
+func (s *InMemoryEventStore) GetEventsByTypeSince(eventType string, since time.Time, limit int) ([]domainevents.DomainEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]domainevents.DomainEvent, 0)
+	for i := len(s.events) - 1; i >= 0; i-- {
+		event := s.events[i]
+		if event.EventType() != eventType || event.OccurredOn().Before(since) {
+			continue
+		}
+		result = append(result, event)
+	}
+
+	// Время события может не совпадать с порядком сохранения (например, при импорте)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].OccurredOn().After(result[j].OccurredOn())
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+// SaveSnapshot сохраняет копию снимка агрегата
+// Снимок не может опережать поток событий или быть старше уже сохраненного
+func (s *InMemoryEventStore) SaveSnapshot(aggregateID string, version int, state []byte) error {
+	if aggregateID == "" {
+		return errors.NewDomainError("snapshot aggregate id cannot be empty")
+	}
+
+	if version < 0 {
+		return errors.NewDomainError("snapshot version cannot be negative")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if streamLength := len(s.byAggregate[aggregateID]); version > streamLength {
+		return errors.NewDomainError(fmt.Sprintf(
+			"snapshot version %d is ahead of event stream with %d events", version, streamLength))
+	}
+
+	if existing, ok := s.snapshots[aggregateID]; ok && version < existing.version {
+		return errors.NewDomainError(fmt.Sprintf(
+			"snapshot version %d is older than stored version %d", version, existing.version))
+	}
+
+	s.snapshots[aggregateID] = snapshot{
+		version: version,
+		state:   append([]byte(nil), state...),
+	}
+	return nil
+}
+
+// LoadSnapshot возвращает копию последнего снимка агрегата
+func (s *InMemoryEventStore) LoadSnapshot(aggregateID string) ([]byte, int, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, ok := s.snapshots[aggregateID]
+	if !ok {
+		return nil, 0, false
+	}
+
+	return append([]byte(nil), stored.state...), stored.version, true
+}
@@ -0,0 +1,263 @@
+package events
+
+import (
+	domainevents "daily-tracker/internal/domain/events"
+	"daily-tracker/pkg/errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInMemoryEventStore_PerAggregateOrdering(t *testing.T) {
+	store := NewInMemoryEventStore()
+
+	// События двух агрегатов сохраняются вперемешку
+	saved := []domainevents.DomainEvent{
+		newTestEvent("TaskCreated", "task-1"),
+		newTestEvent("TaskCreated", "task-2"),
+		newTestEvent("TaskStarted", "task-1"),
+		newTestEvent("TaskStarted", "task-2"),
+		newTestEvent("TaskCompleted", "task-1"),
+	}
+	for _, event := range saved {
+		if err := store.SaveEvent(event); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+
+	events, err := store.GetEvents("task-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := []domainevents.DomainEvent{saved[0], saved[2], saved[4]}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events for task-1, got %d", len(expected), len(events))
+	}
+	for i := range expected {
+		if events[i].EventID() != expected[i].EventID() {
+			t.Errorf("Event %d: expected %s, got %s", i, expected[i].EventType(), events[i].EventType())
+		}
+	}
+
+	// Возвращается копия: изменение результата не затрагивает хранилище
+	events[0] = nil
+	again, _ := store.GetEvents("task-1")
+	if again[0] == nil {
+		t.Error("Expected GetEvents to return a defensive copy")
+	}
+
+	unknown, err := store.GetEvents("task-3")
+	if err != nil || len(unknown) != 0 {
+		t.Errorf("Expected no events for unknown aggregate, got %d (err: %v)", len(unknown), err)
+	}
+}
+
+func TestInMemoryEventStore_GetEventsByType(t *testing.T) {
+	store := NewInMemoryEventStore()
+	base := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+
+	// Порядок сохранения намеренно не совпадает с временем возникновения
+	store.SaveEvent(newTestEventAt("TaskStarted", "task-2", base.Add(2*time.Hour)))
+	store.SaveEvent(newTestEventAt("TaskStarted", "task-1", base))
+	store.SaveEvent(newTestEventAt("TaskCreated", "task-4", base.Add(5*time.Hour)))
+	store.SaveEvent(newTestEventAt("TaskStarted", "task-4", base.Add(3*time.Hour)))
+	store.SaveEvent(newTestEventAt("TaskStarted", "task-3", base.Add(time.Hour)))
+
+	tests := []struct {
+		name       string
+		limit      int
+		aggregates []string
+	}{
+		{"limited", 2, []string{"task-4", "task-2"}},
+		{"unlimited", 0, []string{"task-4", "task-2", "task-3", "task-1"}},
+		{"limit above count", 10, []string{"task-4", "task-2", "task-3", "task-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := store.GetEventsByType("TaskStarted", tt.limit)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			assertEventAggregates(t, events, "TaskStarted", tt.aggregates)
+		})
+	}
+}
+
+func TestInMemoryEventStore_GetEventsByTypeSince(t *testing.T) {
+	store := NewInMemoryEventStore()
+	base := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+
+	store.SaveEvent(newTestEventAt("TaskStarted", "task-1", base))
+	store.SaveEvent(newTestEventAt("TaskStarted", "task-2", base.Add(time.Hour)))
+	store.SaveEvent(newTestEventAt("TaskStarted", "task-3", base.Add(2*time.Hour)))
+	store.SaveEvent(newTestEventAt("TaskCreated", "task-4", base.Add(3*time.Hour)))
+	store.SaveEvent(newTestEventAt("TaskStarted", "task-5", base.Add(4*time.Hour)))
+
+	tests := []struct {
+		name       string
+		since      time.Time
+		limit      int
+		aggregates []string
+	}{
+		{"cutoff is inclusive", base.Add(time.Hour), 0, []string{"task-5", "task-3", "task-2"}},
+		{"cutoff between events", base.Add(90 * time.Minute), 0, []string{"task-5", "task-3"}},
+		{"cutoff with limit", base, 2, []string{"task-5", "task-3"}},
+		{"cutoff after all events", base.Add(5 * time.Hour), 0, []string{}},
+		{"zero cutoff", time.Time{}, 0, []string{"task-5", "task-3", "task-2", "task-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := store.GetEventsByTypeSince("TaskStarted", tt.since, tt.limit)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			assertEventAggregates(t, events, "TaskStarted", tt.aggregates)
+		})
+	}
+}
+
+func TestInMemoryEventStore_SaveEventValidation(t *testing.T) {
+	store := NewInMemoryEventStore()
+
+	if err := store.SaveEvent(nil); err == nil {
+		t.Error("Expected error for nil event, got nil")
+	}
+
+	if err := store.SaveEvent(newTestEvent("TaskStarted", "")); err == nil {
+		t.Error("Expected error for empty aggregate id, got nil")
+	}
+}
+
+func TestInMemoryEventStore_Snapshots(t *testing.T) {
+	store := NewInMemoryEventStore()
+	store.SaveEvent(newTestEvent("TaskCreated", "task-1"))
+	store.SaveEvent(newTestEvent("TaskStarted", "task-1"))
+
+	if _, _, ok := store.LoadSnapshot("task-1"); ok {
+		t.Fatal("Expected no snapshot before save")
+	}
+
+	state := []byte(`{"started":true}`)
+	if err := store.SaveSnapshot("task-1", 2, state); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Хранилище держит копию: изменение исходного среза не затрагивает снимок
+	state[0] = 'x'
+	loaded, version, ok := store.LoadSnapshot("task-1")
+	if !ok || version != 2 || string(loaded) != `{"started":true}` {
+		t.Errorf("Expected snapshot v2 with original state, got v%d %q (ok=%v)", version, loaded, ok)
+	}
+
+	tests := []struct {
+		name        string
+		aggregateID string
+		version     int
+	}{
+		{"empty aggregate id", "", 0},
+		{"negative version", "task-1", -1},
+		{"ahead of stream", "task-1", 3},
+		{"older than stored", "task-1", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := store.SaveSnapshot(tt.aggregateID, tt.version, []byte("{}"))
+			if !errors.IsDomainError(err) {
+				t.Errorf("Expected DomainError, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestReplayFromSnapshot_StartsAfterSnapshotVersion(t *testing.T) {
+	store := NewInMemoryEventStore()
+	for _, eventType := range []string{"TaskCreated", "TaskStarted", "PomodoroCompleted", "PomodoroCompleted", "TaskCompleted"} {
+		store.SaveEvent(newTestEvent(eventType, "task-1"))
+	}
+	store.SaveEvent(newTestEvent("TaskCreated", "task-2"))
+
+	// Снимок учитывает первые три события потока
+	store.SaveSnapshot("task-1", 3, []byte("TaskCreated,TaskStarted,PomodoroCompleted"))
+
+	var restored string
+	var applied []string
+	version, err := domainevents.ReplayFromSnapshot(store, store, "task-1",
+		func(state []byte) error {
+			restored = string(state)
+			return nil
+		},
+		func(event domainevents.DomainEvent) error {
+			applied = append(applied, event.EventType())
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if restored != "TaskCreated,TaskStarted,PomodoroCompleted" {
+		t.Errorf("Expected snapshot state to be restored, got %q", restored)
+	}
+
+	if strings.Join(applied, ",") != "PomodoroCompleted,TaskCompleted" {
+		t.Errorf("Expected only events after the snapshot, got %v", applied)
+	}
+
+	if version != 5 {
+		t.Errorf("Expected final version 5, got %d", version)
+	}
+}
+
+func TestReplayFromSnapshot_WithoutSnapshotReplaysWholeStream(t *testing.T) {
+	store := NewInMemoryEventStore()
+	store.SaveEvent(newTestEvent("TaskCreated", "task-1"))
+	store.SaveEvent(newTestEvent("TaskStarted", "task-1"))
+
+	restoreCalled := false
+	applied := 0
+	version, err := domainevents.ReplayFromSnapshot(store, store, "task-1",
+		func(state []byte) error {
+			restoreCalled = true
+			return nil
+		},
+		func(event domainevents.DomainEvent) error {
+			applied++
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if restoreCalled || applied != 2 || version != 2 {
+		t.Errorf("Expected full replay of 2 events without restore, got restore=%v applied=%d version=%d",
+			restoreCalled, applied, version)
+	}
+}
+
+func newTestEventAt(eventType, aggregateID string, occurredAt time.Time) domainevents.DomainEvent {
+	event := domainevents.NewBaseEvent(eventType, aggregateID)
+	event.OccurredAt = occurredAt
+	return event
+}
+
+func assertEventAggregates(t *testing.T, events []domainevents.DomainEvent, eventType string, aggregates []string) {
+	t.Helper()
+
+	if len(events) != len(aggregates) {
+		t.Fatalf("Expected %d events, got %d", len(aggregates), len(events))
+	}
+
+	for i, aggregateID := range aggregates {
+		if events[i].AggregateID() != aggregateID || events[i].EventType() != eventType {
+			t.Errorf("Event %d: expected %s for %s, got %s for %s",
+				i, eventType, aggregateID, events[i].EventType(), events[i].AggregateID())
+		}
+	}
+}
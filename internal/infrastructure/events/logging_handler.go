@@ -0,0 +1,45 @@
+package events
+
+import (
+	domainevents "daily-tracker/internal/domain/events"
+	"daily-tracker/pkg/errors"
+	"log/slog"
+)
+
+// Проверка на этапе компиляции, что тип реализует интерфейс
+var _ domainevents.EventHandler = (*LoggingEventHandler)(nil)
+
+// LoggingEventHandler пишет каждое событие в структурированный лог
+// Принимает события любых типов, поэтому его можно подписать на все интересующие типы
+// для отладки, не связывая сущности с логгером
+type LoggingEventHandler struct {
+	logger *slog.Logger
+}
+
+// NewLoggingEventHandler создает обработчик поверх logger (nil - логгер slog по умолчанию)
+func NewLoggingEventHandler(logger *slog.Logger) *LoggingEventHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &LoggingEventHandler{logger: logger}
+}
+
+// Handle записывает тип, время и агрегат события
+func (h *LoggingEventHandler) Handle(event domainevents.DomainEvent) error {
+	if event == nil {
+		return errors.NewDomainError("event cannot be nil")
+	}
+
+	h.logger.Info("domain event",
+		slog.String("event_type", event.EventType()),
+		slog.Time("occurred_on", event.OccurredOn()),
+		slog.String("aggregate_id", event.AggregateID()),
+	)
+	return nil
+}
+
+// CanHandle всегда true: логируются события любых типов
+func (h *LoggingEventHandler) CanHandle(eventType string) bool {
+	return true
+}
@@ -0,0 +1,70 @@
+package events
+
+import (
+	"bytes"
+	"daily-tracker/internal/domain/entities"
+	domainevents "daily-tracker/internal/domain/events"
+	"daily-tracker/internal/domain/valueobjects"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestLoggingEventHandler_LogsPublishedTaskStarted(t *testing.T) {
+	startedAt := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+	restore := entities.SetClock(entities.NewFixedClock(startedAt))
+	defer restore()
+
+	stressBefore, _ := valueobjects.NewStressLevel(6)
+	task, err := entities.NewTaskEntry("task-1", startedAt, 1, "Написать отчет", valueobjects.TaskCategoryWork, stressBefore)
+	if err != nil {
+		t.Fatalf("Failed to create task entry: %v", err)
+	}
+	task.StartTask()
+
+	// Логирующий обработчик как подписчик по умолчанию на события задачи
+	var output bytes.Buffer
+	handler := NewLoggingEventHandler(slog.New(slog.NewJSONHandler(&output, nil)))
+	bus := NewInMemoryEventBus()
+	for _, eventType := range []string{"TaskEntryCreated", "TaskStarted"} {
+		bus.Subscribe(eventType, handler)
+	}
+
+	started := task.DomainEvents()[1]
+	if err := bus.Publish(domainevents.ToStorable(started, string(task.ID()))); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var record struct {
+		Msg         string    `json:"msg"`
+		EventType   string    `json:"event_type"`
+		OccurredOn  time.Time `json:"occurred_on"`
+		AggregateID string    `json:"aggregate_id"`
+	}
+	if err := json.Unmarshal(output.Bytes(), &record); err != nil {
+		t.Fatalf("Expected a single JSON log record, got %q: %v", output.String(), err)
+	}
+
+	if record.Msg != "domain event" || record.EventType != "TaskStarted" || record.AggregateID != "task-1" {
+		t.Errorf("Expected TaskStarted for task-1, got %+v", record)
+	}
+
+	if !record.OccurredOn.Equal(startedAt) {
+		t.Errorf("Expected occurred_on %v, got %v", startedAt, record.OccurredOn)
+	}
+}
+
+func TestLoggingEventHandler_CanHandleAnyType(t *testing.T) {
+	handler := NewLoggingEventHandler(nil)
+
+	for _, eventType := range []string{"TaskStarted", "SleepEntryCreated", ""} {
+		if !handler.CanHandle(eventType) {
+			t.Errorf("Expected CanHandle(%q) to be true", eventType)
+		}
+	}
+
+	if err := handler.Handle(nil); err == nil {
+		t.Error("Expected error for nil event, got nil")
+	}
+}
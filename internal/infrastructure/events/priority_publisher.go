@@ -0,0 +1,51 @@
+package events
+
+import (
+	domainevents "daily-tracker/internal/domain/events"
+	"daily-tracker/pkg/errors"
+	"sort"
+)
+
+// Проверка на этапе компиляции, что тип реализует интерфейс
+var _ domainevents.EventPublisher = (*PriorityPublisher)(nil)
+
+// PriorityPublisher декоратор, публикующий пакет в порядке убывания приоритета
+// События без Publishable считаются PriorityNormal; внутри одного приоритета
+// исходный порядок сохраняется
+type PriorityPublisher struct {
+	inner domainevents.EventPublisher
+}
+
+// NewPriorityPublisher оборачивает издателя сортировкой по приоритету
+func NewPriorityPublisher(inner domainevents.EventPublisher) (*PriorityPublisher, error) {
+	if inner == nil {
+		return nil, errors.NewDomainError("inner publisher cannot be nil")
+	}
+
+	return &PriorityPublisher{inner: inner}, nil
+}
+
+// Publish передает одиночное событие без изменений
+func (p *PriorityPublisher) Publish(event domainevents.DomainEvent) error {
+	return p.inner.Publish(event)
+}
+
+// PublishBatch сортирует копию пакета по приоритету и передает ее внутреннему издателю
+func (p *PriorityPublisher) PublishBatch(events []domainevents.DomainEvent) error {
+	ordered := make([]domainevents.DomainEvent, len(events))
+	copy(ordered, events)
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return priorityOf(ordered[i]) > priorityOf(ordered[j])
+	})
+
+	return p.inner.PublishBatch(ordered)
+}
+
+// priorityOf возвращает приоритет события, PriorityNormal для событий без Publishable
+func priorityOf(event domainevents.DomainEvent) domainevents.EventPriority {
+	if publishable, ok := event.(domainevents.Publishable); ok {
+		return publishable.Priority()
+	}
+	return domainevents.PriorityNormal
+}
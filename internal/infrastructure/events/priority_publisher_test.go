@@ -0,0 +1,64 @@
+package events
+
+import (
+	domainevents "daily-tracker/internal/domain/events"
+	"testing"
+)
+
+func TestPriorityPublisher_OrdersBatchByPriority(t *testing.T) {
+	inner := &flakyPublisher{}
+	publisher, err := NewPriorityPublisher(inner)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	batch := []domainevents.DomainEvent{
+		newPrioritizedEvent("low-1", domainevents.PriorityLow),
+		newTestEvent("TaskStarted", "plain-1"),
+		newPrioritizedEvent("critical-1", domainevents.PriorityCritical),
+		newPrioritizedEvent("low-2", domainevents.PriorityLow),
+		newTestEvent("TaskStarted", "plain-2"),
+		newPrioritizedEvent("critical-2", domainevents.PriorityCritical),
+	}
+
+	if err := publisher.PublishBatch(batch); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := []string{"critical-1", "critical-2", "plain-1", "plain-2", "low-1", "low-2"}
+	if len(inner.published) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(inner.published))
+	}
+
+	for i, aggregateID := range expected {
+		if inner.published[i].AggregateID() != aggregateID {
+			t.Errorf("Position %d: expected %s, got %s", i, aggregateID, inner.published[i].AggregateID())
+		}
+	}
+
+	// Исходный пакет вызывающего не переупорядочивается
+	if batch[0].AggregateID() != "low-1" {
+		t.Error("Expected caller's batch to stay in original order")
+	}
+}
+
+// prioritizedEvent событие с явным приоритетом
+type prioritizedEvent struct {
+	domainevents.BaseEvent
+	priority domainevents.EventPriority
+}
+
+func (e *prioritizedEvent) RoutingKey() string {
+	return e.EventType()
+}
+
+func (e *prioritizedEvent) Priority() domainevents.EventPriority {
+	return e.priority
+}
+
+func newPrioritizedEvent(aggregateID string, priority domainevents.EventPriority) *prioritizedEvent {
+	return &prioritizedEvent{
+		BaseEvent: domainevents.NewBaseEvent("TaskStarted", aggregateID),
+		priority:  priority,
+	}
+}
@@ -0,0 +1,127 @@
+package events
+
+import (
+	"context"
+	domainevents "daily-tracker/internal/domain/events"
+	"daily-tracker/pkg/errors"
+	stderrors "errors"
+	"fmt"
+	"time"
+)
+
+// Проверка на этапе компиляции, что тип реализует интерфейс
+var _ domainevents.EventPublisher = (*RetryingPublisher)(nil)
+
+// RetryPolicy параметры повторных попыток публикации
+type RetryPolicy struct {
+	MaxAttempts    int           // Всего попыток, включая первую
+	InitialBackoff time.Duration // Пауза перед второй попыткой, дальше удваивается
+	MaxBackoff     time.Duration // Верхняя граница паузы (0 - без ограничения)
+}
+
+// RetryingPublisher декоратор, повторяющий публикацию при временных сбоях
+// с экспоненциальной паузой между попытками
+type RetryingPublisher struct {
+	inner  domainevents.EventPublisher
+	policy RetryPolicy
+}
+
+// NewRetryingPublisher оборачивает издателя политикой повторов
+func NewRetryingPublisher(inner domainevents.EventPublisher, policy RetryPolicy) (*RetryingPublisher, error) {
+	if inner == nil {
+		return nil, errors.NewDomainError("inner publisher cannot be nil")
+	}
+
+	if policy.MaxAttempts < 1 {
+		return nil, errors.NewDomainError("max attempts must be positive")
+	}
+
+	if policy.InitialBackoff < 0 || policy.MaxBackoff < 0 {
+		return nil, errors.NewDomainError("backoff cannot be negative")
+	}
+
+	return &RetryingPublisher{inner: inner, policy: policy}, nil
+}
+
+// Publish публикует событие с повторами без ограничения по контексту
+func (p *RetryingPublisher) Publish(event domainevents.DomainEvent) error {
+	return p.PublishContext(context.Background(), event)
+}
+
+// PublishBatch публикует пакет с повторами без ограничения по контексту
+func (p *RetryingPublisher) PublishBatch(events []domainevents.DomainEvent) error {
+	return p.PublishBatchContext(context.Background(), events)
+}
+
+// PublishContext публикует событие, повторяя при ошибке, пока не исчерпаны попытки
+// или не отменен ctx
+func (p *RetryingPublisher) PublishContext(ctx context.Context, event domainevents.DomainEvent) error {
+	return p.retry(ctx, func() error {
+		return p.inner.Publish(event)
+	})
+}
+
+// PublishBatchContext публикует пакет с повторами. Если издатель сообщает об ошибках
+// по отдельным событиям (BatchPublishError), повторяются только они, иначе весь пакет
+func (p *RetryingPublisher) PublishBatchContext(ctx context.Context, events []domainevents.DomainEvent) error {
+	pending := events
+
+	return p.retry(ctx, func() error {
+		err := p.inner.PublishBatch(pending)
+
+		var batchErr *domainevents.BatchPublishError
+		if stderrors.As(err, &batchErr) && len(batchErr.Failed) > 0 {
+			failed := make([]domainevents.DomainEvent, 0, len(batchErr.Failed))
+			for _, f := range batchErr.Failed {
+				failed = append(failed, f.Event)
+			}
+			pending = failed
+		}
+
+		return err
+	})
+}
+
+// retry выполняет attempt до успеха, исчерпания попыток или отмены ctx
+func (p *RetryingPublisher) retry(ctx context.Context, attempt func() error) error {
+	backoff := p.policy.InitialBackoff
+
+	var lastErr error
+	for n := 1; n <= p.policy.MaxAttempts; n++ {
+		if err := ctx.Err(); err != nil {
+			return p.cancelled(n-1, err, lastErr)
+		}
+
+		lastErr = attempt()
+		if lastErr == nil {
+			return nil
+		}
+
+		if n == p.policy.MaxAttempts {
+			break
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return p.cancelled(n, ctx.Err(), lastErr)
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if p.policy.MaxBackoff > 0 && backoff > p.policy.MaxBackoff {
+			backoff = p.policy.MaxBackoff
+		}
+	}
+
+	return fmt.Errorf("publish failed after %d attempts: %w", p.policy.MaxAttempts, lastErr)
+}
+
+// cancelled формирует ошибку отмены, сохраняя последнюю ошибку публикации
+func (p *RetryingPublisher) cancelled(attempts int, ctxErr, lastErr error) error {
+	if lastErr == nil {
+		return ctxErr
+	}
+	return fmt.Errorf("publish cancelled after %d attempts: %w", attempts, stderrors.Join(ctxErr, lastErr))
+}
@@ -0,0 +1,176 @@
+package events
+
+import (
+	"context"
+	domainevents "daily-tracker/internal/domain/events"
+	stderrors "errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var errBrokerUnavailable = stderrors.New("broker unavailable")
+
+func TestRetryingPublisher_SucceedsOnThirdAttempt(t *testing.T) {
+	inner := &flakyPublisher{failuresLeft: 2}
+	publisher := newRetryingPublisher(t, inner, 5)
+
+	if err := publisher.Publish(newTestEvent("TaskStarted", "task-1")); err != nil {
+		t.Fatalf("Expected success after retries, got: %v", err)
+	}
+
+	if inner.calls != 3 || len(inner.published) != 1 {
+		t.Errorf("Expected 3 calls and 1 published event, got %d and %d", inner.calls, len(inner.published))
+	}
+}
+
+func TestRetryingPublisher_ExhaustsAttempts(t *testing.T) {
+	inner := &flakyPublisher{failuresLeft: 10}
+	publisher := newRetryingPublisher(t, inner, 3)
+
+	err := publisher.Publish(newTestEvent("TaskStarted", "task-1"))
+
+	if !stderrors.Is(err, errBrokerUnavailable) || !strings.Contains(err.Error(), "after 3 attempts") {
+		t.Errorf("Expected wrapped last error with attempt count, got %v", err)
+	}
+
+	if inner.calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", inner.calls)
+	}
+}
+
+func TestRetryingPublisher_BatchRetriesWholeBatch(t *testing.T) {
+	inner := &flakyPublisher{failuresLeft: 2}
+	publisher := newRetryingPublisher(t, inner, 5)
+	batch := []domainevents.DomainEvent{newTestEvent("TaskStarted", "task-1"), newTestEvent("TaskStarted", "task-2")}
+
+	if err := publisher.PublishBatch(batch); err != nil {
+		t.Fatalf("Expected success after retries, got: %v", err)
+	}
+
+	// Без отчета по событиям весь пакет отправляется заново
+	if len(inner.batches) != 3 || len(inner.batches[2]) != 2 {
+		t.Errorf("Expected 3 full-batch attempts, got %v", inner.batches)
+	}
+}
+
+func TestRetryingPublisher_BatchRetriesOnlyFailedEvents(t *testing.T) {
+	first := newTestEvent("TaskStarted", "task-1")
+	second := newTestEvent("TaskStarted", "task-2")
+	third := newTestEvent("TaskStarted", "task-3")
+	inner := &flakyPublisher{failuresLeft: 2, perEvent: true, failing: map[string]bool{second.EventID(): true}}
+	publisher := newRetryingPublisher(t, inner, 5)
+
+	if err := publisher.PublishBatch([]domainevents.DomainEvent{first, second, third}); err != nil {
+		t.Fatalf("Expected success after retries, got: %v", err)
+	}
+
+	if len(inner.batches) != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", len(inner.batches))
+	}
+
+	for _, retried := range inner.batches[1:] {
+		if len(retried) != 1 || retried[0] != second {
+			t.Errorf("Expected only the failed event to be retried, got %d events", len(retried))
+		}
+	}
+
+	if len(inner.published) != 3 {
+		t.Errorf("Expected each event published once, got %d", len(inner.published))
+	}
+}
+
+func TestRetryingPublisher_ContextCancellation(t *testing.T) {
+	inner := &flakyPublisher{failuresLeft: 10}
+	publisher, _ := NewRetryingPublisher(inner, RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := publisher.PublishContext(ctx, newTestEvent("TaskStarted", "task-1"))
+
+	if !stderrors.Is(err, context.DeadlineExceeded) || !stderrors.Is(err, errBrokerUnavailable) {
+		t.Errorf("Expected cancellation with last publish error, got %v", err)
+	}
+
+	if inner.calls != 1 {
+		t.Errorf("Expected no retries after cancellation, got %d calls", inner.calls)
+	}
+}
+
+func newRetryingPublisher(t *testing.T, inner domainevents.EventPublisher, attempts int) *RetryingPublisher {
+	t.Helper()
+
+	publisher, err := NewRetryingPublisher(inner, RetryPolicy{
+		MaxAttempts:    attempts,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     2 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create publisher: %v", err)
+	}
+
+	return publisher
+}
+
+// flakyPublisher падает заданное число раз, затем публикует успешно
+// В режиме perEvent падают только события из failing, а ошибка сообщает о них отдельно
+type flakyPublisher struct {
+	mu           sync.Mutex
+	failuresLeft int
+	perEvent     bool
+	failing      map[string]bool
+	calls        int
+	batches      [][]domainevents.DomainEvent
+	published    []domainevents.DomainEvent
+}
+
+func (p *flakyPublisher) Publish(event domainevents.DomainEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls++
+	if p.failuresLeft > 0 {
+		p.failuresLeft--
+		return errBrokerUnavailable
+	}
+
+	p.published = append(p.published, event)
+	return nil
+}
+
+func (p *flakyPublisher) PublishBatch(events []domainevents.DomainEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls++
+	p.batches = append(p.batches, events)
+
+	fails := p.failuresLeft > 0
+	if fails {
+		p.failuresLeft--
+	}
+
+	if !p.perEvent {
+		if fails {
+			return errBrokerUnavailable
+		}
+		p.published = append(p.published, events...)
+		return nil
+	}
+
+	batchErr := &domainevents.BatchPublishError{}
+	for _, event := range events {
+		if fails && p.failing[event.EventID()] {
+			batchErr.Failed = append(batchErr.Failed, domainevents.FailedEvent{Event: event, Err: errBrokerUnavailable})
+			continue
+		}
+		p.published = append(p.published, event)
+	}
+
+	if len(batchErr.Failed) > 0 {
+		return batchErr
+	}
+	return nil
+}
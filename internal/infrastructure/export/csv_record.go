@@ -0,0 +1,161 @@
+package export
+
+import (
+	"daily-tracker/pkg/errors"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvRecord строка CSV с доступом к значениям по имени колонки
+// Ошибки разбора не прерывают чтение строки, а копятся в validation
+// под ключом "line N", чтобы сообщить обо всех проблемах файла разом
+type csvRecord struct {
+	line       int
+	columns    map[string]int
+	values     []string
+	validation *errors.ValidationErrors
+}
+
+// fail добавляет ошибку колонки для текущей строки
+func (r *csvRecord) fail(column string, err error) {
+	r.validation.Add(fmt.Sprintf("line %d", r.line), column+": "+err.Error())
+}
+
+// string возвращает значение колонки как есть
+func (r *csvRecord) string(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.values) {
+		r.fail(column, fmt.Errorf("missing value"))
+		return ""
+	}
+	return r.values[i]
+}
+
+func (r *csvRecord) int(column string) int {
+	value, err := strconv.Atoi(strings.TrimSpace(r.string(column)))
+	if err != nil {
+		r.fail(column, fmt.Errorf("must be an integer"))
+	}
+	return value
+}
+
+func (r *csvRecord) bool(column string) bool {
+	value, err := strconv.ParseBool(strings.TrimSpace(r.string(column)))
+	if err != nil {
+		r.fail(column, fmt.Errorf("must be true or false"))
+	}
+	return value
+}
+
+// minutes разбирает длительность, записанную в минутах
+func (r *csvRecord) minutes(column string) time.Duration {
+	value, err := strconv.ParseFloat(strings.TrimSpace(r.string(column)), 64)
+	if err != nil {
+		r.fail(column, fmt.Errorf("must be a number of minutes"))
+		return 0
+	}
+	if value < 0 {
+		r.fail(column, fmt.Errorf("cannot be negative"))
+		return 0
+	}
+	return minutesDuration(value)
+}
+
+// time разбирает обязательное время в RFC3339
+func (r *csvRecord) time(column string) time.Time {
+	return r.parseTime(column, r.string(column))
+}
+
+// optionalTime разбирает необязательное время; пустая ячейка дает nil
+func (r *csvRecord) optionalTime(column string) *time.Time {
+	raw := r.string(column)
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	value := r.parseTime(column, raw)
+	return &value
+}
+
+// intervals разбирает интервалы формата formatIntervals; пустая ячейка дает nil
+func (r *csvRecord) intervals(column string) (starts, ends []time.Time) {
+	raw := strings.TrimSpace(r.string(column))
+	if raw == "" {
+		return nil, nil
+	}
+
+	for _, part := range strings.Split(raw, ";") {
+		start, end, ok := strings.Cut(part, "/")
+		if !ok {
+			r.fail(column, fmt.Errorf("interval must be start/end"))
+			return nil, nil
+		}
+		starts = append(starts, r.parseTime(column, start))
+		ends = append(ends, r.parseTime(column, end))
+	}
+	return starts, ends
+}
+
+func (r *csvRecord) parseTime(column, raw string) time.Time {
+	value, err := time.Parse(time.RFC3339, strings.TrimSpace(raw))
+	if err != nil {
+		r.fail(column, fmt.Errorf("must be an RFC3339 time"))
+	}
+	return value
+}
+
+// check записывает ошибку конструктора value object, если она есть
+func (r *csvRecord) check(column string, err error) {
+	if err != nil {
+		r.fail(column, err)
+	}
+}
+
+// readCSVRecords читает CSV и вызывает handle для каждой строки данных
+// Строка заголовка пропускается, если ее первая ячейка совпадает с первой колонкой header;
+// иначе колонки берутся в порядке header
+func readCSVRecords(r io.Reader, header []string, validation *errors.ValidationErrors, handle func(*csvRecord)) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	columns := columnIndex(header)
+	first := true
+
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read csv: %w", err)
+		}
+
+		if first {
+			first = false
+			if len(values) > 0 && strings.TrimSpace(values[0]) == header[0] {
+				columns = columnIndex(values)
+				continue
+			}
+		}
+
+		line, _ := reader.FieldPos(0)
+		handle(&csvRecord{
+			line:       line,
+			columns:    columns,
+			values:     values,
+			validation: validation,
+		})
+	}
+}
+
+// columnIndex сопоставляет имена колонок с их позициями
+func columnIndex(header []string) map[string]int {
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.TrimSpace(column)] = i
+	}
+	return columns
+}
@@ -0,0 +1,39 @@
+package export
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// formatTime записывает время в RFC3339
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+// formatOptionalTime записывает необязательное время; nil дает пустую ячейку
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTime(*t)
+}
+
+// formatMinutes записывает длительность в минутах без потери дробной части
+func formatMinutes(d time.Duration) string {
+	return strconv.FormatFloat(d.Minutes(), 'f', -1, 64)
+}
+
+// minutesDuration переводит минуты обратно в длительность
+func minutesDuration(minutes float64) time.Duration {
+	return time.Duration(minutes * float64(time.Minute))
+}
+
+// formatIntervals записывает интервалы как "начало/конец" через точку с запятой
+func formatIntervals(starts, ends []time.Time) string {
+	parts := make([]string, len(starts))
+	for i := range starts {
+		parts[i] = formatTime(starts[i]) + "/" + formatTime(ends[i])
+	}
+	return strings.Join(parts, ";")
+}
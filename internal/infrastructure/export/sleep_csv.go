@@ -0,0 +1,162 @@
+package export
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"daily-tracker/pkg/errors"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// sleepCSVHeader порядок колонок CSV для записей сна
+// Дневной сон и дополнительные сегменты записываются интервалами "начало/конец" через ";"
+var sleepCSVHeader = []string{
+	"id",
+	"date",
+	"bedtime",
+	"wake_time",
+	"sleep_latency_min",
+	"night_awakenings",
+	"time_awake_during_night_min",
+	"total_sleep_hours",
+	"sleep_quality",
+	"daytime_sleepiness",
+	"caffeine_after_noon",
+	"screen_use_before_bed_min",
+	"evening_free_time_min",
+	"notes",
+	"naps",
+	"segments",
+}
+
+// ExportSleepCSV записывает записи сна в CSV: строка заголовка и по строке на запись
+func ExportSleepCSV(w io.Writer, entries []*entities.SleepEntry) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(sleepCSVHeader); err != nil {
+		return fmt.Errorf("write sleep header: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry == nil {
+			return errors.NewDomainError("sleep entry cannot be nil")
+		}
+
+		if err := writer.Write(sleepRecord(entry.State())); err != nil {
+			return fmt.Errorf("write sleep entry %s: %w", entry.ID(), err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("flush sleep csv: %w", err)
+	}
+	return nil
+}
+
+// ImportSleepCSV читает записи сна в формате ExportSleepCSV через ReconstructSleepEntry,
+// поэтому события не генерируются. Ошибки строк собираются в ValidationErrors по номеру строки
+func ImportSleepCSV(r io.Reader) ([]*entities.SleepEntry, error) {
+	validation := errors.NewValidationErrors()
+	entries := make([]*entities.SleepEntry, 0)
+
+	err := readCSVRecords(r, sleepCSVHeader, validation, func(record *csvRecord) {
+		errorsBefore := len(validation.Errors())
+		state := sleepState(record)
+		if len(validation.Errors()) > errorsBefore {
+			return
+		}
+
+		entry, err := entities.ReconstructSleepEntry(state)
+		if err != nil {
+			record.fail("sleep entry", err)
+			return
+		}
+		entries = append(entries, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, validation.Err()
+}
+
+// sleepRecord переводит состояние записи сна в строку CSV в порядке sleepCSVHeader
+func sleepRecord(state entities.SleepEntryState) []string {
+	napStarts, napEnds := make([]time.Time, len(state.Naps)), make([]time.Time, len(state.Naps))
+	for i, nap := range state.Naps {
+		napStarts[i], napEnds[i] = nap.Start, nap.End
+	}
+
+	segmentStarts, segmentEnds := make([]time.Time, len(state.Segments)), make([]time.Time, len(state.Segments))
+	for i, segment := range state.Segments {
+		segmentStarts[i], segmentEnds[i] = segment.Bedtime, segment.WakeTime
+	}
+
+	return []string{
+		string(state.ID),
+		formatTime(state.Date),
+		formatTime(state.Bedtime),
+		formatTime(state.WakeTime),
+		formatMinutes(state.SleepLatency),
+		strconv.Itoa(state.NightAwakenings),
+		formatMinutes(state.TimeAwakeDuringNight),
+		strconv.FormatFloat(state.TotalSleepHours, 'f', -1, 64),
+		strconv.Itoa(state.SleepQuality.Int()),
+		strconv.Itoa(state.DaytimeSleepiness.Int()),
+		strconv.FormatBool(state.CaffeineAfterNoon),
+		formatMinutes(state.ScreenUseBeforeBed),
+		formatMinutes(state.EveningFreeTime),
+		state.Notes,
+		formatIntervals(napStarts, napEnds),
+		formatIntervals(segmentStarts, segmentEnds),
+	}
+}
+
+// sleepState разбирает строку CSV в состояние записи сна, накапливая ошибки в record
+func sleepState(record *csvRecord) entities.SleepEntryState {
+	quality, err := valueobjects.ParseSleepQuality(record.string("sleep_quality"))
+	record.check("sleep_quality", err)
+
+	sleepiness, err := valueobjects.ParseDaytimeSleepiness(record.string("daytime_sleepiness"))
+	record.check("daytime_sleepiness", err)
+
+	totalSleepHours, err := strconv.ParseFloat(record.string("total_sleep_hours"), 64)
+	if err != nil {
+		record.fail("total_sleep_hours", fmt.Errorf("must be a number"))
+	}
+
+	var naps []entities.Nap
+	napStarts, napEnds := record.intervals("naps")
+	for i := range napStarts {
+		naps = append(naps, entities.Nap{Start: napStarts[i], End: napEnds[i]})
+	}
+
+	var segments []entities.SleepSegment
+	segmentStarts, segmentEnds := record.intervals("segments")
+	for i := range segmentStarts {
+		segments = append(segments, entities.SleepSegment{Bedtime: segmentStarts[i], WakeTime: segmentEnds[i]})
+	}
+
+	return entities.SleepEntryState{
+		ID:                   entities.SleepEntryID(record.string("id")),
+		Date:                 record.time("date"),
+		Bedtime:              record.time("bedtime"),
+		WakeTime:             record.time("wake_time"),
+		SleepLatency:         record.minutes("sleep_latency_min"),
+		NightAwakenings:      record.int("night_awakenings"),
+		TimeAwakeDuringNight: record.minutes("time_awake_during_night_min"),
+		TotalSleepHours:      totalSleepHours,
+		SleepQuality:         quality,
+		DaytimeSleepiness:    sleepiness,
+		CaffeineAfterNoon:    record.bool("caffeine_after_noon"),
+		ScreenUseBeforeBed:   record.minutes("screen_use_before_bed_min"),
+		EveningFreeTime:      record.minutes("evening_free_time_min"),
+		Notes:                record.string("notes"),
+		Naps:                 naps,
+		Segments:             segments,
+	}
+}
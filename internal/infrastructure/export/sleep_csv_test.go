@@ -0,0 +1,124 @@
+package export
+
+import (
+	"bytes"
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/pkg/errors"
+	stderrors "errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSleepCSV_RoundTrip(t *testing.T) {
+	original := []*entities.SleepEntry{
+		newFullSleepEntry(t, "sleep-1"),
+		newSleepEntry(t, "sleep-2"),
+	}
+
+	var buf bytes.Buffer
+	if err := ExportSleepCSV(&buf, original); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	imported, err := ImportSleepCSV(&buf)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	assertSameSleepEntries(t, original, imported)
+}
+
+func TestImportSleepCSV_MalformedRow(t *testing.T) {
+	var buf bytes.Buffer
+	ExportSleepCSV(&buf, []*entities.SleepEntry{newSleepEntry(t, "sleep-1")})
+	buf.WriteString("sleep-2,2025-08-13T00:00:00Z,not-a-time,2025-08-13T07:00:00Z,0,0,0,8,12,0,false,0,0,,,\n")
+
+	entries, err := ImportSleepCSV(&buf)
+
+	if len(entries) != 1 || entries[0].ID() != "sleep-1" {
+		t.Fatalf("Expected only sleep-1 to be imported, got %d entries", len(entries))
+	}
+
+	var validation *errors.ValidationErrors
+	if !stderrors.As(err, &validation) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+
+	if len(validation.ErrorsByField()["line 3"]) != 2 {
+		t.Errorf("Expected bedtime and sleep quality errors on line 3, got %v", validation.ErrorsByField())
+	}
+}
+
+func TestImportSleepCSV_EmptyInput(t *testing.T) {
+	entries, err := ImportSleepCSV(strings.NewReader(""))
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Expected no entries and no error, got %d entries and %v", len(entries), err)
+	}
+}
+
+// assertSameSleepEntries сравнивает полные состояния записей сна
+func assertSameSleepEntries(t *testing.T, expected, actual []*entities.SleepEntry) {
+	t.Helper()
+
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected %d entries, got %d", len(expected), len(actual))
+	}
+
+	for i := range expected {
+		if !reflect.DeepEqual(expected[i].State(), actual[i].State()) {
+			t.Errorf("Entry %d differs:\nexpected %+v\ngot      %+v", i, expected[i].State(), actual[i].State())
+		}
+
+		if len(actual[i].DomainEvents()) != 0 {
+			t.Errorf("Expected no domain events on imported entry %d, got %d", i, len(actual[i].DomainEvents()))
+		}
+	}
+}
+
+// newFullSleepEntry создает запись сна со всеми заполненными полями
+func newFullSleepEntry(t *testing.T, id string) *entities.SleepEntry {
+	t.Helper()
+
+	night := time.Date(2025, 8, 11, 23, 0, 0, 0, time.UTC)
+	entry, err := entities.ReconstructSleepEntry(entities.SleepEntryState{
+		ID:                   entities.SleepEntryID(id),
+		Date:                 time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC),
+		Bedtime:              night,
+		WakeTime:             night.Add(5 * time.Hour),
+		SleepLatency:         20 * time.Minute,
+		NightAwakenings:      2,
+		TimeAwakeDuringNight: 25 * time.Minute,
+		TotalSleepHours:      6.25,
+		SleepQuality:         6,
+		DaytimeSleepiness:    4,
+		CaffeineAfterNoon:    true,
+		ScreenUseBeforeBed:   90 * time.Minute,
+		EveningFreeTime:      150 * time.Minute,
+		Notes:                "Шумные соседи, \"проснулся\"\nдважды",
+		Naps: []entities.Nap{
+			{Start: night.Add(15 * time.Hour), End: night.Add(15*time.Hour + 30*time.Minute)},
+		},
+		Segments: []entities.SleepSegment{
+			{Bedtime: night.Add(6 * time.Hour), WakeTime: night.Add(8 * time.Hour)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create sleep entry: %v", err)
+	}
+	return entry
+}
+
+// newSleepEntry создает запись сна только с обязательными полями
+func newSleepEntry(t *testing.T, id string) *entities.SleepEntry {
+	t.Helper()
+
+	night := time.Date(2025, 8, 12, 23, 30, 0, 0, time.UTC)
+	entry, err := entities.NewSleepEntry(entities.SleepEntryID(id), time.Date(2025, 8, 13, 0, 0, 0, 0, time.UTC), night, night.Add(7*time.Hour), 8)
+	if err != nil {
+		t.Fatalf("Failed to create sleep entry: %v", err)
+	}
+	entry.ClearDomainEvents()
+	return entry
+}
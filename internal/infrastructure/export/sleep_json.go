@@ -0,0 +1,134 @@
+package export
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"daily-tracker/pkg/errors"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// sleepJSONRecord формат записи сна для обмена: время в RFC3339, длительности в минутах
+type sleepJSONRecord struct {
+	ID                      string                  `json:"id"`
+	Date                    time.Time               `json:"date"`
+	Bedtime                 time.Time               `json:"bedtime"`
+	WakeTime                time.Time               `json:"wake_time"`
+	SleepLatencyMin         float64                 `json:"sleep_latency_min"`
+	NightAwakenings         int                     `json:"night_awakenings"`
+	TimeAwakeDuringNightMin float64                 `json:"time_awake_during_night_min"`
+	TotalSleepHours         float64                 `json:"total_sleep_hours"`
+	SleepQuality            int                     `json:"sleep_quality"`
+	DaytimeSleepiness       int                     `json:"daytime_sleepiness"`
+	CaffeineAfterNoon       bool                    `json:"caffeine_after_noon"`
+	ScreenUseBeforeBedMin   float64                 `json:"screen_use_before_bed_min"`
+	EveningFreeTimeMin      float64                 `json:"evening_free_time_min"`
+	Notes                   string                  `json:"notes"`
+	Naps                    []entities.Nap          `json:"naps,omitempty"`
+	Segments                []entities.SleepSegment `json:"segments,omitempty"`
+}
+
+// ExportSleepJSON записывает записи сна JSON-массивом
+func ExportSleepJSON(w io.Writer, entries []*entities.SleepEntry) error {
+	records := make([]sleepJSONRecord, 0, len(entries))
+	for _, entry := range entries {
+		if entry == nil {
+			return errors.NewDomainError("sleep entry cannot be nil")
+		}
+		records = append(records, newSleepJSONRecord(entry.State()))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(records); err != nil {
+		return fmt.Errorf("encode sleep json: %w", err)
+	}
+	return nil
+}
+
+// ImportSleepJSON читает записи сна в формате ExportSleepJSON через ReconstructSleepEntry
+// Ошибки отдельных записей собираются в ValidationErrors по номеру записи (с 1)
+func ImportSleepJSON(r io.Reader) ([]*entities.SleepEntry, error) {
+	var records []sleepJSONRecord
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		if err == io.EOF {
+			return []*entities.SleepEntry{}, nil
+		}
+		return nil, fmt.Errorf("decode sleep json: %w", err)
+	}
+
+	validation := errors.NewValidationErrors()
+	entries := make([]*entities.SleepEntry, 0, len(records))
+
+	for i, record := range records {
+		entry, err := record.toEntry()
+		if err != nil {
+			validation.Add(fmt.Sprintf("entry %d", i+1), err.Error())
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, validation.Err()
+}
+
+func newSleepJSONRecord(state entities.SleepEntryState) sleepJSONRecord {
+	return sleepJSONRecord{
+		ID:                      string(state.ID),
+		Date:                    state.Date,
+		Bedtime:                 state.Bedtime,
+		WakeTime:                state.WakeTime,
+		SleepLatencyMin:         state.SleepLatency.Minutes(),
+		NightAwakenings:         state.NightAwakenings,
+		TimeAwakeDuringNightMin: state.TimeAwakeDuringNight.Minutes(),
+		TotalSleepHours:         state.TotalSleepHours,
+		SleepQuality:            state.SleepQuality.Int(),
+		DaytimeSleepiness:       state.DaytimeSleepiness.Int(),
+		CaffeineAfterNoon:       state.CaffeineAfterNoon,
+		ScreenUseBeforeBedMin:   state.ScreenUseBeforeBed.Minutes(),
+		EveningFreeTimeMin:      state.EveningFreeTime.Minutes(),
+		Notes:                   state.Notes,
+		Naps:                    state.Naps,
+		Segments:                state.Segments,
+	}
+}
+
+// toEntry восстанавливает запись сна, проверяя уровни конструкторами value objects
+func (r sleepJSONRecord) toEntry() (*entities.SleepEntry, error) {
+	quality, err := valueobjects.NewSleepQuality(r.SleepQuality)
+	if err != nil {
+		return nil, err
+	}
+
+	sleepiness, err := valueobjects.NewDaytimeSleepiness(r.DaytimeSleepiness)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, minutes := range []float64{r.SleepLatencyMin, r.TimeAwakeDuringNightMin, r.ScreenUseBeforeBedMin, r.EveningFreeTimeMin} {
+		if minutes < 0 {
+			return nil, errors.NewDomainError("duration cannot be negative")
+		}
+	}
+
+	return entities.ReconstructSleepEntry(entities.SleepEntryState{
+		ID:                   entities.SleepEntryID(r.ID),
+		Date:                 r.Date,
+		Bedtime:              r.Bedtime,
+		WakeTime:             r.WakeTime,
+		SleepLatency:         minutesDuration(r.SleepLatencyMin),
+		NightAwakenings:      r.NightAwakenings,
+		TimeAwakeDuringNight: minutesDuration(r.TimeAwakeDuringNightMin),
+		TotalSleepHours:      r.TotalSleepHours,
+		SleepQuality:         quality,
+		DaytimeSleepiness:    sleepiness,
+		CaffeineAfterNoon:    r.CaffeineAfterNoon,
+		ScreenUseBeforeBed:   minutesDuration(r.ScreenUseBeforeBedMin),
+		EveningFreeTime:      minutesDuration(r.EveningFreeTimeMin),
+		Notes:                r.Notes,
+		Naps:                 r.Naps,
+		Segments:             r.Segments,
+	})
+}
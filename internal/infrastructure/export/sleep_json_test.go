@@ -0,0 +1,58 @@
+package export
+
+import (
+	"bytes"
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/pkg/errors"
+	stderrors "errors"
+	"strings"
+	"testing"
+)
+
+func TestSleepJSON_RoundTrip(t *testing.T) {
+	original := []*entities.SleepEntry{
+		newFullSleepEntry(t, "sleep-1"),
+		newSleepEntry(t, "sleep-2"),
+	}
+
+	var buf bytes.Buffer
+	if err := ExportSleepJSON(&buf, original); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `"sleep_latency_min": 20`) || !strings.Contains(buf.String(), `"time_awake_during_night_min": 25`) || !strings.Contains(buf.String(), `"bedtime": "2025-08-11T23:00:00Z"`) {
+		t.Errorf("Expected minutes and RFC3339 times in output, got %s", buf.String())
+	}
+
+	imported, err := ImportSleepJSON(&buf)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	assertSameSleepEntries(t, original, imported)
+}
+
+func TestImportSleepJSON_InvalidEntry(t *testing.T) {
+	input := `[
+		{"id": "sleep-1", "bedtime": "2025-08-11T23:00:00Z", "wake_time": "2025-08-12T07:00:00Z", "sleep_quality": 7},
+		{"id": "sleep-2", "bedtime": "2025-08-12T23:00:00Z", "wake_time": "2025-08-13T07:00:00Z", "sleep_quality": 15}
+	]`
+
+	entries, err := ImportSleepJSON(strings.NewReader(input))
+
+	if len(entries) != 1 || entries[0].ID() != "sleep-1" {
+		t.Fatalf("Expected only sleep-1 to be imported, got %d entries", len(entries))
+	}
+
+	var validation *errors.ValidationErrors
+	if !stderrors.As(err, &validation) || len(validation.ErrorsByField()["entry 2"]) != 1 {
+		t.Errorf("Expected an error for entry 2, got %v", err)
+	}
+}
+
+func TestImportSleepJSON_EmptyInput(t *testing.T) {
+	entries, err := ImportSleepJSON(strings.NewReader(""))
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Expected no entries and no error, got %d entries and %v", len(entries), err)
+	}
+}
@@ -0,0 +1,174 @@
+package export
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"daily-tracker/pkg/errors"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// taskCSVHeader порядок колонок CSV для задач
+// Длительности записываются в минутах, время - в RFC3339
+var taskCSVHeader = []string{
+	"id",
+	"date",
+	"day_number",
+	"key_task",
+	"category",
+	"priority",
+	"stress_before",
+	"started",
+	"start_time",
+	"active_duration_min",
+	"continued_after",
+	"stress_after",
+	"has_stress_after",
+	"distractions_min",
+	"blocks_completed",
+	"pomodoro_count",
+	"light_exposure_min",
+	"energy",
+	"mood",
+	"notes",
+	"completed_at",
+	"paused",
+	"session_start",
+}
+
+// ExportTasksCSV записывает задачи в CSV: строка заголовка и по строке на задачу
+// Выгружаются скалярные поля состояния; теги и подзадачи в CSV не попадают
+func ExportTasksCSV(w io.Writer, tasks []*entities.TaskEntry) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(taskCSVHeader); err != nil {
+		return fmt.Errorf("write tasks header: %w", err)
+	}
+
+	for _, task := range tasks {
+		if task == nil {
+			return errors.NewDomainError("task cannot be nil")
+		}
+
+		if err := writer.Write(taskRecord(task.State())); err != nil {
+			return fmt.Errorf("write task %s: %w", task.ID(), err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("flush tasks csv: %w", err)
+	}
+	return nil
+}
+
+// taskRecord переводит состояние задачи в строку CSV в порядке taskCSVHeader
+func taskRecord(state entities.TaskEntryState) []string {
+	return []string{
+		string(state.ID),
+		formatTime(state.Date),
+		strconv.Itoa(state.DayNumber),
+		state.KeyTask,
+		state.Category.String(),
+		state.Priority.String(),
+		strconv.Itoa(state.StressBefore.Int()),
+		strconv.FormatBool(state.Started),
+		formatOptionalTime(state.StartTime),
+		formatMinutes(state.ActiveDuration),
+		strconv.FormatBool(state.ContinuedAfter),
+		strconv.Itoa(state.StressAfter.Int()),
+		strconv.FormatBool(state.HasStressAfter),
+		formatMinutes(state.Distractions),
+		strconv.Itoa(state.BlocksCompleted),
+		strconv.Itoa(state.PomodoroCount),
+		formatMinutes(state.LightExposure),
+		strconv.Itoa(state.Energy.Int()),
+		strconv.Itoa(state.Mood.Int()),
+		state.Notes,
+		formatOptionalTime(state.CompletedAt),
+		strconv.FormatBool(state.Paused),
+		formatOptionalTime(state.SessionStart),
+	}
+}
+
+// ImportTasksCSV читает задачи в формате ExportTasksCSV
+// Каждое поле проходит через конструкторы value objects и ReconstructTaskEntry,
+// поэтому события не генерируются. Ошибочные строки пропускаются, а их ошибки
+// собираются в ValidationErrors по номеру строки; корректные задачи возвращаются вместе с ними
+func ImportTasksCSV(r io.Reader) ([]*entities.TaskEntry, error) {
+	validation := errors.NewValidationErrors()
+	tasks := make([]*entities.TaskEntry, 0)
+
+	err := readCSVRecords(r, taskCSVHeader, validation, func(record *csvRecord) {
+		errorsBefore := len(validation.Errors())
+		state := taskState(record)
+		if len(validation.Errors()) > errorsBefore {
+			return
+		}
+
+		task, err := entities.ReconstructTaskEntry(state)
+		if err != nil {
+			record.fail("task", err)
+			return
+		}
+		tasks = append(tasks, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, validation.Err()
+}
+
+// taskState разбирает строку CSV в состояние задачи, накапливая ошибки в record
+func taskState(record *csvRecord) entities.TaskEntryState {
+	category, err := valueobjects.NewTaskCategory(record.string("category"))
+	record.check("category", err)
+
+	var priority valueobjects.TaskPriority
+	if raw := record.string("priority"); strings.TrimSpace(raw) != "" {
+		priority, err = valueobjects.NewTaskPriority(raw)
+		record.check("priority", err)
+	}
+
+	stressBefore, err := valueobjects.ParseStressLevel(record.string("stress_before"))
+	record.check("stress_before", err)
+
+	stressAfter, err := valueobjects.ParseStressLevel(record.string("stress_after"))
+	record.check("stress_after", err)
+
+	energy, err := valueobjects.ParseEnergyLevel(record.string("energy"))
+	record.check("energy", err)
+
+	mood, err := valueobjects.ParseMoodLevel(record.string("mood"))
+	record.check("mood", err)
+
+	return entities.TaskEntryState{
+		ID:              entities.TaskEntryID(record.string("id")),
+		Date:            record.time("date"),
+		DayNumber:       record.int("day_number"),
+		KeyTask:         record.string("key_task"),
+		Category:        category,
+		Priority:        priority,
+		StressBefore:    stressBefore,
+		Started:         record.bool("started"),
+		StartTime:       record.optionalTime("start_time"),
+		ActiveDuration:  record.minutes("active_duration_min"),
+		ContinuedAfter:  record.bool("continued_after"),
+		StressAfter:     stressAfter,
+		HasStressAfter:  record.bool("has_stress_after"),
+		Distractions:    record.minutes("distractions_min"),
+		BlocksCompleted: record.int("blocks_completed"),
+		PomodoroCount:   record.int("pomodoro_count"),
+		LightExposure:   record.minutes("light_exposure_min"),
+		Energy:          energy,
+		Mood:            mood,
+		Notes:           record.string("notes"),
+		CompletedAt:     record.optionalTime("completed_at"),
+		Paused:          record.bool("paused"),
+		SessionStart:    record.optionalTime("session_start"),
+	}
+}
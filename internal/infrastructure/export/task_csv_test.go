@@ -0,0 +1,185 @@
+package export
+
+import (
+	"bytes"
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"daily-tracker/pkg/errors"
+	"encoding/csv"
+	stderrors "errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportTasksCSV(t *testing.T) {
+	startedAt := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+	defer entities.SetClock(entities.NewFixedClock(startedAt))()
+
+	first := newTask(t, "task-1", startedAt, 1)
+	first.StartTask()
+	first.UpdateDuration(90 * time.Minute)
+	first.RecordDistraction(15 * time.Minute)
+	first.SetStressAfter(valueobjects.StressLevel(3))
+	first.SetPriority(valueobjects.TaskPriorityHigh)
+	first.AddNotes("Отвлекся на почту, 5 мин\nпотом звонок")
+
+	second := newTask(t, "task-2", startedAt.AddDate(0, 0, 1), 2)
+
+	var buf bytes.Buffer
+	if err := ExportTasksCSV(&buf, []*entities.TaskEntry{first, second}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected valid CSV, got %v", err)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d records", len(records))
+	}
+
+	row := csvRow(records[0], records[1])
+	expected := map[string]string{
+		"id":                  "task-1",
+		"date":                "2025-08-12T09:00:00Z",
+		"day_number":          "1",
+		"key_task":            "Написать отчет",
+		"category":            "работа",
+		"priority":            "high",
+		"stress_before":       "7",
+		"started":             "true",
+		"start_time":          "2025-08-12T09:00:00Z",
+		"active_duration_min": "90",
+		"stress_after":        "3",
+		"has_stress_after":    "true",
+		"distractions_min":    "15",
+		"notes":               "Отвлекся на почту, 5 мин\nпотом звонок",
+		"completed_at":        "",
+		"paused":              "false",
+		"session_start":       "2025-08-12T09:00:00Z",
+	}
+
+	for column, value := range expected {
+		if row[column] != value {
+			t.Errorf("Expected %s = %q, got %q", column, value, row[column])
+		}
+	}
+
+	secondRow := csvRow(records[0], records[2])
+	if secondRow["id"] != "task-2" || secondRow["started"] != "false" || secondRow["start_time"] != "" || secondRow["session_start"] != "" {
+		t.Errorf("Unexpected second row: %v", secondRow)
+	}
+}
+
+func TestExportTasksCSV_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportTasksCSV(&buf, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	records, _ := csv.NewReader(&buf).ReadAll()
+	if len(records) != 1 || len(records[0]) != len(taskCSVHeader) {
+		t.Errorf("Expected only the header row, got %v", records)
+	}
+}
+
+func TestImportTasksCSV_RoundTrip(t *testing.T) {
+	startedAt := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+	defer entities.SetClock(entities.NewFixedClock(startedAt))()
+
+	original := newTask(t, "task-1", startedAt, 1)
+	original.StartTask()
+	original.UpdateDuration(45 * time.Minute)
+	original.SetEnergy(valueobjects.EnergyLevel(6))
+	original.SetPriority(valueobjects.TaskPriorityUrgent)
+	original.AddNotes("Заметка, с запятой")
+
+	var buf bytes.Buffer
+	ExportTasksCSV(&buf, []*entities.TaskEntry{original, newTask(t, "task-2", startedAt, 2)})
+
+	tasks, err := ImportTasksCSV(&buf)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(tasks) != 2 {
+		t.Fatalf("Expected 2 tasks, got %d", len(tasks))
+	}
+
+	imported := tasks[0]
+	if imported.ID() != "task-1" || !imported.Started() || imported.ActiveDuration() != 45*time.Minute {
+		t.Errorf("Unexpected imported task: id=%s started=%v duration=%v", imported.ID(), imported.Started(), imported.ActiveDuration())
+	}
+
+	if imported.Energy() != 6 || imported.Priority() != valueobjects.TaskPriorityUrgent || imported.Notes() != "Заметка, с запятой" {
+		t.Errorf("Unexpected imported fields: energy=%d priority=%s notes=%q", imported.Energy(), imported.Priority(), imported.Notes())
+	}
+
+	if !imported.StartTime().Equal(startedAt) || !imported.Date().Equal(startedAt) {
+		t.Errorf("Expected times %v, got start=%v date=%v", startedAt, imported.StartTime(), imported.Date())
+	}
+
+	if sessionStart := imported.State().SessionStart; sessionStart == nil || !sessionStart.Equal(startedAt) {
+		t.Errorf("Expected open session from %v, got %v", startedAt, sessionStart)
+	}
+
+	if tasks[1].State().SessionStart != nil {
+		t.Errorf("Expected no session for not started task, got %v", tasks[1].State().SessionStart)
+	}
+
+	if len(imported.DomainEvents()) != 0 {
+		t.Errorf("Expected no domain events on imported task, got %d", len(imported.DomainEvents()))
+	}
+}
+
+func TestImportTasksCSV_MalformedRow(t *testing.T) {
+	input := "task-1,2025-08-12T09:00:00Z,1,Отчет,работа,,7,false,,0,false,0,false,0,0,0,0,0,0,,,false,\n" +
+		"task-2,2025-08-13T09:00:00Z,2,Отчет,спорт,,11,false,,0,false,0,false,0,0,0,0,0,0,,,false,\n" +
+		"task-3,2025-08-14T09:00:00Z,3,Отчет,учеба,,5,false,,0,false,0,false,0,0,0,0,0,0,,,false,\n"
+
+	tasks, err := ImportTasksCSV(strings.NewReader(input))
+
+	if len(tasks) != 2 || tasks[0].ID() != "task-1" || tasks[1].ID() != "task-3" {
+		t.Fatalf("Expected valid rows task-1 and task-3, got %d tasks", len(tasks))
+	}
+
+	var validation *errors.ValidationErrors
+	if !stderrors.As(err, &validation) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+
+	byLine := validation.ErrorsByField()
+	if len(byLine) != 1 || len(byLine["line 2"]) != 2 {
+		t.Errorf("Expected category and stress errors on line 2, got %v", byLine)
+	}
+}
+
+func TestImportTasksCSV_EmptyInput(t *testing.T) {
+	for _, input := range []string{"", strings.Join(taskCSVHeader, ",") + "\n"} {
+		tasks, err := ImportTasksCSV(strings.NewReader(input))
+		if err != nil || len(tasks) != 0 {
+			t.Errorf("Expected no tasks and no error for %q, got %d tasks and %v", input, len(tasks), err)
+		}
+	}
+}
+
+// csvRow сопоставляет значения строки с колонками заголовка
+func csvRow(header, record []string) map[string]string {
+	row := make(map[string]string, len(header))
+	for i, column := range header {
+		row[column] = record[i]
+	}
+	return row
+}
+
+func newTask(t *testing.T, id string, date time.Time, dayNumber int) *entities.TaskEntry {
+	t.Helper()
+
+	task, err := entities.NewTaskEntry(entities.TaskEntryID(id), date, dayNumber, "Написать отчет", "работа", 7)
+	if err != nil {
+		t.Fatalf("Failed to create task entry: %v", err)
+	}
+	return task
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/repositories"
+	"time"
+)
+
+var _ repositories.TaskRepository = (*CachingTaskRepository)(nil)
+
+// CachingTaskRepository декоратор репозитория задач с кешированием FindByID
+// Выборки по датам не кешируются и всегда идут в исходный репозиторий
+type CachingTaskRepository struct {
+	repo  repositories.TaskRepository
+	cache repositories.TaskCache
+	ttl   time.Duration
+}
+
+// NewCachingTaskRepository оборачивает repo кешем; ttl - время жизни записи кеша
+func NewCachingTaskRepository(repo repositories.TaskRepository, cache repositories.TaskCache, ttl time.Duration) *CachingTaskRepository {
+	return &CachingTaskRepository{
+		repo:  repo,
+		cache: cache,
+		ttl:   ttl,
+	}
+}
+
+// Save сохраняет задачу и сбрасывает ее запись в кеше
+func (r *CachingTaskRepository) Save(ctx context.Context, task *entities.TaskEntry) error {
+	if err := r.repo.Save(ctx, task); err != nil {
+		return err
+	}
+
+	r.cache.Delete(cacheKey(task.ID()))
+	return nil
+}
+
+// FindByID сначала ищет задачу в кеше, а при промахе загружает и кеширует ее
+func (r *CachingTaskRepository) FindByID(ctx context.Context, id entities.TaskEntryID) (*entities.TaskEntry, error) {
+	if task, ok := r.cache.Get(cacheKey(id)); ok {
+		return task, nil
+	}
+
+	task, err := r.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(cacheKey(id), task, r.ttl)
+	return task, nil
+}
+
+func (r *CachingTaskRepository) FindByDate(ctx context.Context, date time.Time) ([]*entities.TaskEntry, error) {
+	return r.repo.FindByDate(ctx, date)
+}
+
+func (r *CachingTaskRepository) FindByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*entities.TaskEntry, error) {
+	return r.repo.FindByDateRange(ctx, startDate, endDate)
+}
+
+// Delete удаляет задачу и ее запись в кеше
+// Кеш сбрасывается и при ошибке, чтобы не отдавать задачу, состояние которой неизвестно
+func (r *CachingTaskRepository) Delete(ctx context.Context, id entities.TaskEntryID) error {
+	defer r.cache.Delete(cacheKey(id))
+	return r.repo.Delete(ctx, id)
+}
+
+func (r *CachingTaskRepository) Exists(ctx context.Context, id entities.TaskEntryID) (bool, error) {
+	return r.repo.Exists(ctx, id)
+}
+
+// cacheKey ключ задачи в кеше
+func cacheKey(id entities.TaskEntryID) string {
+	return "task:" + string(id)
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"daily-tracker/pkg/errors"
+	"testing"
+	"time"
+)
+
+// countingTaskRepository считает обращения FindByID к исходному репозиторию
+type countingTaskRepository struct {
+	*InMemoryTaskRepository
+	findByIDCalls int
+}
+
+func (r *countingTaskRepository) FindByID(ctx context.Context, id entities.TaskEntryID) (*entities.TaskEntry, error) {
+	r.findByIDCalls++
+	return r.InMemoryTaskRepository.FindByID(ctx, id)
+}
+
+func TestCachingTaskRepository_FindByIDHitsCache(t *testing.T) {
+	ctx := context.Background()
+	backing := &countingTaskRepository{InMemoryTaskRepository: NewInMemoryTaskRepository()}
+	repo := newCachingRepository(t, backing)
+	repo.Save(ctx, newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork))
+
+	for i := 0; i < 2; i++ {
+		task, err := repo.FindByID(ctx, "task-1")
+		if err != nil || task.ID() != "task-1" {
+			t.Fatalf("Expected task-1, got %v (err: %v)", task, err)
+		}
+	}
+
+	if backing.findByIDCalls != 1 {
+		t.Errorf("Expected 1 call to backing repository, got %d", backing.findByIDCalls)
+	}
+}
+
+func TestCachingTaskRepository_SaveInvalidates(t *testing.T) {
+	ctx := context.Background()
+	backing := &countingTaskRepository{InMemoryTaskRepository: NewInMemoryTaskRepository()}
+	repo := newCachingRepository(t, backing)
+
+	task := newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork)
+	repo.Save(ctx, task)
+	repo.FindByID(ctx, "task-1")
+
+	task.AddNotes("updated")
+	repo.Save(ctx, task)
+
+	found, _ := repo.FindByID(ctx, "task-1")
+	if found.Notes() != "updated" {
+		t.Errorf("Expected updated notes after save, got %q", found.Notes())
+	}
+
+	if backing.findByIDCalls != 2 {
+		t.Errorf("Expected cache miss after save, got %d backing calls", backing.findByIDCalls)
+	}
+}
+
+func TestCachingTaskRepository_DeleteInvalidates(t *testing.T) {
+	ctx := context.Background()
+	backing := &countingTaskRepository{InMemoryTaskRepository: NewInMemoryTaskRepository()}
+	repo := newCachingRepository(t, backing)
+	repo.Save(ctx, newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork))
+	repo.FindByID(ctx, "task-1")
+
+	if err := repo.Delete(ctx, "task-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := repo.FindByID(ctx, "task-1"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected NotFoundError after delete, got %v", err)
+	}
+}
+
+func newCachingRepository(t *testing.T, backing *countingTaskRepository) *CachingTaskRepository {
+	t.Helper()
+
+	cache, err := NewLRUTaskCache(10, nil)
+	if err != nil {
+		t.Fatalf("Failed to create cache: %v", err)
+	}
+	return NewCachingTaskRepository(backing, cache, time.Minute)
+}
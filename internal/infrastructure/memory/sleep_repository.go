@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"context"
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/repositories"
+	"daily-tracker/pkg/errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+var _ repositories.SleepRepository = (*InMemorySleepRepository)(nil)
+
+// InMemorySleepRepository хранит записи сна в памяти процесса
+type InMemorySleepRepository struct {
+	mu      sync.RWMutex
+	entries map[entities.SleepEntryID]*entities.SleepEntry
+}
+
+// NewInMemorySleepRepository создает пустой репозиторий записей сна
+func NewInMemorySleepRepository() *InMemorySleepRepository {
+	return &InMemorySleepRepository{
+		entries: make(map[entities.SleepEntryID]*entities.SleepEntry),
+	}
+}
+
+// Save сохраняет копию записи сна
+// Запись за ту же ночь с другим ID заменяется: за ночь хранится одна запись
+func (r *InMemorySleepRepository) Save(ctx context.Context, entry *entities.SleepEntry) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if entry == nil {
+		return errors.NewDomainError("sleep entry cannot be nil")
+	}
+
+	stored := copySleepEntry(entry)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, existing := range r.entries {
+		if id != entry.ID() && withinDays(existing.Date(), entry.Date(), entry.Date()) {
+			delete(r.entries, id)
+		}
+	}
+
+	r.entries[entry.ID()] = stored
+	return nil
+}
+
+// FindByID возвращает копию записи сна или NotFoundError
+func (r *InMemorySleepRepository) FindByID(ctx context.Context, id entities.SleepEntryID) (*entities.SleepEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.entries[id]
+	if !ok {
+		return nil, errors.NewNotFoundError("sleep entry", string(id))
+	}
+
+	return copySleepEntry(entry), nil
+}
+
+// FindByDate возвращает запись сна за календарный день или NotFoundError
+func (r *InMemorySleepRepository) FindByDate(ctx context.Context, date time.Time) (*entities.SleepEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range r.entries {
+		if withinDays(entry.Date(), date, date) {
+			return copySleepEntry(entry), nil
+		}
+	}
+
+	return nil, errors.NewNotFoundError("sleep entry", date.Format("2006-01-02"))
+}
+
+// FindByDateRange возвращает записи сна с startDate по endDate включительно по календарным дням
+func (r *InMemorySleepRepository) FindByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*entities.SleepEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.SleepEntry, 0)
+	for _, entry := range r.entries {
+		if !withinDays(entry.Date(), startDate, endDate) {
+			continue
+		}
+
+		result = append(result, copySleepEntry(entry))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Date().Before(result[j].Date())
+	})
+	return result, nil
+}
+
+// Delete удаляет запись сна или возвращает NotFoundError
+func (r *InMemorySleepRepository) Delete(ctx context.Context, id entities.SleepEntryID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.entries[id]; !ok {
+		return errors.NewNotFoundError("sleep entry", string(id))
+	}
+
+	delete(r.entries, id)
+	return nil
+}
+
+// Exists проверяет наличие записи сна
+func (r *InMemorySleepRepository) Exists(ctx context.Context, id entities.SleepEntryID) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.entries[id]
+	return ok, nil
+}
+
+// copySleepEntry создает независимую копию записи сна без доменных событий
+func copySleepEntry(entry *entities.SleepEntry) *entities.SleepEntry {
+	clone := entry.Clone()
+	clone.ClearDomainEvents()
+	return clone
+}
@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"daily-tracker/pkg/errors"
+	stderrors "errors"
+	"testing"
+	"time"
+)
+
+func TestInMemorySleepRepository_SaveOverwritesSameID(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemorySleepRepository()
+	night := time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)
+
+	repo.Save(ctx, newSleep(t, "sleep-1", night, 5))
+	if err := repo.Save(ctx, newSleep(t, "sleep-1", night, 8)); err != nil {
+		t.Fatalf("Expected no error on overwrite, got: %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, "sleep-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if found.SleepQuality().Int() != 8 {
+		t.Errorf("Expected overwritten quality 8, got %d", found.SleepQuality().Int())
+	}
+}
+
+func TestInMemorySleepRepository_SaveReplacesSameNight(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemorySleepRepository()
+	night := time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)
+
+	repo.Save(ctx, newSleep(t, "first", night, 5))
+	repo.Save(ctx, newSleep(t, "second", night, 8))
+
+	found, err := repo.FindByDate(ctx, night.Add(12*time.Hour))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if found.ID() != "second" {
+		t.Errorf("Expected latest entry for the night, got %s", found.ID())
+	}
+
+	if exists, _ := repo.Exists(ctx, "first"); exists {
+		t.Error("Expected replaced entry to be removed")
+	}
+}
+
+func TestInMemorySleepRepository_FindByDate_NotFound(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemorySleepRepository()
+	repo.Save(ctx, newSleep(t, "sleep-1", time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC), 7))
+
+	_, err := repo.FindByDate(ctx, time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC))
+	if !errors.IsNotFoundError(err) {
+		t.Errorf("Expected NotFoundError, got %v", err)
+	}
+}
+
+func TestInMemorySleepRepository_FindByDateRangeAndDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemorySleepRepository()
+	for day := 10; day <= 14; day++ {
+		date := time.Date(2025, 8, day, 0, 0, 0, 0, time.UTC)
+		repo.Save(ctx, newSleep(t, date.Format("sleep-2006-01-02"), date, 7))
+	}
+
+	entries, err := repo.FindByDateRange(ctx, time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC), time.Date(2025, 8, 13, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(entries) != 3 || entries[0].ID() != "sleep-2025-08-11" || entries[2].ID() != "sleep-2025-08-13" {
+		t.Errorf("Expected nights 11..13 in order, got %d entries", len(entries))
+	}
+
+	if err := repo.Delete(ctx, "sleep-2025-08-12"); err != nil {
+		t.Fatalf("Expected no error on delete, got: %v", err)
+	}
+
+	if err := repo.Delete(ctx, "sleep-2025-08-12"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected NotFoundError on second delete, got %v", err)
+	}
+}
+
+// Вспомогательная функция для создания записи сна на ночь перед date
+func newSleep(t *testing.T, id string, date time.Time, quality int) *entities.SleepEntry {
+	t.Helper()
+
+	sleepQuality, _ := valueobjects.NewSleepQuality(quality)
+	bedtime := date.Add(-time.Hour)
+	wakeTime := date.Add(7 * time.Hour)
+
+	entry, err := entities.NewSleepEntry(entities.SleepEntryID(id), date, bedtime, wakeTime, sleepQuality)
+	if err != nil {
+		t.Fatalf("Failed to create sleep entry: %v", err)
+	}
+
+	return entry
+}
+
+func TestInMemorySleepRepository_CancelledContext(t *testing.T) {
+	repo := NewInMemorySleepRepository()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	night := time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)
+	entries, err := repo.FindByDateRange(ctx, night, night)
+	if !stderrors.Is(err, context.Canceled) || entries != nil {
+		t.Errorf("Expected context.Canceled and no entries, got %v, %v", entries, err)
+	}
+}
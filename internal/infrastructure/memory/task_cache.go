@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"container/list"
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/repositories"
+	"daily-tracker/pkg/errors"
+	"sync"
+	"time"
+)
+
+var _ repositories.TaskCache = (*LRUTaskCache)(nil)
+
+// LRUTaskCache кеш задач с ограниченным размером и временем жизни записей
+// При переполнении вытесняется запись, к которой дольше всего не обращались
+type LRUTaskCache struct {
+	mu      sync.Mutex
+	maxSize int
+	clock   entities.Clock
+	order   *list.List               // Начало списка - самые свежие обращения
+	entries map[string]*list.Element // Ключ -> элемент списка с *cacheEntry
+}
+
+// cacheEntry запись кеша; нулевое expiresAt означает запись без срока жизни
+type cacheEntry struct {
+	key       string
+	task      *entities.TaskEntry
+	expiresAt time.Time
+}
+
+// NewLRUTaskCache создает кеш на maxSize записей
+// clock задает источник времени для TTL; nil означает системное время
+func NewLRUTaskCache(maxSize int, clock entities.Clock) (*LRUTaskCache, error) {
+	if maxSize < 1 {
+		return nil, errors.NewValidationError("maxSize", "must be positive")
+	}
+
+	if clock == nil {
+		clock = systemClock{}
+	}
+
+	return &LRUTaskCache{
+		maxSize: maxSize,
+		clock:   clock,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}, nil
+}
+
+// Get возвращает копию задачи и отмечает ключ как недавно использованный
+// Для отсутствующего или просроченного ключа возвращает (nil, false)
+func (c *LRUTaskCache) Get(key string) (*entities.TaskEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*cacheEntry)
+	if c.expired(entry) {
+		c.remove(element)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return copyTask(entry.task), true
+}
+
+// Set сохраняет копию задачи на время ttl (ttl <= 0 - без срока жизни)
+func (c *LRUTaskCache) Set(key string, task *entities.TaskEntry, ttl time.Duration) {
+	if task == nil {
+		c.Delete(key)
+		return
+	}
+
+	stored := copyTask(task)
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.clock.Now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value = &cacheEntry{key: key, task: stored, expiresAt: expiresAt}
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, task: stored, expiresAt: expiresAt})
+
+	if c.order.Len() > c.maxSize {
+		c.remove(c.order.Back())
+	}
+}
+
+// Delete удаляет ключ из кеша
+func (c *LRUTaskCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// Clear удаляет все записи
+func (c *LRUTaskCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+}
+
+// Len возвращает число записей, включая еще не удаленные просроченные
+func (c *LRUTaskCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *LRUTaskCache) expired(entry *cacheEntry) bool {
+	return !entry.expiresAt.IsZero() && !c.clock.Now().Before(entry.expiresAt)
+}
+
+func (c *LRUTaskCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*cacheEntry).key)
+}
+
+// systemClock источник системного времени для кеша по умолчанию
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"daily-tracker/pkg/errors"
+	"testing"
+	"time"
+)
+
+func TestLRUTaskCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache, _ := NewLRUTaskCache(2, nil)
+	date := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+
+	cache.Set("task-1", newTask(t, "task-1", date, valueobjects.TaskCategoryWork), 0)
+	cache.Set("task-2", newTask(t, "task-2", date, valueobjects.TaskCategoryWork), 0)
+
+	// Обращение делает task-1 самым свежим, вытесняться будет task-2
+	if _, ok := cache.Get("task-1"); !ok {
+		t.Fatal("Expected task-1 to be cached")
+	}
+
+	cache.Set("task-3", newTask(t, "task-3", date, valueobjects.TaskCategoryWork), 0)
+
+	if _, ok := cache.Get("task-2"); ok {
+		t.Error("Expected task-2 to be evicted")
+	}
+
+	for _, key := range []string{"task-1", "task-3"} {
+		if task, ok := cache.Get(key); !ok || string(task.ID()) != key {
+			t.Errorf("Expected %s to stay cached", key)
+		}
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", cache.Len())
+	}
+}
+
+func TestLRUTaskCache_TTLExpiry(t *testing.T) {
+	clock := entities.NewFixedClock(time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC))
+	cache, _ := NewLRUTaskCache(10, clock)
+	task := newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork)
+
+	cache.Set("short", task, time.Minute)
+	cache.Set("forever", task, 0)
+
+	clock.Advance(59 * time.Second)
+	if _, ok := cache.Get("short"); !ok {
+		t.Error("Expected entry to be cached before TTL")
+	}
+
+	clock.Advance(time.Second)
+	if got, ok := cache.Get("short"); ok || got != nil {
+		t.Error("Expected (nil, false) after TTL")
+	}
+
+	clock.Advance(24 * time.Hour)
+	if _, ok := cache.Get("forever"); !ok {
+		t.Error("Expected entry without TTL to stay cached")
+	}
+}
+
+func TestLRUTaskCache_StoresCopies(t *testing.T) {
+	cache, _ := NewLRUTaskCache(1, nil)
+	task := newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork)
+
+	cache.Set("task-1", task, 0)
+	task.AddNotes("changed after set")
+
+	cached, _ := cache.Get("task-1")
+	cached.AddNotes("changed after get")
+
+	again, _ := cache.Get("task-1")
+	if again.Notes() != "" {
+		t.Errorf("Expected cached notes to stay empty, got %q", again.Notes())
+	}
+}
+
+func TestLRUTaskCache_DeleteAndClear(t *testing.T) {
+	cache, _ := NewLRUTaskCache(5, nil)
+	date := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+	cache.Set("task-1", newTask(t, "task-1", date, valueobjects.TaskCategoryWork), 0)
+	cache.Set("task-2", newTask(t, "task-2", date, valueobjects.TaskCategoryWork), 0)
+
+	cache.Delete("task-1")
+	if _, ok := cache.Get("task-1"); ok {
+		t.Error("Expected task-1 to be deleted")
+	}
+
+	cache.Clear()
+	if _, ok := cache.Get("task-2"); ok || cache.Len() != 0 {
+		t.Errorf("Expected empty cache after Clear, got %d entries", cache.Len())
+	}
+}
+
+func TestNewLRUTaskCache_InvalidSize(t *testing.T) {
+	if _, err := NewLRUTaskCache(0, nil); !errors.IsValidationError(err) {
+		t.Errorf("Expected ValidationError, got %v", err)
+	}
+}
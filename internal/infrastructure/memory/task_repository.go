@@ -0,0 +1,560 @@
+package memory
+
+import (
+	"context"
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/repositories"
+	"daily-tracker/internal/domain/valueobjects"
+	"daily-tracker/pkg/errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Проверка на этапе компиляции, что тип реализует интерфейс
+var (
+	_ repositories.TaskRepository           = (*InMemoryTaskRepository)(nil)
+	_ repositories.TaskStatisticsRepository = (*InMemoryTaskRepository)(nil)
+	_ repositories.PagedTaskReader          = (*InMemoryTaskRepository)(nil)
+	_ repositories.TaskReader               = (*InMemoryTaskRepository)(nil)
+	_ repositories.TaskCategoryReader       = (*InMemoryTaskRepository)(nil)
+	_ repositories.TaskWriter               = (*InMemoryTaskRepository)(nil)
+	_ repositories.TaskRecycleBin           = (*InMemoryTaskRepository)(nil)
+	_ repositories.FilteredTaskReader       = (*InMemoryTaskRepository)(nil)
+)
+
+// InMemoryTaskRepository хранит задачи в памяти процесса
+// Подходит для тестов и локального запуска без базы данных.
+// Delete переносит задачу в корзину (deleted), поэтому запросы по tasks
+// видят только действующие задачи
+type InMemoryTaskRepository struct {
+	mu      sync.RWMutex
+	tasks   map[entities.TaskEntryID]*entities.TaskEntry
+	deleted map[entities.TaskEntryID]deletedTask
+	clock   entities.Clock
+}
+
+// deletedTask задача в корзине и время ее удаления
+type deletedTask struct {
+	task      *entities.TaskEntry
+	deletedAt time.Time
+}
+
+// NewInMemoryTaskRepository создает пустой репозиторий задач
+func NewInMemoryTaskRepository() *InMemoryTaskRepository {
+	return &InMemoryTaskRepository{
+		tasks:   make(map[entities.TaskEntryID]*entities.TaskEntry),
+		deleted: make(map[entities.TaskEntryID]deletedTask),
+		clock:   systemClock{},
+	}
+}
+
+// Save сохраняет копию задачи, чтобы изменения снаружи не затрагивали хранилище
+func (r *InMemoryTaskRepository) Save(ctx context.Context, task *entities.TaskEntry) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if task == nil {
+		return errors.NewDomainError("task cannot be nil")
+	}
+
+	stored := copyTask(task)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := checkTaskVersion(r.tasks[task.ID()], stored); err != nil {
+		return err
+	}
+
+	stored.MarkPersisted()
+	// Сохранение удаленной задачи возвращает ее из корзины
+	delete(r.deleted, task.ID())
+	r.tasks[task.ID()] = stored
+	task.MarkPersisted()
+	return nil
+}
+
+// SaveBatch сохраняет копии всех задач под одной блокировкой
+// Сначала проверяются и копируются все задачи; при любой ошибке хранилище не меняется
+func (r *InMemoryTaskRepository) SaveBatch(ctx context.Context, tasks []*entities.TaskEntry) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	stored := make([]*entities.TaskEntry, 0, len(tasks))
+	for i, task := range tasks {
+		if task == nil {
+			return errors.NewDomainError(fmt.Sprintf("task at index %d cannot be nil", i))
+		}
+
+		stored = append(stored, copyTask(task))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Версии сверяются и с уже сохраненными задачами, и с предыдущими задачами пакета
+	pending := make(map[entities.TaskEntryID]*entities.TaskEntry, len(stored))
+	for _, task := range stored {
+		existing, ok := pending[task.ID()]
+		if !ok {
+			existing = r.tasks[task.ID()]
+		}
+		if err := checkTaskVersion(existing, task); err != nil {
+			return err
+		}
+		pending[task.ID()] = task
+	}
+
+	for _, task := range stored {
+		task.MarkPersisted()
+		delete(r.deleted, task.ID())
+		r.tasks[task.ID()] = task
+	}
+	for _, task := range tasks {
+		task.MarkPersisted()
+	}
+	return nil
+}
+
+// FindByID возвращает копию задачи или NotFoundError
+func (r *InMemoryTaskRepository) FindByID(ctx context.Context, id entities.TaskEntryID) (*entities.TaskEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	task, ok := r.tasks[id]
+	if !ok {
+		return nil, errors.NewNotFoundError("task", string(id))
+	}
+
+	return copyTask(task), nil
+}
+
+// FindByDate возвращает задачи за календарный день (время суток не учитывается)
+func (r *InMemoryTaskRepository) FindByDate(ctx context.Context, date time.Time) ([]*entities.TaskEntry, error) {
+	return r.FindByDateRange(ctx, date, date)
+}
+
+// FindByDateRange возвращает задачи с startDate по endDate включительно по календарным дням
+func (r *InMemoryTaskRepository) FindByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*entities.TaskEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	guard := scanGuard{ctx: ctx}
+	result := make([]*entities.TaskEntry, 0)
+	for _, task := range r.tasks {
+		if err := guard.check(); err != nil {
+			return nil, err
+		}
+
+		if !withinDays(task.Date(), startDate, endDate) {
+			continue
+		}
+
+		result = append(result, copyTask(task))
+	}
+
+	sortTasks(result)
+	return result, nil
+}
+
+// FindByCategory возвращает задачи категории с startDate по endDate включительно
+func (r *InMemoryTaskRepository) FindByCategory(ctx context.Context, category valueobjects.TaskCategory, startDate, endDate time.Time) ([]*entities.TaskEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if !category.IsValid() {
+		return nil, errors.NewValidationError("category", "invalid task category: "+category.String())
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	guard := scanGuard{ctx: ctx}
+	result := make([]*entities.TaskEntry, 0)
+	for _, task := range r.tasks {
+		if err := guard.check(); err != nil {
+			return nil, err
+		}
+
+		if task.Category() != category || !withinDays(task.Date(), startDate, endDate) {
+			continue
+		}
+
+		result = append(result, copyTask(task))
+	}
+
+	sortTasks(result)
+	return result, nil
+}
+
+// FindByFilter возвращает задачи, удовлетворяющие всем заданным условиям фильтра
+// Нулевой фильтр возвращает все действующие задачи
+func (r *InMemoryTaskRepository) FindByFilter(ctx context.Context, filter repositories.TaskFilter) ([]*entities.TaskEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if filter.Category != nil && !filter.Category.IsValid() {
+		return nil, errors.NewValidationError("category", "invalid task category: "+filter.Category.String())
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	guard := scanGuard{ctx: ctx}
+	result := make([]*entities.TaskEntry, 0)
+	for _, task := range r.tasks {
+		if err := guard.check(); err != nil {
+			return nil, err
+		}
+
+		if !matchesFilter(task, filter) {
+			continue
+		}
+
+		result = append(result, copyTask(task))
+	}
+
+	sortTasks(result)
+	return result, nil
+}
+
+// FindByDateRangePaged возвращает страницу задач за период в заданном порядке
+// Total содержит число всех задач периода независимо от Limit и Offset
+func (r *InMemoryTaskRepository) FindByDateRangePaged(ctx context.Context, startDate, endDate time.Time, opts repositories.QueryOptions) (repositories.Page, error) {
+	if err := ctx.Err(); err != nil {
+		return repositories.Page{}, err
+	}
+
+	if err := validateQueryOptions(opts); err != nil {
+		return repositories.Page{}, err
+	}
+
+	tasks, err := r.FindByDateRange(ctx, startDate, endDate)
+	if err != nil {
+		return repositories.Page{}, err
+	}
+
+	sortTasksBy(tasks, opts.SortBy)
+
+	total := len(tasks)
+	from := min(opts.Offset, total)
+	to := total
+	if opts.Limit > 0 {
+		to = min(from+opts.Limit, total)
+	}
+
+	return repositories.Page{
+		Tasks: tasks[from:to],
+		Total: total,
+	}, nil
+}
+
+// Delete удаляет задачу или возвращает NotFoundError
+func (r *InMemoryTaskRepository) Delete(ctx context.Context, id entities.TaskEntryID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task, ok := r.tasks[id]
+	if !ok {
+		return errors.NewNotFoundError("task", string(id))
+	}
+
+	delete(r.tasks, id)
+	r.deleted[id] = deletedTask{task: task, deletedAt: r.clock.Now()}
+	return nil
+}
+
+// RestoreDeleted возвращает задачу из корзины или возвращает NotFoundError
+func (r *InMemoryTaskRepository) RestoreDeleted(ctx context.Context, id entities.TaskEntryID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.deleted[id]
+	if !ok {
+		return errors.NewNotFoundError("deleted task", string(id))
+	}
+
+	delete(r.deleted, id)
+	r.tasks[id] = entry.task
+	return nil
+}
+
+// FindDeleted возвращает копии задач из корзины, упорядоченные по дате
+func (r *InMemoryTaskRepository) FindDeleted(ctx context.Context) ([]*entities.TaskEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*entities.TaskEntry, 0, len(r.deleted))
+	for _, entry := range r.deleted {
+		result = append(result, copyTask(entry.task))
+	}
+
+	sortTasks(result)
+	return result, nil
+}
+
+// PurgeDeleted окончательно удаляет задачи, попавшие в корзину раньше olderThan
+func (r *InMemoryTaskRepository) PurgeDeleted(ctx context.Context, olderThan time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	purged := 0
+	for id, entry := range r.deleted {
+		if entry.deletedAt.Before(olderThan) {
+			delete(r.deleted, id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+// Exists проверяет наличие задачи
+func (r *InMemoryTaskRepository) Exists(ctx context.Context, id entities.TaskEntryID) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.tasks[id]
+	return ok, nil
+}
+
+// Count считает задачи с startDate по endDate включительно по календарным дням
+func (r *InMemoryTaskRepository) Count(ctx context.Context, startDate, endDate time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	guard := scanGuard{ctx: ctx}
+	count := 0
+	for _, task := range r.tasks {
+		if err := guard.check(); err != nil {
+			return 0, err
+		}
+
+		if withinDays(task.Date(), startDate, endDate) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// GetTaskCountByCategory считает задачи по категориям за период (включительно по дням)
+// Категории без задач в результат не попадают
+func (r *InMemoryTaskRepository) GetTaskCountByCategory(ctx context.Context, startDate, endDate time.Time) (map[string]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	guard := scanGuard{ctx: ctx}
+	counts := make(map[string]int)
+	for _, task := range r.tasks {
+		if err := guard.check(); err != nil {
+			return nil, err
+		}
+
+		if withinDays(task.Date(), startDate, endDate) {
+			counts[task.Category().String()]++
+		}
+	}
+
+	return counts, nil
+}
+
+// GetAverageStressReduction возвращает среднее снижение стресса за период
+// Учитываются только задачи с записанным стрессом после выполнения,
+// задачи без него не считаются нулевыми. Для пустой выборки возвращается 0, nil
+func (r *InMemoryTaskRepository) GetAverageStressReduction(ctx context.Context, startDate, endDate time.Time) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	guard := scanGuard{ctx: ctx}
+	var sum, count int
+	for _, task := range r.tasks {
+		if err := guard.check(); err != nil {
+			return 0, err
+		}
+
+		if !task.HasStressAfter() || !withinDays(task.Date(), startDate, endDate) {
+			continue
+		}
+		sum += task.CalculateStressReduction()
+		count++
+	}
+
+	if count == 0 {
+		return 0, nil
+	}
+
+	return float64(sum) / float64(count), nil
+}
+
+// ctxCheckInterval через сколько записей длинный обход хранилища проверяет отмену контекста
+const ctxCheckInterval = 256
+
+// scanGuard прерывает обход хранилища при отмене контекста (например, по таймауту
+// HTTP-обработчика), проверяя ctx.Err() не на каждой записи, а раз в ctxCheckInterval
+type scanGuard struct {
+	ctx     context.Context
+	scanned int
+}
+
+// check учитывает очередную запись и возвращает ошибку контекста, если пора проверять и он отменен
+func (g *scanGuard) check() error {
+	g.scanned++
+	if g.scanned%ctxCheckInterval != 0 {
+		return nil
+	}
+	return g.ctx.Err()
+}
+
+// copyTask создает независимую копию задачи без доменных событий: в хранилище они не нужны
+func copyTask(task *entities.TaskEntry) *entities.TaskEntry {
+	clone := task.Clone()
+	clone.ClearDomainEvents()
+	return clone
+}
+
+// sortTasks упорядочивает задачи по дате, а при равенстве по ID
+// (порядок обхода map в Go не определен)
+func sortTasks(tasks []*entities.TaskEntry) {
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].Date().Equal(tasks[j].Date()) {
+			return tasks[i].Date().Before(tasks[j].Date())
+		}
+		return tasks[i].ID() < tasks[j].ID()
+	})
+}
+
+// sortTasksBy упорядочивает задачи по выбранному полю
+// При равенстве сохраняется порядок sortTasks (дата, затем ID)
+func sortTasksBy(tasks []*entities.TaskEntry, sortBy repositories.TaskSortBy) {
+	switch sortBy {
+	case repositories.SortByDateDesc:
+		sort.SliceStable(tasks, func(i, j int) bool {
+			return tasks[i].Date().After(tasks[j].Date())
+		})
+	case repositories.SortByStressReduction:
+		// Задачи без stressAfter идут последними: их снижение не измерено
+		sort.SliceStable(tasks, func(i, j int) bool {
+			if tasks[i].HasStressAfter() != tasks[j].HasStressAfter() {
+				return tasks[i].HasStressAfter()
+			}
+			return tasks[i].CalculateStressReduction() > tasks[j].CalculateStressReduction()
+		})
+	case repositories.SortByDuration:
+		sort.SliceStable(tasks, func(i, j int) bool {
+			return tasks[i].ActiveDuration() > tasks[j].ActiveDuration()
+		})
+	}
+}
+
+// validateQueryOptions проверяет параметры пагинации до выполнения запроса
+func validateQueryOptions(opts repositories.QueryOptions) error {
+	if opts.Limit < 0 {
+		return errors.NewValidationError("limit", "cannot be negative")
+	}
+
+	if opts.Offset < 0 {
+		return errors.NewValidationError("offset", "cannot be negative")
+	}
+
+	if opts.SortBy < repositories.SortByDateAsc || opts.SortBy > repositories.SortByDuration {
+		return errors.NewValidationError("sortBy", "unknown sort order")
+	}
+
+	return nil
+}
+
+// withinDays проверяет, что дата попадает в диапазон календарных дней [start, end]
+func withinDays(date, start, end time.Time) bool {
+	from := startOfDay(start)
+	to := startOfDay(end).AddDate(0, 0, 1)
+	return !date.Before(from) && date.Before(to)
+}
+
+// matchesFilter проверяет задачу по всем заданным условиям фильтра
+func matchesFilter(task *entities.TaskEntry, filter repositories.TaskFilter) bool {
+	if filter.Category != nil && task.Category() != *filter.Category {
+		return false
+	}
+
+	if filter.StartDate != nil && task.Date().Before(startOfDay(*filter.StartDate)) {
+		return false
+	}
+
+	if filter.EndDate != nil && !task.Date().Before(startOfDay(*filter.EndDate).AddDate(0, 0, 1)) {
+		return false
+	}
+
+	if filter.MinStressReduction != nil {
+		if !task.HasStressAfter() || task.CalculateStressReduction() < *filter.MinStressReduction {
+			return false
+		}
+	}
+
+	if filter.StartedOnly && !task.Started() {
+		return false
+	}
+
+	return true
+}
+
+// startOfDay возвращает полночь того же календарного дня
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// checkTaskVersion отклоняет запись, загруженную не с той версии, что сохранена сейчас
+// Так правка поверх устаревшей версии получает ConflictError независимо от того,
+// сколько изменений в ней сделано, а неизмененная перечитанная запись сохраняется
+func checkTaskVersion(existing, incoming *entities.TaskEntry) error {
+	if existing != nil && incoming.LoadedVersion() != existing.Version() {
+		return errors.NewConflictError("task", string(incoming.ID()), existing.Version(), incoming.Version())
+	}
+	return nil
+}
@@ -0,0 +1,881 @@
+package memory
+
+import (
+	"context"
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/repositories"
+	"daily-tracker/internal/domain/valueobjects"
+	"daily-tracker/pkg/errors"
+	stderrors "errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestInMemoryTaskRepository_SaveAndFindByID(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	task := newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork)
+
+	if err := repo.Save(ctx, task); err != nil {
+		t.Fatalf("Expected no error on save, got: %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if found.KeyTask() != task.KeyTask() || !found.Date().Equal(task.Date()) {
+		t.Errorf("Expected stored task to match, got %+v", found.State())
+	}
+
+	exists, err := repo.Exists(ctx, "task-1")
+	if err != nil || !exists {
+		t.Errorf("Expected task to exist, got exists=%v err=%v", exists, err)
+	}
+}
+
+func TestInMemoryTaskRepository_StoresCopies(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	task := newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork)
+	repo.Save(ctx, task)
+
+	// Изменения исходной сущности после сохранения не попадают в хранилище
+	task.AddNotes("changed after save")
+
+	found, _ := repo.FindByID(ctx, "task-1")
+	if found.Notes() != "" {
+		t.Errorf("Expected stored notes to stay empty, got %q", found.Notes())
+	}
+
+	// Изменения возвращенной сущности тоже не попадают в хранилище
+	found.StartTask()
+
+	again, _ := repo.FindByID(ctx, "task-1")
+	if again.Started() {
+		t.Error("Expected stored task to stay unstarted")
+	}
+}
+
+func TestInMemoryTaskRepository_SaveBatch(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	date := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+
+	err := repo.SaveBatch(ctx, []*entities.TaskEntry{
+		newTask(t, "task-1", date, valueobjects.TaskCategoryWork),
+		newTask(t, "task-2", date, valueobjects.TaskCategoryStudy),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if count, _ := repo.Count(ctx, date, date); count != 2 {
+		t.Errorf("Expected 2 saved tasks, got %d", count)
+	}
+}
+
+func TestInMemoryTaskRepository_SaveBatch_InvalidLeavesStoreUnchanged(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	date := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+
+	existing := newTask(t, "task-1", date, valueobjects.TaskCategoryWork)
+	repo.Save(ctx, existing)
+
+	updated := newTask(t, "task-1", date, valueobjects.TaskCategoryWork)
+	updated.AddNotes("updated in batch")
+
+	err := repo.SaveBatch(ctx, []*entities.TaskEntry{
+		updated,
+		newTask(t, "task-2", date, valueobjects.TaskCategoryStudy),
+		nil,
+	})
+	if !errors.IsDomainError(err) {
+		t.Fatalf("Expected DomainError, got: %v", err)
+	}
+
+	if exists, _ := repo.Exists(ctx, "task-2"); exists {
+		t.Error("Expected task-2 not to be saved")
+	}
+
+	found, _ := repo.FindByID(ctx, "task-1")
+	if found.Notes() != "" {
+		t.Errorf("Expected task-1 to stay unchanged, got notes %q", found.Notes())
+	}
+}
+
+func TestInMemoryTaskRepository_Save_RejectsStaleVersion(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	repo.Save(ctx, newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork))
+
+	// Два клиента загружают одну и ту же версию и правят ее независимо
+	first, _ := repo.FindByID(ctx, "task-1")
+	second, _ := repo.FindByID(ctx, "task-1")
+	first.AddNotes("first")
+	second.AddNotes("second")
+
+	if err := repo.Save(ctx, first); err != nil {
+		t.Fatalf("Expected first save to succeed, got: %v", err)
+	}
+
+	err := repo.Save(ctx, second)
+	var conflictErr *errors.ConflictError
+	if !stderrors.As(err, &conflictErr) {
+		t.Fatalf("Expected ConflictError, got: %v", err)
+	}
+	if conflictErr.StoredVersion() != first.Version() || conflictErr.ActualVersion() != second.Version() {
+		t.Errorf("Expected versions %d/%d, got %d/%d",
+			first.Version(), second.Version(), conflictErr.StoredVersion(), conflictErr.ActualVersion())
+	}
+
+	found, _ := repo.FindByID(ctx, "task-1")
+	if found.Notes() != "first" {
+		t.Errorf("Expected first write to win, got notes %q", found.Notes())
+	}
+
+	// Перечитанная задача сохраняется после новой правки
+	found.AddNotes("second, retried")
+	if err := repo.Save(ctx, found); err != nil {
+		t.Errorf("Expected save after reload to succeed, got: %v", err)
+	}
+}
+
+func TestInMemoryTaskRepository_Save_StaleWriterWithMoreEdits(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	repo.Save(ctx, newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork))
+
+	winner, _ := repo.FindByID(ctx, "task-1")
+	stale, _ := repo.FindByID(ctx, "task-1")
+	winner.AddNotes("winner")
+	if err := repo.Save(ctx, winner); err != nil {
+		t.Fatalf("Expected winner save to succeed, got: %v", err)
+	}
+
+	// Устаревший клиент сделал больше правок, и его версия выше сохраненной
+	stale.AddNotes("stale")
+	stale.AddNotes("stale again")
+	if stale.Version() <= winner.Version() {
+		t.Fatalf("Expected stale version %d to exceed winner version %d", stale.Version(), winner.Version())
+	}
+
+	if err := repo.Save(ctx, stale); !errors.IsConflictError(err) {
+		t.Fatalf("Expected ConflictError, got: %v", err)
+	}
+
+	found, _ := repo.FindByID(ctx, "task-1")
+	if found.Notes() != "winner" {
+		t.Errorf("Expected winner edit to be kept, got notes %q", found.Notes())
+	}
+}
+
+func TestInMemoryTaskRepository_Save_UnchangedAndRepeated(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	task := newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork)
+	repo.Save(ctx, task)
+
+	loaded, _ := repo.FindByID(ctx, "task-1")
+	if err := repo.Save(ctx, loaded); err != nil {
+		t.Errorf("Expected unchanged task to be saved again, got: %v", err)
+	}
+
+	// Тот же экземпляр можно править и сохранять повторно
+	task.AddNotes("first")
+	if err := repo.Save(ctx, task); err != nil {
+		t.Fatalf("Expected first save of edit to succeed, got: %v", err)
+	}
+	task.AddNotes("second")
+	if err := repo.Save(ctx, task); err != nil {
+		t.Errorf("Expected repeated save to succeed, got: %v", err)
+	}
+}
+
+func TestInMemoryTaskRepository_SaveBatch_RejectsStaleVersion(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	date := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+	repo.Save(ctx, newTask(t, "task-1", date, valueobjects.TaskCategoryWork))
+
+	stale, _ := repo.FindByID(ctx, "task-1")
+	fresh, _ := repo.FindByID(ctx, "task-1")
+	fresh.AddNotes("fresh")
+	repo.Save(ctx, fresh)
+
+	stale.AddNotes("stale")
+	err := repo.SaveBatch(ctx, []*entities.TaskEntry{newTask(t, "task-2", date, valueobjects.TaskCategoryStudy), stale})
+	if !errors.IsConflictError(err) {
+		t.Fatalf("Expected ConflictError, got: %v", err)
+	}
+
+	if exists, _ := repo.Exists(ctx, "task-2"); exists {
+		t.Error("Expected task-2 not to be saved")
+	}
+}
+
+func TestInMemoryTaskRepository_NotFound(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+
+	if _, err := repo.FindByID(ctx, "missing"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected NotFoundError from FindByID, got %v", err)
+	}
+
+	if err := repo.Delete(ctx, "missing"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected NotFoundError from Delete, got %v", err)
+	}
+
+	exists, err := repo.Exists(ctx, "missing")
+	if err != nil || exists {
+		t.Errorf("Expected missing task to not exist, got exists=%v err=%v", exists, err)
+	}
+}
+
+func TestInMemoryTaskRepository_Delete(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	repo.Save(ctx, newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork))
+
+	if err := repo.Delete(ctx, "task-1"); err != nil {
+		t.Fatalf("Expected no error on delete, got: %v", err)
+	}
+
+	if _, err := repo.FindByID(ctx, "task-1"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected NotFoundError after delete, got %v", err)
+	}
+}
+
+func TestInMemoryTaskRepository_SoftDeleteAndRestore(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	day := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+	repo.Save(ctx, newTask(t, "task-1", day, valueobjects.TaskCategoryWork))
+	repo.Save(ctx, newTask(t, "task-2", day, valueobjects.TaskCategoryWork))
+
+	if err := repo.Delete(ctx, "task-1"); err != nil {
+		t.Fatalf("Expected no error on delete, got: %v", err)
+	}
+
+	tasks, _ := repo.FindByDateRange(ctx, day, day)
+	assertTaskIDs(t, tasks, []entities.TaskEntryID{"task-2"})
+
+	if exists, _ := repo.Exists(ctx, "task-1"); exists {
+		t.Error("Expected deleted task to be hidden from Exists")
+	}
+
+	deleted, _ := repo.FindDeleted(ctx)
+	assertTaskIDs(t, deleted, []entities.TaskEntryID{"task-1"})
+
+	if err := repo.RestoreDeleted(ctx, "task-1"); err != nil {
+		t.Fatalf("Expected no error on restore, got: %v", err)
+	}
+
+	tasks, _ = repo.FindByDateRange(ctx, day, day)
+	assertTaskIDs(t, tasks, []entities.TaskEntryID{"task-1", "task-2"})
+
+	deleted, _ = repo.FindDeleted(ctx)
+	assertTaskIDs(t, deleted, []entities.TaskEntryID{})
+
+	if err := repo.RestoreDeleted(ctx, "task-1"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected NotFoundError when restoring a live task, got %v", err)
+	}
+}
+
+func TestInMemoryTaskRepository_PurgeDeleted(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	clock := entities.NewFixedClock(time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC))
+	repo.clock = clock
+
+	day := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+	repo.Save(ctx, newTask(t, "old", day, valueobjects.TaskCategoryWork))
+	repo.Save(ctx, newTask(t, "recent", day, valueobjects.TaskCategoryWork))
+
+	repo.Delete(ctx, "old")
+	clock.Advance(48 * time.Hour)
+	repo.Delete(ctx, "recent")
+
+	purged, err := repo.PurgeDeleted(ctx, clock.Now().Add(-24*time.Hour))
+	if err != nil || purged != 1 {
+		t.Fatalf("Expected 1 purged task, got %d (err: %v)", purged, err)
+	}
+
+	deleted, _ := repo.FindDeleted(ctx)
+	assertTaskIDs(t, deleted, []entities.TaskEntryID{"recent"})
+
+	if err := repo.RestoreDeleted(ctx, "old"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected purged task to be gone, got %v", err)
+	}
+}
+
+func TestInMemoryTaskRepository_FindByDate_IgnoresTimeOfDay(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	repo.Save(ctx, newTask(t, "morning", time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork))
+	repo.Save(ctx, newTask(t, "night", time.Date(2025, 8, 12, 23, 59, 59, 0, time.UTC), valueobjects.TaskCategoryWork))
+	repo.Save(ctx, newTask(t, "next-day", time.Date(2025, 8, 13, 0, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork))
+
+	tasks, err := repo.FindByDate(ctx, time.Date(2025, 8, 12, 15, 30, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(tasks) != 2 || tasks[0].ID() != "morning" || tasks[1].ID() != "night" {
+		t.Errorf("Expected [morning night], got %v", taskIDs(tasks))
+	}
+}
+
+func TestInMemoryTaskRepository_FindByDateRange_Boundaries(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	for _, task := range []*entities.TaskEntry{
+		newTask(t, "aug-10-late", time.Date(2025, 8, 10, 23, 59, 0, 0, time.UTC), valueobjects.TaskCategoryWork),
+		newTask(t, "aug-11", time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork),
+		newTask(t, "aug-12", time.Date(2025, 8, 12, 12, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork),
+		newTask(t, "aug-13-late", time.Date(2025, 8, 13, 23, 59, 0, 0, time.UTC), valueobjects.TaskCategoryWork),
+		newTask(t, "aug-14", time.Date(2025, 8, 14, 0, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork),
+	} {
+		repo.Save(ctx, task)
+	}
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		expected []entities.TaskEntryID
+	}{
+		{
+			name:     "inclusive calendar days",
+			start:    time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2025, 8, 13, 0, 0, 0, 0, time.UTC),
+			expected: []entities.TaskEntryID{"aug-11", "aug-12", "aug-13-late"},
+		},
+		{
+			name:     "time of day on bounds is ignored",
+			start:    time.Date(2025, 8, 11, 18, 0, 0, 0, time.UTC),
+			end:      time.Date(2025, 8, 11, 6, 0, 0, 0, time.UTC),
+			expected: []entities.TaskEntryID{"aug-11"},
+		},
+		{
+			name:     "whole range",
+			start:    time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC),
+			expected: []entities.TaskEntryID{"aug-10-late", "aug-11", "aug-12", "aug-13-late", "aug-14"},
+		},
+		{
+			name:     "empty range",
+			start:    time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC),
+			expected: []entities.TaskEntryID{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := repo.FindByDateRange(ctx, tt.start, tt.end)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			ids := taskIDs(tasks)
+			if len(ids) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, ids)
+			}
+			for i := range ids {
+				if ids[i] != tt.expected[i] {
+					t.Errorf("Expected %v, got %v", tt.expected, ids)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestInMemoryTaskRepository_FindByDateRangePaged_LimitOffset(t *testing.T) {
+	ctx := context.Background()
+	repo := newPagedRepository(t)
+	start := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		opts     repositories.QueryOptions
+		expected []entities.TaskEntryID
+	}{
+		{"no limit", repositories.QueryOptions{}, []entities.TaskEntryID{"aug-11", "aug-12", "aug-13", "aug-14"}},
+		{"first page", repositories.QueryOptions{Limit: 2}, []entities.TaskEntryID{"aug-11", "aug-12"}},
+		{"last partial page", repositories.QueryOptions{Limit: 3, Offset: 3}, []entities.TaskEntryID{"aug-14"}},
+		{"offset at end", repositories.QueryOptions{Limit: 2, Offset: 4}, []entities.TaskEntryID{}},
+		{"offset past end", repositories.QueryOptions{Offset: 10}, []entities.TaskEntryID{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := repo.FindByDateRangePaged(ctx, start, end, tt.opts)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if page.Total != 4 {
+				t.Errorf("Expected total 4, got %d", page.Total)
+			}
+			assertTaskIDs(t, page.Tasks, tt.expected)
+		})
+	}
+}
+
+func TestInMemoryTaskRepository_FindByDateRangePaged_SortOrders(t *testing.T) {
+	ctx := context.Background()
+	repo := newPagedRepository(t)
+	start := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		sortBy   repositories.TaskSortBy
+		expected []entities.TaskEntryID
+	}{
+		{"date ascending", repositories.SortByDateAsc, []entities.TaskEntryID{"aug-11", "aug-12", "aug-13", "aug-14"}},
+		{"date descending", repositories.SortByDateDesc, []entities.TaskEntryID{"aug-14", "aug-13", "aug-12", "aug-11"}},
+		{"stress reduction", repositories.SortByStressReduction, []entities.TaskEntryID{"aug-13", "aug-11", "aug-14", "aug-12"}},
+		{"duration", repositories.SortByDuration, []entities.TaskEntryID{"aug-12", "aug-14", "aug-11", "aug-13"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := repo.FindByDateRangePaged(ctx, start, end, repositories.QueryOptions{SortBy: tt.sortBy})
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			assertTaskIDs(t, page.Tasks, tt.expected)
+		})
+	}
+}
+
+func TestInMemoryTaskRepository_FindByDateRangePaged_UnratedSortLast(t *testing.T) {
+	ctx := context.Background()
+	repo := newPagedRepository(t)
+
+	// Без stressAfter снижение равно stressBefore (7) и обогнало бы все оцененные задачи
+	repo.Save(ctx, newTask(t, "unrated", time.Date(2025, 8, 10, 9, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork))
+
+	start := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC)
+	page, err := repo.FindByDateRangePaged(ctx, start, end, repositories.QueryOptions{SortBy: repositories.SortByStressReduction})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	assertTaskIDs(t, page.Tasks, []entities.TaskEntryID{"aug-13", "aug-11", "aug-14", "aug-12", "unrated"})
+}
+
+func TestInMemoryTaskRepository_FindByDateRangePaged_InvalidOptions(t *testing.T) {
+	repo := NewInMemoryTaskRepository()
+	day := time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC)
+
+	for _, opts := range []repositories.QueryOptions{
+		{Limit: -1},
+		{Offset: -1},
+		{SortBy: repositories.TaskSortBy(42)},
+	} {
+		if _, err := repo.FindByDateRangePaged(context.Background(), day, day, opts); !errors.IsValidationError(err) {
+			t.Errorf("Expected ValidationError for %+v, got %v", opts, err)
+		}
+	}
+}
+
+func TestInMemoryTaskRepository_Count(t *testing.T) {
+	ctx := context.Background()
+	repo := newPagedRepository(t)
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		expected int
+	}{
+		{"inclusive bounds", time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC), time.Date(2025, 8, 13, 0, 0, 0, 0, time.UTC), 2},
+		{"whole month", time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC), 4},
+		{"empty range", time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := repo.Count(ctx, tt.start, tt.end)
+			if err != nil || count != tt.expected {
+				t.Errorf("Expected %d, nil, got %d, %v", tt.expected, count, err)
+			}
+		})
+	}
+
+	empty, err := NewInMemoryTaskRepository().Count(ctx, tests[1].start, tests[1].end)
+	if err != nil || empty != 0 {
+		t.Errorf("Expected 0, nil for empty repository, got %d, %v", empty, err)
+	}
+}
+
+func TestInMemoryTaskRepository_FindByCategory(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	for _, task := range []*entities.TaskEntry{
+		newTask(t, "work-1", time.Date(2025, 8, 11, 9, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork),
+		newTask(t, "study-1", time.Date(2025, 8, 11, 18, 0, 0, 0, time.UTC), valueobjects.TaskCategoryStudy),
+		newTask(t, "work-2", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork),
+		newTask(t, "work-3", time.Date(2025, 8, 20, 9, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork),
+		newTask(t, "health-1", time.Date(2025, 8, 12, 7, 0, 0, 0, time.UTC), valueobjects.TaskCategoryHealth),
+	} {
+		repo.Save(ctx, task)
+	}
+
+	start := time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		category valueobjects.TaskCategory
+		expected []entities.TaskEntryID
+	}{
+		{"work within range", valueobjects.TaskCategoryWork, []entities.TaskEntryID{"work-1", "work-2"}},
+		{"study", valueobjects.TaskCategoryStudy, []entities.TaskEntryID{"study-1"}},
+		{"health", valueobjects.TaskCategoryHealth, []entities.TaskEntryID{"health-1"}},
+		{"no match", valueobjects.TaskCategoryHobbies, []entities.TaskEntryID{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := repo.FindByCategory(ctx, tt.category, start, end)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			assertTaskIDs(t, tasks, tt.expected)
+		})
+	}
+}
+
+func TestInMemoryTaskRepository_FindByCategory_Invalid(t *testing.T) {
+	repo := NewInMemoryTaskRepository()
+	day := time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC)
+
+	_, err := repo.FindByCategory(context.Background(), valueobjects.TaskCategory("несуществующая"), day, day)
+	if !errors.IsValidationError(err) {
+		t.Errorf("Expected ValidationError, got %v", err)
+	}
+}
+
+func TestInMemoryTaskRepository_CancelledContext(t *testing.T) {
+	repo := NewInMemoryTaskRepository()
+	day := time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC)
+	repo.Save(context.Background(), newTask(t, "task-1", day, valueobjects.TaskCategoryWork))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tasks, err := repo.FindByDateRange(ctx, day, day)
+	if !stderrors.Is(err, context.Canceled) || tasks != nil {
+		t.Errorf("Expected context.Canceled and no tasks, got %v, %v", tasks, err)
+	}
+
+	if err := repo.Save(ctx, newTask(t, "task-2", day, valueobjects.TaskCategoryWork)); !stderrors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled from Save, got %v", err)
+	}
+
+	if exists, _ := repo.Exists(context.Background(), "task-2"); exists {
+		t.Error("Expected cancelled Save not to store the task")
+	}
+}
+
+func TestInMemoryTaskRepository_GetTaskCountByCategory(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	categories := []valueobjects.TaskCategory{
+		valueobjects.TaskCategoryWork,
+		valueobjects.TaskCategoryStudy,
+		valueobjects.TaskCategoryWork,
+		valueobjects.TaskCategoryHealth,
+		valueobjects.TaskCategoryWork,
+	}
+	for day, category := range categories {
+		date := time.Date(2025, 8, 11+day, 10, 0, 0, 0, time.UTC)
+		repo.Save(ctx, newTask(t, date.Format("task-2006-01-02"), date, category))
+	}
+
+	counts, err := repo.GetTaskCountByCategory(ctx,
+		time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := map[string]int{"работа": 3, "учеба": 1, "здоровье": 1}
+	if len(counts) != len(expected) {
+		t.Errorf("Expected %v, got %v", expected, counts)
+	}
+	for category, count := range expected {
+		if counts[category] != count {
+			t.Errorf("Expected %d tasks for %s, got %d", count, category, counts[category])
+		}
+	}
+
+	// Граница диапазона: только последний день
+	lastDay, _ := repo.GetTaskCountByCategory(ctx,
+		time.Date(2025, 8, 15, 23, 0, 0, 0, time.UTC),
+		time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC))
+	if len(lastDay) != 1 || lastDay["работа"] != 1 {
+		t.Errorf("Expected only one work task on Aug 15, got %v", lastDay)
+	}
+}
+
+func TestInMemoryTaskRepository_GetTaskCountByCategory_EmptyRange(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	repo.Save(ctx, newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork))
+
+	counts, err := repo.GetTaskCountByCategory(ctx,
+		time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if counts == nil || len(counts) != 0 {
+		t.Errorf("Expected empty map, got %v", counts)
+	}
+}
+
+func TestInMemoryTaskRepository_GetAverageStressReduction(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	day := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+
+	// stressBefore = 7 у всех задач (см. newTask)
+	for i, after := range []int{3, 6, -1, 0} {
+		task := newTask(t, fmt.Sprintf("task-%d", i), day, valueobjects.TaskCategoryWork)
+		if after >= 0 {
+			stressAfter, _ := valueobjects.NewStressLevel(after)
+			task.SetStressAfter(stressAfter)
+		}
+		repo.Save(ctx, task)
+	}
+
+	average, err := repo.GetAverageStressReduction(ctx, day, day)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// (4 + 1 + 7) / 3: задача без stressAfter не учитывается
+	if average != 4 {
+		t.Errorf("Expected average reduction 4, got %v", average)
+	}
+
+	empty, err := repo.GetAverageStressReduction(ctx, day.AddDate(0, 1, 0), day.AddDate(0, 1, 0))
+	if err != nil || empty != 0 {
+		t.Errorf("Expected 0, nil for empty range, got %v, %v", empty, err)
+	}
+}
+
+func TestInMemoryTaskRepository_FindByFilter(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+	for _, tc := range []struct {
+		id          string
+		day         int
+		category    valueobjects.TaskCategory
+		started     bool
+		stressAfter int
+	}{
+		{"work-started", 11, valueobjects.TaskCategoryWork, true, 3},
+		{"work-idle", 11, valueobjects.TaskCategoryWork, false, -1},
+		{"study-started", 12, valueobjects.TaskCategoryStudy, true, 6},
+		{"work-late", 12, valueobjects.TaskCategoryWork, true, 6},
+		{"work-outside", 20, valueobjects.TaskCategoryWork, true, 2},
+	} {
+		task := newTask(t, tc.id, time.Date(2025, 8, tc.day, 9, 0, 0, 0, time.UTC), tc.category)
+		if tc.started {
+			task.StartTask()
+		}
+		if tc.stressAfter >= 0 {
+			task.SetStressAfter(valueobjects.StressLevel(tc.stressAfter))
+		}
+		repo.Save(ctx, task)
+	}
+
+	work := valueobjects.TaskCategoryWork
+	start := time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC)
+	minReduction := 2
+
+	tests := []struct {
+		name     string
+		filter   repositories.TaskFilter
+		expected []entities.TaskEntryID
+	}{
+		{"zero filter", repositories.TaskFilter{},
+			[]entities.TaskEntryID{"work-idle", "work-started", "study-started", "work-late", "work-outside"}},
+		{"category and date range", repositories.TaskFilter{Category: &work, StartDate: &start, EndDate: &end},
+			[]entities.TaskEntryID{"work-idle", "work-started", "work-late"}},
+		{"category, date range and started only", repositories.TaskFilter{Category: &work, StartDate: &start, EndDate: &end, StartedOnly: true},
+			[]entities.TaskEntryID{"work-started", "work-late"}},
+		{"open-ended start", repositories.TaskFilter{StartDate: &end, StartedOnly: true},
+			[]entities.TaskEntryID{"study-started", "work-late", "work-outside"}},
+		{"min stress reduction", repositories.TaskFilter{Category: &work, MinStressReduction: &minReduction},
+			[]entities.TaskEntryID{"work-started", "work-outside"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := repo.FindByFilter(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			assertTaskIDs(t, tasks, tt.expected)
+		})
+	}
+}
+
+func TestInMemoryTaskRepository_FindByFilter_InvalidCategory(t *testing.T) {
+	category := valueobjects.TaskCategory("несуществующая")
+
+	_, err := NewInMemoryTaskRepository().FindByFilter(context.Background(), repositories.TaskFilter{Category: &category})
+	if !errors.IsValidationError(err) {
+		t.Errorf("Expected ValidationError, got %v", err)
+	}
+}
+
+func TestInMemoryTaskRepository_StatisticsCancelMidScan(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryTaskRepository()
+
+	// Год данных по 100 задач в день
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	tasks := make([]*entities.TaskEntry, 0, 365*100)
+	for day := 0; day < 365; day++ {
+		for i := 0; i < 100; i++ {
+			date := start.AddDate(0, 0, day)
+			tasks = append(tasks, newTask(t, fmt.Sprintf("task-%d-%d", day, i), date, valueobjects.TaskCategoryWork))
+		}
+	}
+	if err := repo.SaveBatch(ctx, tasks); err != nil {
+		t.Fatalf("Failed to seed repository: %v", err)
+	}
+
+	end := start.AddDate(1, 0, 0)
+	scans := []struct {
+		name string
+		run  func(ctx context.Context) error
+	}{
+		{"GetTaskCountByCategory", func(ctx context.Context) error {
+			_, err := repo.GetTaskCountByCategory(ctx, start, end)
+			return err
+		}},
+		{"GetAverageStressReduction", func(ctx context.Context) error {
+			_, err := repo.GetAverageStressReduction(ctx, start, end)
+			return err
+		}},
+		{"Count", func(ctx context.Context) error {
+			_, err := repo.Count(ctx, start, end)
+			return err
+		}},
+		{"FindByDateRange", func(ctx context.Context) error {
+			_, err := repo.FindByDateRange(ctx, start, end)
+			return err
+		}},
+	}
+
+	for _, scan := range scans {
+		t.Run(scan.name, func(t *testing.T) {
+			// Контекст отменяется на третьей проверке: до обхода и после двух порций записей
+			cancelling := &cancelAfterChecks{Context: ctx, cancelAt: 3}
+
+			err := scan.run(cancelling)
+			if !stderrors.Is(err, context.Canceled) {
+				t.Fatalf("Expected context.Canceled, got %v", err)
+			}
+
+			// Обход остановился сразу после отмены, а не дочитал все 36500 записей
+			if cancelling.checks != 3 {
+				t.Errorf("Expected scan to stop at the cancelling check, got %d checks", cancelling.checks)
+			}
+		})
+	}
+}
+
+// cancelAfterChecks контекст, который считается отмененным начиная с cancelAt-го вызова Err()
+// Позволяет детерминированно отменить обход на середине без таймеров
+type cancelAfterChecks struct {
+	context.Context
+	cancelAt int
+	checks   int
+}
+
+func (c *cancelAfterChecks) Err() error {
+	c.checks++
+	if c.checks >= c.cancelAt {
+		return context.Canceled
+	}
+	return nil
+}
+
+// Вспомогательная функция для создания задачи
+func newTask(t *testing.T, id string, date time.Time, category valueobjects.TaskCategory) *entities.TaskEntry {
+	t.Helper()
+
+	stressBefore, _ := valueobjects.NewStressLevel(7)
+	task, err := entities.NewTaskEntry(entities.TaskEntryID(id), date, 1, "Test task", category, stressBefore)
+	if err != nil {
+		t.Fatalf("Failed to create task entry: %v", err)
+	}
+
+	return task
+}
+
+func taskIDs(tasks []*entities.TaskEntry) []entities.TaskEntryID {
+	ids := make([]entities.TaskEntryID, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID())
+	}
+	return ids
+}
+
+// newPagedRepository заполняет репозиторий задачами с разным снижением стресса и длительностью
+func newPagedRepository(t *testing.T) *InMemoryTaskRepository {
+	t.Helper()
+
+	repo := NewInMemoryTaskRepository()
+	for _, tc := range []struct {
+		id          string
+		day         int
+		stressAfter int
+		duration    time.Duration
+	}{
+		{"aug-11", 11, 4, 30 * time.Minute},
+		{"aug-12", 12, 7, 90 * time.Minute},
+		{"aug-13", 13, 2, 10 * time.Minute},
+		{"aug-14", 14, 5, 60 * time.Minute},
+	} {
+		task := newTask(t, tc.id, time.Date(2025, 8, tc.day, 9, 0, 0, 0, time.UTC), valueobjects.TaskCategoryWork)
+		task.StartTask()
+		task.UpdateDuration(tc.duration)
+		task.SetStressAfter(valueobjects.StressLevel(tc.stressAfter))
+		repo.Save(context.Background(), task)
+	}
+	return repo
+}
+
+func assertTaskIDs(t *testing.T, tasks []*entities.TaskEntry, expected []entities.TaskEntryID) {
+	t.Helper()
+
+	ids := taskIDs(tasks)
+	if len(ids) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, ids)
+	}
+	for i := range ids {
+		if ids[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, ids)
+			return
+		}
+	}
+}
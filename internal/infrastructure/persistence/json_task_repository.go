@@ -0,0 +1,354 @@
+package persistence
+
+import (
+	"context"
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/repositories"
+	"daily-tracker/pkg/errors"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	_ repositories.TaskRepository       = (*JSONFileTaskRepository)(nil)
+	_ repositories.TaskReader           = (*JSONFileTaskRepository)(nil)
+	_ repositories.TaskBackupRepository = (*JSONFileTaskRepository)(nil)
+)
+
+// JSONFileTaskRepository хранит все задачи в одном JSON-файле
+// Данные загружаются в память при создании, а при каждом Save/Delete
+// файл перезаписывается целиком. Подходит для однопользовательского режима
+type JSONFileTaskRepository struct {
+	mu    sync.Mutex
+	path  string
+	tasks map[entities.TaskEntryID]entities.TaskEntryState
+}
+
+// NewJSONFileTaskRepository открывает репозиторий поверх файла
+// Отсутствующий файл означает пустое хранилище и будет создан при первой записи
+func NewJSONFileTaskRepository(path string) (*JSONFileTaskRepository, error) {
+	repo := &JSONFileTaskRepository{
+		path:  path,
+		tasks: make(map[entities.TaskEntryID]entities.TaskEntryState),
+	}
+
+	tasks, err := readStates(path)
+	if os.IsNotExist(err) {
+		return repo, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	repo.tasks = tasks
+	return repo, nil
+}
+
+// readStates читает и проверяет файл задач
+// Ошибка отсутствия файла возвращается без обертки, чтобы ее можно было проверить через os.IsNotExist
+func readStates(path string) (map[entities.TaskEntryID]entities.TaskEntryState, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read tasks file: %w", err)
+	}
+
+	var states []entities.TaskEntryState
+	if err := json.Unmarshal(data, &states); err != nil {
+		return nil, fmt.Errorf("decode tasks file: %w", err)
+	}
+
+	tasks := make(map[entities.TaskEntryID]entities.TaskEntryState, len(states))
+	for _, state := range states {
+		// Прогоняем через конструктор, чтобы не загрузить невалидные записи
+		if _, err := entities.ReconstructTaskEntry(state); err != nil {
+			return nil, fmt.Errorf("task %s: %w", state.ID, err)
+		}
+		tasks[state.ID] = state
+	}
+
+	return tasks, nil
+}
+
+// Save сохраняет задачу и атомарно перезаписывает файл
+// При ошибке записи состояние в памяти не меняется
+func (r *JSONFileTaskRepository) Save(ctx context.Context, task *entities.TaskEntry) error {
+	if task == nil {
+		return errors.NewDomainError("task cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state := task.State()
+	if err := checkStateVersion(r.tasks, state, task.LoadedVersion()); err != nil {
+		return err
+	}
+
+	next := r.cloneTasks()
+	next[task.ID()] = state
+
+	if err := r.writeFile(next); err != nil {
+		return err
+	}
+
+	r.tasks = next
+	task.MarkPersisted()
+	return nil
+}
+
+// SaveBatch сохраняет все задачи одной атомарной перезаписью файла
+// При ошибке проверки или записи ни одна задача не сохраняется
+func (r *JSONFileTaskRepository) SaveBatch(ctx context.Context, tasks []*entities.TaskEntry) error {
+	for i, task := range tasks {
+		if task == nil {
+			return errors.NewDomainError(fmt.Sprintf("task at index %d cannot be nil", i))
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next := r.cloneTasks()
+	for _, task := range tasks {
+		state := task.State()
+		if err := checkStateVersion(next, state, task.LoadedVersion()); err != nil {
+			return err
+		}
+		next[task.ID()] = state
+	}
+
+	if err := r.writeFile(next); err != nil {
+		return err
+	}
+
+	r.tasks = next
+	for _, task := range tasks {
+		task.MarkPersisted()
+	}
+	return nil
+}
+
+// FindByID возвращает задачу или NotFoundError
+func (r *JSONFileTaskRepository) FindByID(ctx context.Context, id entities.TaskEntryID) (*entities.TaskEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, ok := r.tasks[id]
+	if !ok {
+		return nil, errors.NewNotFoundError("task", string(id))
+	}
+
+	return entities.ReconstructTaskEntry(state)
+}
+
+// FindByDate возвращает задачи за календарный день
+func (r *JSONFileTaskRepository) FindByDate(ctx context.Context, date time.Time) ([]*entities.TaskEntry, error) {
+	return r.FindByDateRange(ctx, date, date)
+}
+
+// FindByDateRange возвращает задачи с startDate по endDate включительно по календарным дням
+func (r *JSONFileTaskRepository) FindByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*entities.TaskEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	from := startOfDay(startDate)
+	to := startOfDay(endDate).AddDate(0, 0, 1)
+
+	result := make([]*entities.TaskEntry, 0)
+	for _, state := range sortedStates(r.tasks) {
+		if state.Date.Before(from) || !state.Date.Before(to) {
+			continue
+		}
+
+		task, err := entities.ReconstructTaskEntry(state)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, task)
+	}
+
+	return result, nil
+}
+
+// Count считает задачи с startDate по endDate включительно по календарным дням
+// Работает по сохраненным состояниям без восстановления сущностей
+func (r *JSONFileTaskRepository) Count(ctx context.Context, startDate, endDate time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	from := startOfDay(startDate)
+	to := startOfDay(endDate).AddDate(0, 0, 1)
+
+	count := 0
+	for _, state := range r.tasks {
+		if !state.Date.Before(from) && state.Date.Before(to) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// Delete удаляет задачу и атомарно перезаписывает файл
+func (r *JSONFileTaskRepository) Delete(ctx context.Context, id entities.TaskEntryID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tasks[id]; !ok {
+		return errors.NewNotFoundError("task", string(id))
+	}
+
+	next := r.cloneTasks()
+	delete(next, id)
+
+	if err := r.writeFile(next); err != nil {
+		return err
+	}
+
+	r.tasks = next
+	return nil
+}
+
+// Exists проверяет наличие задачи
+func (r *JSONFileTaskRepository) Exists(ctx context.Context, id entities.TaskEntryID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.tasks[id]
+	return ok, nil
+}
+
+// Flush принудительно записывает текущее состояние в файл
+func (r *JSONFileTaskRepository) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.writeFile(r.tasks)
+}
+
+// Backup атомарно записывает все задачи в файл filePath в том же формате, что и основной файл
+func (r *JSONFileTaskRepository) Backup(ctx context.Context, filePath string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return writeStates(filePath, r.tasks)
+}
+
+// Restore заменяет все задачи содержимым резервной копии filePath
+// Файл сначала целиком читается и проверяется; при любой ошибке
+// ни основной файл, ни данные в памяти не меняются
+func (r *JSONFileTaskRepository) Restore(ctx context.Context, filePath string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	restored, err := readStates(filePath)
+	if err != nil {
+		return fmt.Errorf("restore from %s: %w", filePath, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.writeFile(restored); err != nil {
+		return err
+	}
+
+	r.tasks = restored
+	return nil
+}
+
+// writeFile атомарно перезаписывает основной файл репозитория
+func (r *JSONFileTaskRepository) writeFile(tasks map[entities.TaskEntryID]entities.TaskEntryState) error {
+	return writeStates(r.path, tasks)
+}
+
+// writeStates атомарно записывает задачи: сначала во временный файл в той же
+// директории, затем os.Rename поверх целевого. Сбой посередине не оставит
+// обрезанный файл — исходный останется нетронутым
+func writeStates(path string, tasks map[entities.TaskEntryID]entities.TaskEntryState) (err error) {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+
+	// Удаляем временный файл при любой ошибке
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	encoder := json.NewEncoder(tmp)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(sortedStates(tasks)); err != nil {
+		return fmt.Errorf("encode tasks: %w", err)
+	}
+
+	if err = tmp.Sync(); err != nil {
+		return fmt.Errorf("sync temp file: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("replace tasks file: %w", err)
+	}
+
+	return nil
+}
+
+// cloneTasks копирует карту состояний, чтобы изменения применялись только после успешной записи
+func (r *JSONFileTaskRepository) cloneTasks() map[entities.TaskEntryID]entities.TaskEntryState {
+	clone := make(map[entities.TaskEntryID]entities.TaskEntryState, len(r.tasks))
+	for id, state := range r.tasks {
+		clone[id] = state
+	}
+	return clone
+}
+
+// checkStateVersion отклоняет состояние, загруженное не с той версии, что сохранена сейчас
+func checkStateVersion(tasks map[entities.TaskEntryID]entities.TaskEntryState, state entities.TaskEntryState, loadedVersion int) error {
+	if existing, ok := tasks[state.ID]; ok && loadedVersion != existing.Version {
+		return errors.NewConflictError("task", string(state.ID), existing.Version, state.Version)
+	}
+	return nil
+}
+
+// sortedStates возвращает состояния в детерминированном порядке: по дате, затем по ID
+func sortedStates(tasks map[entities.TaskEntryID]entities.TaskEntryState) []entities.TaskEntryState {
+	states := make([]entities.TaskEntryState, 0, len(tasks))
+	for _, state := range tasks {
+		states = append(states, state)
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		if !states[i].Date.Equal(states[j].Date) {
+			return states[i].Date.Before(states[j].Date)
+		}
+		return states[i].ID < states[j].ID
+	})
+
+	return states
+}
+
+// startOfDay возвращает полночь того же календарного дня
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,339 @@
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"daily-tracker/pkg/errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONFileTaskRepository_PersistsAcrossInstances(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "tasks.json")
+
+	repo, err := NewJSONFileTaskRepository(path)
+	if err != nil {
+		t.Fatalf("Expected no error for missing file, got: %v", err)
+	}
+
+	task := newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC))
+	task.StartTask()
+	task.AddNotes("Отвлекся на почту, 5 мин")
+	if err := repo.Save(ctx, task); err != nil {
+		t.Fatalf("Expected no error on save, got: %v", err)
+	}
+	repo.Save(ctx, newTask(t, "task-2", time.Date(2025, 8, 13, 9, 0, 0, 0, time.UTC)))
+
+	reopened, err := NewJSONFileTaskRepository(path)
+	if err != nil {
+		t.Fatalf("Expected no error on reopen, got: %v", err)
+	}
+
+	found, err := reopened.FindByID(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !found.Started() || found.Notes() != task.Notes() {
+		t.Errorf("Expected persisted state, got started=%v notes=%q", found.Started(), found.Notes())
+	}
+
+	if len(found.DomainEvents()) != 0 {
+		t.Errorf("Expected no domain events on loaded task, got %d", len(found.DomainEvents()))
+	}
+
+	tasks, _ := reopened.FindByDate(ctx, time.Date(2025, 8, 13, 0, 0, 0, 0, time.UTC))
+	if len(tasks) != 1 || tasks[0].ID() != "task-2" {
+		t.Errorf("Expected task-2 for Aug 13, got %d tasks", len(tasks))
+	}
+}
+
+func TestJSONFileTaskRepository_DeletePersists(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	repo, _ := NewJSONFileTaskRepository(path)
+	repo.Save(ctx, newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)))
+
+	if err := repo.Delete(ctx, "task-1"); err != nil {
+		t.Fatalf("Expected no error on delete, got: %v", err)
+	}
+
+	if err := repo.Delete(ctx, "task-1"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected NotFoundError on second delete, got %v", err)
+	}
+
+	reopened, _ := NewJSONFileTaskRepository(path)
+	if exists, _ := reopened.Exists(ctx, "task-1"); exists {
+		t.Error("Expected deleted task to stay deleted after reopen")
+	}
+}
+
+func TestJSONFileTaskRepository_FailedWriteKeepsOriginal(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tasks.json")
+	repo, _ := NewJSONFileTaskRepository(path)
+	repo.Save(ctx, newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)))
+
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read tasks file: %v", err)
+	}
+
+	// time.Time не сериализуется для годов за пределами 9999,
+	// поэтому кодирование упадет посреди записи временного файла
+	// Часы переводятся туда же, иначе конструктор отклонит дату из будущего
+	farFuture := time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)
+	restore := entities.SetClock(entities.NewFixedClock(farFuture))
+	broken := newTask(t, "broken", farFuture)
+	restore()
+	if err := repo.Save(ctx, broken); err == nil {
+		t.Fatal("Expected marshal error, got nil")
+	}
+
+	current, _ := os.ReadFile(path)
+	if !bytes.Equal(original, current) {
+		t.Error("Expected original file to survive a failed write")
+	}
+
+	files, _ := os.ReadDir(dir)
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), ".tmp") {
+			t.Errorf("Expected temp file to be cleaned up, found %s", file.Name())
+		}
+	}
+
+	// Состояние в памяти тоже не должно измениться
+	if exists, _ := repo.Exists(ctx, "broken"); exists {
+		t.Error("Expected failed save to leave in-memory state unchanged")
+	}
+}
+
+func TestJSONFileTaskRepository_Count(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := NewJSONFileTaskRepository(filepath.Join(t.TempDir(), "tasks.json"))
+
+	start := time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 8, 13, 0, 0, 0, 0, time.UTC)
+
+	if count, err := repo.Count(ctx, start, end); err != nil || count != 0 {
+		t.Errorf("Expected 0, nil for empty repository, got %d, %v", count, err)
+	}
+
+	repo.Save(ctx, newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)))
+	repo.Save(ctx, newTask(t, "task-2", time.Date(2025, 8, 13, 23, 59, 0, 0, time.UTC)))
+	repo.Save(ctx, newTask(t, "task-3", time.Date(2025, 8, 14, 0, 0, 0, 0, time.UTC)))
+
+	if count, err := repo.Count(ctx, start, end); err != nil || count != 2 {
+		t.Errorf("Expected 2, nil, got %d, %v", count, err)
+	}
+}
+
+func TestJSONFileTaskRepository_SaveBatch_InvalidLeavesStoreUnchanged(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	repo, _ := NewJSONFileTaskRepository(path)
+	date := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+
+	if err := repo.SaveBatch(ctx, []*entities.TaskEntry{newTask(t, "task-1", date), newTask(t, "task-2", date)}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	err := repo.SaveBatch(ctx, []*entities.TaskEntry{newTask(t, "task-3", date), nil})
+	if !errors.IsDomainError(err) {
+		t.Fatalf("Expected DomainError, got: %v", err)
+	}
+
+	reopened, _ := NewJSONFileTaskRepository(path)
+	if count, _ := reopened.Count(ctx, date, date); count != 2 {
+		t.Errorf("Expected only the first batch to be persisted, got %d tasks", count)
+	}
+}
+
+func TestJSONFileTaskRepository_Save_RejectsStaleVersion(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	repo, _ := NewJSONFileTaskRepository(path)
+	repo.Save(ctx, newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)))
+
+	first, _ := repo.FindByID(ctx, "task-1")
+	second, _ := repo.FindByID(ctx, "task-1")
+	first.AddNotes("first")
+	second.AddNotes("second")
+
+	if err := repo.Save(ctx, first); err != nil {
+		t.Fatalf("Expected first save to succeed, got: %v", err)
+	}
+
+	if err := repo.Save(ctx, second); !errors.IsConflictError(err) {
+		t.Fatalf("Expected ConflictError, got: %v", err)
+	}
+
+	reopened, _ := NewJSONFileTaskRepository(path)
+	found, _ := reopened.FindByID(ctx, "task-1")
+	if found.Notes() != "first" || found.Version() != first.Version() {
+		t.Errorf("Expected first write with version %d, got notes %q version %d", first.Version(), found.Notes(), found.Version())
+	}
+}
+
+func TestJSONFileTaskRepository_Save_StaleWriterWithMoreEdits(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := NewJSONFileTaskRepository(filepath.Join(t.TempDir(), "tasks.json"))
+	repo.Save(ctx, newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)))
+
+	winner, _ := repo.FindByID(ctx, "task-1")
+	stale, _ := repo.FindByID(ctx, "task-1")
+	winner.AddNotes("winner")
+	if err := repo.Save(ctx, winner); err != nil {
+		t.Fatalf("Expected winner save to succeed, got: %v", err)
+	}
+
+	stale.AddNotes("stale")
+	stale.AddNotes("stale again")
+	if err := repo.SaveBatch(ctx, []*entities.TaskEntry{stale}); !errors.IsConflictError(err) {
+		t.Fatalf("Expected ConflictError from batch, got: %v", err)
+	}
+	if err := repo.Save(ctx, stale); !errors.IsConflictError(err) {
+		t.Fatalf("Expected ConflictError, got: %v", err)
+	}
+
+	found, _ := repo.FindByID(ctx, "task-1")
+	if found.Notes() != "winner" {
+		t.Errorf("Expected winner edit to be kept, got notes %q", found.Notes())
+	}
+}
+
+func TestJSONFileTaskRepository_Save_Unchanged(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := NewJSONFileTaskRepository(filepath.Join(t.TempDir(), "tasks.json"))
+	repo.Save(ctx, newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)))
+
+	loaded, _ := repo.FindByID(ctx, "task-1")
+	if err := repo.Save(ctx, loaded); err != nil {
+		t.Errorf("Expected unchanged task to be saved again, got: %v", err)
+	}
+
+	loaded.AddNotes("edit")
+	if err := repo.Save(ctx, loaded); err != nil {
+		t.Errorf("Expected save after edit to succeed, got: %v", err)
+	}
+}
+
+func TestJSONFileTaskRepository_Flush(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	repo, _ := NewJSONFileTaskRepository(path)
+
+	if err := repo.Flush(ctx); err != nil {
+		t.Fatalf("Expected no error on flush, got: %v", err)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Expected file to exist after flush, got: %v", err)
+	}
+}
+
+// Вспомогательная функция для создания задачи
+func newTask(t *testing.T, id string, date time.Time) *entities.TaskEntry {
+	t.Helper()
+
+	stressBefore, _ := valueobjects.NewStressLevel(7)
+	task, err := entities.NewTaskEntry(entities.TaskEntryID(id), date, 1, "Test task", valueobjects.TaskCategoryWork, stressBefore)
+	if err != nil {
+		t.Fatalf("Failed to create task entry: %v", err)
+	}
+
+	return task
+}
+
+func TestJSONFileTaskRepository_BackupRestoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	repo, _ := NewJSONFileTaskRepository(filepath.Join(dir, "tasks.json"))
+
+	task := newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC))
+	task.StartTask()
+	task.AddNotes("до бэкапа")
+	repo.Save(ctx, task)
+	repo.Save(ctx, newTask(t, "task-2", time.Date(2025, 8, 13, 9, 0, 0, 0, time.UTC)))
+
+	backupPath := filepath.Join(dir, "backup.json")
+	if err := repo.Backup(ctx, backupPath); err != nil {
+		t.Fatalf("Expected no error on backup, got: %v", err)
+	}
+
+	// Очищаем хранилище и добавляем задачу, которой нет в копии
+	repo.Delete(ctx, "task-1")
+	repo.Delete(ctx, "task-2")
+	repo.Save(ctx, newTask(t, "task-3", time.Date(2025, 8, 14, 9, 0, 0, 0, time.UTC)))
+
+	if err := repo.Restore(ctx, backupPath); err != nil {
+		t.Fatalf("Expected no error on restore, got: %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("Expected restored task-1, got: %v", err)
+	}
+	if !found.Started() || found.Notes() != "до бэкапа" {
+		t.Errorf("Expected restored state, got started=%v notes=%q", found.Started(), found.Notes())
+	}
+
+	if exists, _ := repo.Exists(ctx, "task-3"); exists {
+		t.Error("Expected restore to replace current contents")
+	}
+
+	// Восстановленные данные записаны и в основной файл
+	reopened, _ := NewJSONFileTaskRepository(filepath.Join(dir, "tasks.json"))
+	if exists, _ := reopened.Exists(ctx, "task-2"); !exists {
+		t.Error("Expected restored task-2 to be persisted")
+	}
+}
+
+func TestJSONFileTaskRepository_RestoreCorruptFileKeepsData(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tasks.json")
+	repo, _ := NewJSONFileTaskRepository(path)
+	repo.Save(ctx, newTask(t, "task-1", time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)))
+
+	before, _ := os.ReadFile(path)
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"malformed json", `[{"id": "task-9",`},
+		{"invalid entry", `[{"id": "task-9", "key_task": "", "day_number": 1}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backupPath := filepath.Join(dir, "corrupt.json")
+			os.WriteFile(backupPath, []byte(tt.content), 0o644)
+
+			if err := repo.Restore(ctx, backupPath); err == nil {
+				t.Fatal("Expected error for corrupt backup, got nil")
+			}
+
+			if exists, _ := repo.Exists(ctx, "task-1"); !exists {
+				t.Error("Expected existing data to survive failed restore")
+			}
+
+			after, _ := os.ReadFile(path)
+			if !bytes.Equal(before, after) {
+				t.Error("Expected tasks file to stay unchanged")
+			}
+		})
+	}
+
+	if err := repo.Restore(ctx, filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected error for missing backup file")
+	}
+}
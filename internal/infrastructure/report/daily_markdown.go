@@ -0,0 +1,70 @@
+package report
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RenderDailyMarkdown формирует раздел дневника в Markdown:
+// таблицу со сводкой сна и список задач дня
+// Если данных о сне нет (sleep == nil), вместо таблицы выводится пометка
+func RenderDailyMarkdown(date time.Time, tasks []*entities.TaskEntry, sleep *entities.SleepEntry) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "## %s\n\n", date.Format("2006-01-02"))
+
+	b.WriteString("### Sleep\n\n")
+	if sleep == nil {
+		b.WriteString("_No sleep data._\n")
+	} else {
+		b.WriteString("| Hours | Quality | Awakenings |\n")
+		b.WriteString("|------:|--------:|-----------:|\n")
+		fmt.Fprintf(&b, "| %.1f | %d/10 | %d |\n",
+			sleep.TotalSleepHours(), sleep.SleepQuality().Int(), sleep.NightAwakenings())
+	}
+
+	b.WriteString("\n### Tasks\n\n")
+	if len(tasks) == 0 {
+		b.WriteString("_No tasks._\n")
+		return b.String()
+	}
+
+	for _, task := range tasks {
+		if task == nil {
+			continue
+		}
+		fmt.Fprintf(&b, "- [%s] **%s** (%s): %s, stress reduction %s\n",
+			checkbox(task.IsCompleted()),
+			task.KeyTask(),
+			task.Category(),
+			formatMinutes(task.ActiveDuration()),
+			formatStressReduction(task),
+		)
+	}
+
+	return b.String()
+}
+
+// checkbox отмечает выполненную задачу в списке Markdown
+func checkbox(done bool) string {
+	if done {
+		return "x"
+	}
+	return " "
+}
+
+// formatMinutes выводит длительность в целых минутах
+func formatMinutes(d time.Duration) string {
+	return fmt.Sprintf("%d min", int(d.Round(time.Minute).Minutes()))
+}
+
+// formatStressReduction выводит снижение стресса со знаком
+// Пока уровень стресса после не записан, снижение неизвестно
+func formatStressReduction(task *entities.TaskEntry) string {
+	if !task.HasStressAfter() {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+d", task.CalculateStressReduction())
+}
@@ -0,0 +1,91 @@
+package report
+
+import (
+	"daily-tracker/internal/domain/entities"
+	"daily-tracker/internal/domain/valueobjects"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// update перезаписывает эталонные файлы: go test ./internal/infrastructure/report -update
+var update = flag.Bool("update", false, "update golden files")
+
+func TestRenderDailyMarkdown_Golden(t *testing.T) {
+	date := time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC)
+	defer entities.SetClock(entities.NewFixedClock(date.Add(9 * time.Hour)))()
+
+	report := newTask(t, "task-1", date, "Написать отчет по проекту", valueobjects.TaskCategoryWork)
+	report.StartTask()
+	report.UpdateDuration(30 * time.Minute)
+	report.SetStressAfter(valueobjects.StressLevel(4))
+	report.CompleteTask()
+
+	reading := newTask(t, "task-2", date, "Прочитать главу", valueobjects.TaskCategoryStudy)
+
+	night := time.Date(2025, 8, 12, 0, 30, 0, 0, time.UTC)
+	sleep, err := entities.ReconstructSleepEntry(entities.SleepEntryState{
+		ID:              "sleep-1",
+		Date:            date,
+		Bedtime:         night,
+		WakeTime:        night.Add(7*time.Hour + 30*time.Minute),
+		NightAwakenings: 2,
+		TotalSleepHours: 7.5,
+		SleepQuality:    6,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create sleep entry: %v", err)
+	}
+
+	got := RenderDailyMarkdown(date, []*entities.TaskEntry{report, reading}, sleep)
+
+	assertGolden(t, "daily_report.golden.md", got)
+}
+
+func TestRenderDailyMarkdown_NoSleepData(t *testing.T) {
+	date := time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC)
+
+	got := RenderDailyMarkdown(date, nil, nil)
+
+	if !strings.Contains(got, "No sleep data") || !strings.Contains(got, "No tasks") {
+		t.Errorf("Expected notes about missing data, got:\n%s", got)
+	}
+
+	if strings.Contains(got, "| Hours |") {
+		t.Errorf("Expected no sleep table without sleep data, got:\n%s", got)
+	}
+}
+
+// assertGolden сравнивает результат с эталонным файлом из testdata
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+
+	if got != string(want) {
+		t.Errorf("Output differs from %s:\n--- want\n%s\n--- got\n%s", path, want, got)
+	}
+}
+
+func newTask(t *testing.T, id string, date time.Time, keyTask string, category valueobjects.TaskCategory) *entities.TaskEntry {
+	t.Helper()
+
+	task, err := entities.NewTaskEntry(entities.TaskEntryID(id), date, 1, keyTask, category, 8)
+	if err != nil {
+		t.Fatalf("Failed to create task entry: %v", err)
+	}
+	return task
+}
@@ -1,22 +1,49 @@
 package errors
 
-import "fmt"
+import (
+	stderrors "errors"
+	"fmt"
+)
+
+// Сигнальные значения для проверки вида ошибки через errors.Is:
+//
+//	if errors.Is(err, errors.ErrNotFound) { ... }
+var (
+	ErrDomain     = stderrors.New("domain error")
+	ErrValidation = stderrors.New("validation error")
+	ErrNotFound   = stderrors.New("not found")
+	ErrConflict   = stderrors.New("conflict")
+)
 
 // DomainError представляет ошибку на уровне домена
 // В Go ошибки - это значения, а не исключения как в PHP
 type DomainError struct {
 	message string
-	code    string
+	code    ErrorCode
+	cause   error // Исходная ошибка (может быть nil)
 }
 
 // Error реализует интерфейс error (встроенный в Go)
 func (de *DomainError) Error() string {
+	if de.cause != nil {
+		return de.message + ": " + de.cause.Error()
+	}
 	return de.message
 }
 
-// Code возвращает код ошибки
+// Unwrap возвращает исходную ошибку для errors.Is/errors.As
+func (de *DomainError) Unwrap() error {
+	return de.cause
+}
+
+// Is позволяет сравнивать с ErrDomain через errors.Is
+func (de *DomainError) Is(target error) bool {
+	return target == ErrDomain
+}
+
+// Code возвращает строковую форму кода ошибки
 func (de *DomainError) Code() string {
-	return de.code
+	return string(de.code)
 }
 
 // Message возвращает сообщение ошибки
@@ -28,15 +55,35 @@ func (de *DomainError) Message() string {
 func NewDomainError(message string) *DomainError {
 	return &DomainError{
 		message: message,
-		code:    "DOMAIN_ERROR",
+		code:    CodeDomainError,
+	}
+}
+
+// NewDomainErrorWrap создает доменную ошибку, оборачивающую исходную
+func NewDomainErrorWrap(message string, cause error) *DomainError {
+	return &DomainError{
+		message: message,
+		code:    CodeDomainError,
+		cause:   cause,
 	}
 }
 
 // NewDomainErrorWithCode создает доменную ошибку с кодом
-func NewDomainErrorWithCode(message, code string) *DomainError {
+func NewDomainErrorWithCode(message string, code ErrorCode) *DomainError {
+	return &DomainError{
+		message: message,
+		code:    code,
+	}
+}
+
+// NewDomainErrorWithCodeWrap создает доменную ошибку с кодом, оборачивающую исходную
+// Например, ошибку валидации с отклоненным значением: errors.As достает ее из цепочки,
+// а errors.Is(err, ErrDomain) остается истинным
+func NewDomainErrorWithCodeWrap(message string, code ErrorCode, cause error) *DomainError {
 	return &DomainError{
 		message: message,
 		code:    code,
+		cause:   cause,
 	}
 }
 
@@ -44,12 +91,21 @@ func NewDomainErrorWithCode(message, code string) *DomainError {
 type ValidationError struct {
 	field   string
 	message string
+	value   interface{} // Отклоненное значение (nil, если не передано)
 }
 
 func (ve *ValidationError) Error() string {
+	if ve.value != nil {
+		return fmt.Sprintf("validation error for field '%s': %s (got %v)", ve.field, ve.message, ve.value)
+	}
 	return fmt.Sprintf("validation error for field '%s': %s", ve.field, ve.message)
 }
 
+// Is позволяет сравнивать с ErrValidation через errors.Is
+func (ve *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
 func (ve *ValidationError) Field() string {
 	return ve.field
 }
@@ -58,6 +114,11 @@ func (ve *ValidationError) Message() string {
 	return ve.message
 }
 
+// Value возвращает отклоненное значение или nil, если оно не было передано
+func (ve *ValidationError) Value() interface{} {
+	return ve.value
+}
+
 // NewValidationError создает ошибку валидации
 func NewValidationError(field, message string) *ValidationError {
 	return &ValidationError{
@@ -66,6 +127,15 @@ func NewValidationError(field, message string) *ValidationError {
 	}
 }
 
+// NewValidationErrorWithValue создает ошибку валидации с отклоненным значением для логов
+func NewValidationErrorWithValue(field, message string, value interface{}) *ValidationError {
+	return &ValidationError{
+		field:   field,
+		message: message,
+		value:   value,
+	}
+}
+
 // NotFoundError представляет ошибку "не найдено"
 type NotFoundError struct {
 	resource string
@@ -76,6 +146,11 @@ func (nfe *NotFoundError) Error() string {
 	return fmt.Sprintf("%s with id '%s' not found", nfe.resource, nfe.id)
 }
 
+// Is позволяет сравнивать с ErrNotFound через errors.Is
+func (nfe *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
 func (nfe *NotFoundError) Resource() string {
 	return nfe.resource
 }
@@ -92,20 +167,69 @@ func NewNotFoundError(resource, id string) *NotFoundError {
 	}
 }
 
-// IsDomainError проверяет, является ли ошибка доменной
+// ConflictError представляет конфликт версий при оптимистичной блокировке:
+// запись была изменена другим процессом после загрузки
+type ConflictError struct {
+	resource      string
+	id            string
+	storedVersion int
+	actualVersion int
+}
+
+func (ce *ConflictError) Error() string {
+	return fmt.Sprintf("%s with id '%s' was modified concurrently: stored version %d, got %d",
+		ce.resource, ce.id, ce.storedVersion, ce.actualVersion)
+}
+
+// Is позволяет сравнивать с ErrConflict через errors.Is
+func (ce *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+func (ce *ConflictError) Resource() string {
+	return ce.resource
+}
+
+func (ce *ConflictError) ID() string {
+	return ce.id
+}
+
+// StoredVersion возвращает версию, уже сохраненную в хранилище
+func (ce *ConflictError) StoredVersion() int {
+	return ce.storedVersion
+}
+
+// ActualVersion возвращает устаревшую версию, которую пытались сохранить
+func (ce *ConflictError) ActualVersion() int {
+	return ce.actualVersion
+}
+
+// NewConflictError создает ошибку конфликта версий
+func NewConflictError(resource, id string, storedVersion, actualVersion int) *ConflictError {
+	return &ConflictError{
+		resource:      resource,
+		id:            id,
+		storedVersion: storedVersion,
+		actualVersion: actualVersion,
+	}
+}
+
+// IsDomainError проверяет, является ли ошибка (или любая в ее цепочке) доменной
 func IsDomainError(err error) bool {
-	_, ok := err.(*DomainError)
-	return ok
+	return stderrors.Is(err, ErrDomain)
 }
 
-// IsValidationError проверяет, является ли ошибка валидационной
+// IsValidationError проверяет, является ли ошибка (или любая в ее цепочке) валидационной
 func IsValidationError(err error) bool {
-	_, ok := err.(*ValidationError)
-	return ok
+	return stderrors.Is(err, ErrValidation)
 }
 
-// IsNotFoundError проверяет, является ли ошибка "не найдено"
+// IsNotFoundError проверяет, является ли ошибка (или любая в ее цепочке) "не найдено"
 func IsNotFoundError(err error) bool {
-	_, ok := err.(*NotFoundError)
-	return ok
+	return stderrors.Is(err, ErrNotFound)
+}
+
+// IsConflictError проверяет, является ли ошибка (или любая в ее цепочке) конфликтом версий
+func IsConflictError(err error) bool {
+	return stderrors.Is(err, ErrConflict)
 }
@@ -0,0 +1,149 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+)
+
+func TestSentinels_MatchThroughWrappedChains(t *testing.T) {
+	notFound := NewNotFoundError("task", "task-1")
+
+	tests := []struct {
+		name     string
+		err      error
+		target   error
+		expected bool
+	}{
+		{"domain error", NewDomainError("bad state"), ErrDomain, true},
+		{"validation error", NewValidationError("keyTask", "empty"), ErrValidation, true},
+		{"not found error", notFound, ErrNotFound, true},
+		{"fmt wrapped not found", fmt.Errorf("load: %w", notFound), ErrNotFound, true},
+		{"domain wrap exposes cause", NewDomainErrorWrap("cannot load task", notFound), ErrNotFound, true},
+		{"domain wrap is domain", NewDomainErrorWrap("cannot load task", notFound), ErrDomain, true},
+		{"double wrapped", fmt.Errorf("handler: %w", NewDomainErrorWrap("cannot load task", notFound)), ErrNotFound, true},
+		{"different kind", NewDomainError("bad state"), ErrNotFound, false},
+		{"plain error", stderrors.New("boom"), ErrDomain, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if stderrors.Is(tt.err, tt.target) != tt.expected {
+				t.Errorf("Expected errors.Is(%v, %v) to be %v", tt.err, tt.target, tt.expected)
+			}
+		})
+	}
+}
+
+func TestErrorsAs_ThroughWrappedChain(t *testing.T) {
+	err := fmt.Errorf("handler: %w", NewDomainErrorWrap("cannot load task", NewNotFoundError("task", "task-1")))
+
+	var domainErr *DomainError
+	if !stderrors.As(err, &domainErr) || domainErr.Message() != "cannot load task" {
+		t.Errorf("Expected to extract DomainError, got %v", domainErr)
+	}
+
+	var notFoundErr *NotFoundError
+	if !stderrors.As(err, &notFoundErr) || notFoundErr.ID() != "task-1" {
+		t.Errorf("Expected to extract NotFoundError, got %v", notFoundErr)
+	}
+
+	if err.Error() != "handler: cannot load task: task with id 'task-1' not found" {
+		t.Errorf("Expected cause in message, got %q", err.Error())
+	}
+}
+
+func TestIsHelpers_KeepWorking(t *testing.T) {
+	if !IsDomainError(NewDomainError("bad state")) {
+		t.Error("Expected IsDomainError for DomainError")
+	}
+
+	if !IsNotFoundError(fmt.Errorf("wrapped: %w", NewNotFoundError("task", "task-1"))) {
+		t.Error("Expected IsNotFoundError through wrapping")
+	}
+
+	if !IsValidationError(NewValidationError("keyTask", "empty")) {
+		t.Error("Expected IsValidationError for ValidationError")
+	}
+
+	if IsDomainError(nil) || IsNotFoundError(NewDomainError("bad state")) {
+		t.Error("Expected helpers to reject unrelated errors")
+	}
+}
+
+func TestConflictError_MatchesSentinel(t *testing.T) {
+	err := fmt.Errorf("save: %w", NewConflictError("task", "task-1", 3, 2))
+
+	if !IsConflictError(err) {
+		t.Error("Expected IsConflictError through wrapping")
+	}
+
+	if IsNotFoundError(err) || IsConflictError(NewDomainError("bad state")) {
+		t.Error("Expected conflict to be distinct from other kinds")
+	}
+
+	var conflictErr *ConflictError
+	if !stderrors.As(err, &conflictErr) {
+		t.Fatal("Expected to extract ConflictError")
+	}
+	if conflictErr.StoredVersion() != 3 || conflictErr.ActualVersion() != 2 {
+		t.Errorf("Expected versions 3/2, got %d/%d", conflictErr.StoredVersion(), conflictErr.ActualVersion())
+	}
+
+	expected := "save: task with id 'task-1' was modified concurrently: stored version 3, got 2"
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestValidationError_Value(t *testing.T) {
+	withValue := NewValidationErrorWithValue("stressLevel", "must be between 0 and 10", 11)
+
+	if withValue.Value() != 11 {
+		t.Errorf("Expected value 11, got %v", withValue.Value())
+	}
+
+	expected := "validation error for field 'stressLevel': must be between 0 and 10 (got 11)"
+	if withValue.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, withValue.Error())
+	}
+
+	withoutValue := NewValidationError("keyTask", "cannot be empty")
+	if withoutValue.Value() != nil {
+		t.Errorf("Expected nil value, got %v", withoutValue.Value())
+	}
+
+	expected = "validation error for field 'keyTask': cannot be empty"
+	if withoutValue.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, withoutValue.Error())
+	}
+}
+
+func TestDomainErrorWithCodeWrap_KeepsValidationValue(t *testing.T) {
+	err := NewDomainErrorWithCodeWrap("stress level must be between 0 and 10", CodeInvalidRange,
+		NewValidationErrorWithValue("stressLevel", "out of range", 11))
+
+	if !IsDomainError(err) || !IsValidationError(err) {
+		t.Errorf("Expected both DomainError and ValidationError, got %v", err)
+	}
+
+	if err.Code() != CodeInvalidRange.String() {
+		t.Errorf("Expected code %s, got %s", CodeInvalidRange, err.Code())
+	}
+
+	var validation *ValidationError
+	if !stderrors.As(err, &validation) || validation.Value() != 11 {
+		t.Errorf("Expected wrapped value 11, got %v", err)
+	}
+}
+
+func TestDomainError_Code(t *testing.T) {
+	if code := NewDomainError("bad state").Code(); code != "DOMAIN_ERROR" {
+		t.Errorf("Expected default code DOMAIN_ERROR, got %s", code)
+	}
+
+	err := NewDomainErrorWithCode("task already started", CodeAlreadyStarted)
+	if err.Code() != "ALREADY_STARTED" || err.Code() != CodeAlreadyStarted.String() {
+		t.Errorf("Expected code ALREADY_STARTED, got %s", err.Code())
+	}
+}
@@ -0,0 +1,29 @@
+package errors
+
+// ErrorCode машиночитаемый код доменной ошибки для программной обработки
+//
+//	var de *errors.DomainError
+//	if stderrors.As(err, &de) && de.Code() == string(errors.CodeAlreadyStarted) { ... }
+type ErrorCode string
+
+const (
+	CodeDomainError        ErrorCode = "DOMAIN_ERROR"         // Код по умолчанию, без уточнения
+	CodeInvalidRange       ErrorCode = "INVALID_RANGE"        // Значение вне допустимого диапазона
+	CodeInvalidValue       ErrorCode = "INVALID_VALUE"        // Значение не из допустимого набора
+	CodeInvalidFormat      ErrorCode = "INVALID_FORMAT"       // Значение не удалось разобрать
+	CodeEmptyField         ErrorCode = "EMPTY_FIELD"          // Обязательное значение не задано
+	CodeDuplicate          ErrorCode = "DUPLICATE"            // Значение уже существует
+	CodeOverlap            ErrorCode = "OVERLAP"              // Интервалы времени пересекаются
+	CodeAlreadyStarted     ErrorCode = "ALREADY_STARTED"      // Задача уже начата
+	CodeNotStarted         ErrorCode = "NOT_STARTED"          // Задача еще не начата
+	CodeAlreadyPaused      ErrorCode = "ALREADY_PAUSED"       // Задача уже на паузе
+	CodeNotPaused          ErrorCode = "NOT_PAUSED"           // Задача не на паузе
+	CodeAlreadyCompleted   ErrorCode = "ALREADY_COMPLETED"    // Задача или подзадача уже завершена
+	CodeInvalidEventStream ErrorCode = "INVALID_EVENT_STREAM" // Поток событий не восстанавливает агрегат
+	CodeHandlerPanic       ErrorCode = "HANDLER_PANIC"        // Обработчик события завершился паникой
+)
+
+// String возвращает строковую форму кода
+func (c ErrorCode) String() string {
+	return string(c)
+}
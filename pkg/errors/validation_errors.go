@@ -0,0 +1,70 @@
+package errors
+
+import "strings"
+
+// ValidationErrors собирает все нарушения инвариантов, чтобы сообщить о них разом,
+// а не только о первом
+type ValidationErrors struct {
+	errors []*ValidationError
+}
+
+// NewValidationErrors создает пустой набор ошибок валидации
+func NewValidationErrors() *ValidationErrors {
+	return &ValidationErrors{}
+}
+
+// Error объединяет сообщения всех ошибок
+func (ves *ValidationErrors) Error() string {
+	messages := make([]string, 0, len(ves.errors))
+	for _, ve := range ves.errors {
+		messages = append(messages, ve.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Is позволяет сравнивать с ErrValidation через errors.Is
+func (ves *ValidationErrors) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Unwrap возвращает отдельные ошибки, чтобы errors.As находил *ValidationError
+func (ves *ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(ves.errors))
+	for _, ve := range ves.errors {
+		errs = append(errs, ve)
+	}
+	return errs
+}
+
+// Add добавляет ошибку валидации поля
+func (ves *ValidationErrors) Add(field, message string) {
+	ves.errors = append(ves.errors, NewValidationError(field, message))
+}
+
+// HasErrors проверяет, есть ли накопленные ошибки
+func (ves *ValidationErrors) HasErrors() bool {
+	return len(ves.errors) > 0
+}
+
+// Errors возвращает копию списка ошибок в порядке добавления
+func (ves *ValidationErrors) Errors() []*ValidationError {
+	return append([]*ValidationError(nil), ves.errors...)
+}
+
+// ErrorsByField группирует сообщения об ошибках по полям
+func (ves *ValidationErrors) ErrorsByField() map[string][]string {
+	byField := make(map[string][]string)
+	for _, ve := range ves.errors {
+		byField[ve.field] = append(byField[ve.field], ve.message)
+	}
+	return byField
+}
+
+// Err возвращает сам набор, если в нем есть ошибки, иначе nil
+// Избавляет от ловушки с типизированным nil при возврате как error
+func (ves *ValidationErrors) Err() error {
+	if !ves.HasErrors() {
+		return nil
+	}
+	return ves
+}
@@ -0,0 +1,43 @@
+package errors
+
+import (
+	stderrors "errors"
+	"testing"
+)
+
+func TestValidationErrors_CollectsAll(t *testing.T) {
+	ves := NewValidationErrors()
+	if ves.HasErrors() || ves.Err() != nil {
+		t.Fatal("Expected empty set to have no errors")
+	}
+
+	ves.Add("keyTask", "cannot be empty")
+	ves.Add("dayNumber", "must be positive")
+	ves.Add("keyTask", "too short")
+
+	if !ves.HasErrors() || len(ves.Errors()) != 3 {
+		t.Fatalf("Expected 3 errors, got %d", len(ves.Errors()))
+	}
+
+	expected := "validation error for field 'keyTask': cannot be empty; " +
+		"validation error for field 'dayNumber': must be positive; " +
+		"validation error for field 'keyTask': too short"
+	if ves.Error() != expected {
+		t.Errorf("Expected joined message %q, got %q", expected, ves.Error())
+	}
+
+	byField := ves.ErrorsByField()
+	if len(byField["keyTask"]) != 2 || len(byField["dayNumber"]) != 1 {
+		t.Errorf("Expected 2 keyTask and 1 dayNumber errors, got %v", byField)
+	}
+
+	err := ves.Err()
+	if !stderrors.Is(err, ErrValidation) || !IsValidationError(err) {
+		t.Error("Expected ValidationErrors to match ErrValidation")
+	}
+
+	var fieldErr *ValidationError
+	if !stderrors.As(err, &fieldErr) || fieldErr.Field() != "keyTask" {
+		t.Errorf("Expected errors.As to find first field error, got %v", fieldErr)
+	}
+}
@@ -0,0 +1,33 @@
+// Package uuid генерирует случайные UUID версии 4 (RFC 4122)
+// Используем crypto/rand, чтобы не тянуть внешнюю зависимость
+package uuid
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// NewV4 возвращает случайный UUID версии 4 в каноническом виде xxxxxxxx-xxxx-4xxx-yxxx-xxxxxxxxxxxx
+func NewV4() string {
+	var uuid [16]byte
+	if _, err := rand.Read(uuid[:]); err != nil {
+		// crypto/rand не возвращает ошибок на поддерживаемых платформах
+		panic("uuid: failed to read random bytes: " + err.Error())
+	}
+
+	uuid[6] = (uuid[6] & 0x0f) | 0x40 // версия 4
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // вариант RFC 4122
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], uuid[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], uuid[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], uuid[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], uuid[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], uuid[10:])
+
+	return string(buf[:])
+}
@@ -0,0 +1,16 @@
+package uuid
+
+import (
+	"regexp"
+	"testing"
+)
+
+var v4Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestNewV4_Format(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if id := NewV4(); !v4Pattern.MatchString(id) {
+			t.Fatalf("Expected RFC 4122 v4 UUID, got %q", id)
+		}
+	}
+}